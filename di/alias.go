@@ -0,0 +1,79 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterNamedAlias makes the name alias of T refer to the registration named
+// target, looked up at resolution time.
+//
+// Application code resolves the alias, for example "active", while
+// configuration decides which named registration it points to. Registering
+// the alias again points it at another target; the switch takes effect for
+// the next resolution, since the alias caches nothing itself and each target
+// keeps its own cached instances. Aliases may point to other aliases.
+//
+// A registration made under the alias name itself takes precedence over the
+// alias. Returns [ErrInvalidName] if alias is empty or equal to target, and,
+// in strict mode (see [WithStrictMode]), [ErrDuplicateRegistration] if a
+// registration already uses the alias name. The target does not need to be
+// registered yet; resolving an alias whose target is missing fails with
+// [ErrNotRegistered] naming the target.
+//
+// Example:
+//
+//	di.Register[Store](c, newPostgresStore, di.AsSingleton(), di.WithName("postgres"))
+//	di.Register[Store](c, newSQLiteStore, di.AsSingleton(), di.WithName("sqlite"))
+//	di.RegisterNamedAlias[Store](c, "active", cfg.StoreBackend)
+//
+//	store, _ := di.ResolveNamed[Store](c, "active")
+func RegisterNamedAlias[T any](c *Container, alias, target string) error {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	switch {
+	case alias == "":
+		return ErrInvalidName{Type: targetType, Name: alias, Reason: "alias must not be empty"}
+	case alias == target:
+		return ErrInvalidName{Type: targetType, Name: alias, Reason: "alias must not refer to itself"}
+	}
+	if c.namingPolicy != nil {
+		if err := c.namingPolicy(alias); err != nil {
+			return ErrInvalidName{Type: targetType, Name: alias, Reason: err.Error()}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := registrationKey{typ: targetType, name: alias}
+	if _, exists := c.registrations[key]; exists && c.strict {
+		return ErrDuplicateRegistration{Type: targetType, Name: alias}
+	}
+	c.aliases[key] = target
+	return nil
+}
+
+// followAlias returns the name an alias of typ finally refers to, following
+// aliases of aliases, and whether name is an alias at all. Names with a
+// registration of their own are not followed. The caller must hold c.mu.
+func (c *Container) followAlias(typ reflect.Type, name string) (string, bool, error) {
+	seen := map[string]bool{name: true}
+	aliased := false
+	for {
+		key := registrationKey{typ: typ, name: name}
+		if _, registered := c.registrations[key]; registered {
+			return name, aliased, nil
+		}
+		target, ok := c.aliases[key]
+		if !ok {
+			return name, aliased, nil
+		}
+		if seen[target] {
+			return "", true, fmt.Errorf("alias cycle through %q", target)
+		}
+		seen[target] = true
+		name, aliased = target, true
+	}
+}
@@ -0,0 +1,85 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Named Alias Tests
+// =============================================================================
+
+func TestNamedAliasResolvesTarget(t *testing.T) {
+	c := di.New()
+	postgres := &TestLogger{Messages: []string{"postgres"}}
+	sqlite := &TestLogger{Messages: []string{"sqlite"}}
+	di.RegisterInstance[Logger](c, postgres, di.WithName("postgres"))
+	di.RegisterInstance[Logger](c, sqlite, di.WithName("sqlite"))
+
+	if err := di.RegisterNamedAlias[Logger](c, "active", "postgres"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := di.ResolveNamed[Logger](c, "active"); got != postgres {
+		t.Errorf("expected the alias to resolve postgres, got %v", got)
+	}
+	if !di.HasNamed[Logger](c, "active") {
+		t.Error("expected HasNamed to follow the alias")
+	}
+
+	di.RegisterNamedAlias[Logger](c, "active", "sqlite")
+	if got, _ := di.ResolveNamed[Logger](c, "active"); got != sqlite {
+		t.Errorf("expected the switched alias to resolve sqlite, got %v", got)
+	}
+}
+
+func TestNamedAliasChainsAndCycles(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("simple"))
+	di.RegisterNamedAlias[Greeter](c, "default", "simple")
+	di.RegisterNamedAlias[Greeter](c, "active", "default")
+
+	if _, err := di.ResolveNamed[Greeter](c, "active"); err != nil {
+		t.Errorf("expected an alias of an alias to resolve, got %v", err)
+	}
+
+	di.RegisterNamedAlias[Greeter](c, "ping", "pong")
+	di.RegisterNamedAlias[Greeter](c, "pong", "ping")
+	if _, err := di.ResolveNamed[Greeter](c, "ping"); err == nil {
+		t.Error("expected an alias cycle to fail")
+	}
+	if di.HasNamed[Greeter](c, "ping") {
+		t.Error("expected an alias cycle not to count as registered")
+	}
+}
+
+func TestNamedAliasMissingTarget(t *testing.T) {
+	c := di.New()
+	di.RegisterNamedAlias[Greeter](c, "active", "missing")
+
+	_, err := di.ResolveNamed[Greeter](c, "active")
+	var notRegistered di.ErrNotRegistered
+	if !errors.As(err, &notRegistered) || notRegistered.Name != "missing" {
+		t.Errorf("expected ErrNotRegistered naming the target, got %v", err)
+	}
+}
+
+func TestNamedAliasRejectsInvalidNames(t *testing.T) {
+	c := di.New(di.WithStrictMode())
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("simple"))
+
+	var invalid di.ErrInvalidName
+	if err := di.RegisterNamedAlias[Greeter](c, "", "simple"); !errors.As(err, &invalid) {
+		t.Errorf("expected ErrInvalidName for an empty alias, got %v", err)
+	}
+	if err := di.RegisterNamedAlias[Greeter](c, "simple", "simple"); !errors.As(err, &invalid) {
+		t.Errorf("expected ErrInvalidName for a self alias, got %v", err)
+	}
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("other"))
+	if err := di.RegisterNamedAlias[Greeter](c, "other", "simple"); !errors.As(err, new(di.ErrDuplicateRegistration)) {
+		t.Errorf("expected ErrDuplicateRegistration in strict mode, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !di_noreflect
+
+package di
+
+import "reflect"
+
+// reflectiveFactories reports whether factories may be called through
+// reflection. The di_noreflect build tag turns it off (see [Register1]).
+const reflectiveFactories = true
+
+// callFunc calls fn with args through reflection.
+func callFunc(fn reflect.Value, args []reflect.Value) []reflect.Value {
+	return fn.Call(args)
+}
+
+// makeFunc returns a function of type typ that wraps impl.
+func makeFunc(typ reflect.Type, impl func(args []reflect.Value) []reflect.Value) reflect.Value {
+	return reflect.MakeFunc(typ, impl)
+}
@@ -0,0 +1,21 @@
+//go:build di_noreflect
+
+package di
+
+import "reflect"
+
+// reflectiveFactories reports whether factories may be called through
+// reflection. The di_noreflect build tag turns it off (see [Register1]).
+const reflectiveFactories = false
+
+// callFunc is unreachable in di_noreflect builds: every entry point that
+// would call a function through reflection returns [ErrInvalidFactory]
+// first. It panics rather than linking reflect.Value.Call.
+func callFunc(reflect.Value, []reflect.Value) []reflect.Value {
+	panic("di: " + errReflectionDisabled)
+}
+
+// makeFunc is unreachable in di_noreflect builds (see callFunc).
+func makeFunc(reflect.Type, func([]reflect.Value) []reflect.Value) reflect.Value {
+	panic("di: " + errReflectionDisabled)
+}
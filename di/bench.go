@@ -0,0 +1,135 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// benchmarkIterations is the number of warm resolutions Benchmark measures
+// per root.
+const benchmarkIterations = 1000
+
+// BenchmarkResult holds the measurements [Benchmark] took for one root type.
+type BenchmarkResult struct {
+	// Type is the root type.
+	Type reflect.Type
+	// Lifetime is the root registration's lifetime, which determines what a
+	// warm resolution costs: a cache lookup for singletons and scoped
+	// dependencies, a full construction for transients.
+	Lifetime Lifetime
+	// Cold is the duration of the first resolution.
+	Cold time.Duration
+	// ColdAllocs is the number of heap allocations of the first resolution.
+	ColdAllocs uint64
+	// Warm is the average duration of the subsequent resolutions.
+	Warm time.Duration
+	// WarmAllocs is the average number of heap allocations of the subsequent
+	// resolutions.
+	WarmAllocs float64
+}
+
+// BenchmarkReport is the result of [Benchmark].
+type BenchmarkReport struct {
+	// Results holds one entry per root, in the order the roots were given.
+	Results []BenchmarkResult
+	// Iterations is the number of warm resolutions measured per root.
+	Iterations int
+}
+
+// String renders the report as a table.
+func (r BenchmarkReport) String() string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tLIFETIME\tCOLD\tCOLD ALLOCS\tWARM\tWARM ALLOCS")
+	for _, res := range r.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%.1f\n",
+			res.Type, res.Lifetime, res.Cold, res.ColdAllocs, res.Warm, res.WarmAllocs)
+	}
+	tw.Flush()
+	return b.String()
+}
+
+// Benchmark measures cold and warm resolution times and allocation counts for
+// the given root types, so teams can track the container's overhead for their
+// real dependency graphs.
+//
+// Each root is resolved in a fresh scope: the first resolution is the cold
+// measurement, and the following resolutions in the same scope are averaged
+// into the warm measurement. Singletons constructed by the cold resolution
+// stay cached, so benchmark a freshly built container to measure cold start
+// costs; roots sharing dependencies with earlier roots measure warmer. The
+// scope is disposed afterwards. Allocation counts are process-wide and include
+// allocations by other goroutines.
+//
+// Resolutions are recorded in [Container.Stats] as usual. Benchmark stops at
+// the first root that fails to resolve and returns its error.
+//
+// Example:
+//
+//	report, err := di.Benchmark(container,
+//	    reflect.TypeOf((*http.Handler)(nil)).Elem(),
+//	    reflect.TypeOf((*Worker)(nil)))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Print(report)
+func Benchmark(c *Container, roots ...reflect.Type) (BenchmarkReport, error) {
+	report := BenchmarkReport{Iterations: benchmarkIterations}
+	for _, root := range roots {
+		result, err := c.benchmark(root)
+		if err != nil {
+			return report, err
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+// benchmark measures one root.
+func (c *Container) benchmark(root reflect.Type) (result BenchmarkResult, err error) {
+	result.Type = root
+	c.mu.RLock()
+	if reg, ok := c.registrations[registrationKey{typ: root}]; ok {
+		result.Lifetime = reg.lifetime
+	}
+	c.mu.RUnlock()
+
+	scope := newScope("benchmark", c)
+	defer func() {
+		err = errors.Join(err, scope.Dispose())
+	}()
+
+	resolve := func() error {
+		_, err := c.resolve(context.Background(), root, "", scope, make([]reflect.Type, 0))
+		return err
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	if err := resolve(); err != nil {
+		return result, err
+	}
+	result.Cold = time.Since(start)
+	runtime.ReadMemStats(&after)
+	result.ColdAllocs = after.Mallocs - before.Mallocs
+
+	runtime.ReadMemStats(&before)
+	start = time.Now()
+	for range benchmarkIterations {
+		if err := resolve(); err != nil {
+			return result, err
+		}
+	}
+	result.Warm = time.Since(start) / benchmarkIterations
+	runtime.ReadMemStats(&after)
+	result.WarmAllocs = float64(after.Mallocs-before.Mallocs) / benchmarkIterations
+
+	return result, nil
+}
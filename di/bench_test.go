@@ -0,0 +1,59 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Benchmark Helper Tests
+// =============================================================================
+
+func TestBenchmark(t *testing.T) {
+	c := di.New()
+	constructed := 0
+	di.Register[Logger](c, func() Logger {
+		constructed++
+		return &TestLogger{}
+	}, di.AsSingleton())
+	di.Register[Service](c, func(logger Logger) Service {
+		return &DefaultService{logger: logger}
+	})
+
+	loggerType := reflect.TypeOf((*Logger)(nil)).Elem()
+	serviceType := reflect.TypeOf((*Service)(nil)).Elem()
+	report, err := di.Benchmark(c, loggerType, serviceType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Results) != 2 || report.Iterations == 0 {
+		t.Fatalf("expected two results, got %+v", report)
+	}
+	logger, service := report.Results[0], report.Results[1]
+	if logger.Type != loggerType || logger.Lifetime != di.Singleton {
+		t.Errorf("unexpected logger result: %+v", logger)
+	}
+	if service.Type != serviceType || service.Lifetime != di.Transient {
+		t.Errorf("unexpected service result: %+v", service)
+	}
+	if constructed != 1 {
+		t.Errorf("expected singleton to be constructed once, got %d", constructed)
+	}
+
+	if out := report.String(); !strings.Contains(out, "COLD ALLOCS") || !strings.Contains(out, "di_test.Service") {
+		t.Errorf("unexpected report output:\n%s", out)
+	}
+}
+
+func TestBenchmarkUnregisteredRoot(t *testing.T) {
+	c := di.New()
+	if _, err := di.Benchmark(c, reflect.TypeOf((*Logger)(nil)).Elem()); err == nil {
+		t.Error("expected error for unregistered root")
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !di_noreflect
+
 package di_test
 
 import (
@@ -241,6 +243,30 @@ func BenchmarkResolveDeepDependencyChain(b *testing.B) {
 	}
 }
 
+func BenchmarkResolveDeepTransientChain(b *testing.B) {
+	c := di.New()
+
+	// The same chain, constructed in full on every resolution
+	type Level1 interface{ L1() }
+	type Level2 interface{ L2() }
+	type Level3 interface{ L3() }
+	type Level4 interface{ L4() }
+	type Level5 interface{ L5() }
+
+	di.Register[Level1](c, func() Level1 { return &level1Impl{} })
+	di.Register[Level2](c, func(l1 Level1) Level2 { return &level2Impl{} })
+	di.Register[Level3](c, func(l2 Level2) Level3 { return &level3Impl{} })
+	di.Register[Level4](c, func(l3 Level3) Level4 { return &level4Impl{} })
+	di.Register[Level5](c, func(l4 Level4) Level5 { return &level5Impl{} })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = di.Resolve[Level5](c)
+	}
+}
+
 type level1Impl struct{}
 type level2Impl struct{}
 type level3Impl struct{}
@@ -0,0 +1,70 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Bridge exposes the singleton T of from as a registration of T in to, so a
+// process embedding several frameworks that each own a container can share
+// one instance between them.
+//
+// The instance, not the factory, is shared: the first resolution of T in to
+// resolves it from from, constructing it there if needed, and to caches the
+// same instance as a singleton. from owns the instance, so closing to leaves
+// it open and closing from disposes it. opts apply to the registration in to,
+// for example to name it.
+//
+// Returns [ErrNotRegistered] if from has no unnamed registration of T, and an
+// error if that registration is not a singleton, since sharing a transient or
+// scoped instance across containers would change its lifetime.
+//
+// Example:
+//
+//	platform := di.New()
+//	di.Register[*sql.DB](platform, openDB, di.AsSingleton())
+//
+//	legacy := di.New()
+//	if err := di.Bridge[*sql.DB](platform, legacy); err != nil {
+//	    log.Fatal(err)
+//	}
+func Bridge[T any](from, to *Container, opts ...RegistrationOption) error {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	from.mu.RLock()
+	source, exists := from.registrations[registrationKey{typ: targetType}]
+	from.mu.RUnlock()
+	if !exists {
+		return ErrNotRegistered{Type: targetType}
+	}
+	if source.lifetime != Singleton {
+		return fmt.Errorf("di: cannot bridge %s: it is %s, not a singleton", targetType, source.lifetime)
+	}
+
+	factory := func(ctx context.Context) (T, error) {
+		return ResolveCtx[T](ctx, from)
+	}
+	reg := &registration{
+		targetType: targetType,
+		factory:    factory,
+		typed: func(args []any) (any, error) {
+			ctx, _ := args[0].(context.Context)
+			return factory(ctx)
+		},
+		lifetime: Singleton,
+		borrowed: true,
+	}
+	for _, opt := range opts {
+		opt(reg)
+	}
+	reg.lifetime = Singleton
+	if err := to.checkRegistration(reg); err != nil {
+		return err
+	}
+
+	to.mu.Lock()
+	defer to.mu.Unlock()
+	return to.addRegistration(reg)
+}
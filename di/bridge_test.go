@@ -0,0 +1,58 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Bridge Tests
+// =============================================================================
+
+func TestBridgeSharesSingleton(t *testing.T) {
+	from := di.New()
+	constructed := 0
+	di.Register[*closableResource](from, func() *closableResource {
+		constructed++
+		return &closableResource{}
+	}, di.AsSingleton())
+
+	to := di.New()
+	if err := di.Bridge[*closableResource](from, to, di.WithName("shared")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bridged := di.MustResolveNamed[*closableResource](to, "shared")
+	if own := di.MustResolve[*closableResource](from); own != bridged || constructed != 1 {
+		t.Error("expected both containers to share one instance")
+	}
+
+	if err := to.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bridged.closed.Load() {
+		t.Error("expected the target container to leave the instance open")
+	}
+	from.Close(context.Background())
+	if !bridged.closed.Load() {
+		t.Error("expected the source container to dispose the instance")
+	}
+}
+
+func TestBridgeRequiresSingleton(t *testing.T) {
+	from := di.New()
+	di.Register[Greeter](from, func() Greeter { return &SimpleGreeter{} })
+
+	if err := di.Bridge[Greeter](from, di.New()); err == nil {
+		t.Error("expected bridging a transient to fail")
+	}
+	var notRegistered di.ErrNotRegistered
+	if err := di.Bridge[Logger](from, di.New()); !errors.As(err, &notRegistered) {
+		t.Errorf("expected ErrNotRegistered, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package di
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Names of the resources limited by a ScopeBudget, as reported in
+// ErrBudgetExceeded.
+const (
+	budgetInstances        = "instances"
+	budgetConstructionTime = "construction time"
+)
+
+// ScopeBudget limits the resources a scope may spend constructing instances.
+//
+// Budgets protect servers from pathological per-request object graphs, for
+// example in multi-tenant servers where one tenant's configuration could
+// otherwise make every request construct thousands of objects.
+type ScopeBudget struct {
+	// MaxInstances is the total weight of instances the scope may construct.
+	// Each instance weighs 1 unless its registration sets a weight with
+	// [WithWeight]. Zero means no limit.
+	MaxInstances int
+	// MaxConstructionTime is the total time the scope's factories may run,
+	// excluding the resolution of their dependencies. A construction that
+	// starts within the budget is allowed to finish; constructions after the
+	// budget is spent are refused. Zero means no limit.
+	MaxConstructionTime time.Duration
+}
+
+// ScopeUsage reports the resources a scope has spent against its budget.
+type ScopeUsage struct {
+	// Instances is the total weight of instances constructed.
+	Instances int
+	// ConstructionTime is the total time spent in factories.
+	ConstructionTime time.Duration
+}
+
+// scopeBudget tracks a scope's usage against its budget.
+type scopeBudget struct {
+	limits    ScopeBudget
+	instances atomic.Int64
+	nanos     atomic.Int64
+}
+
+// WithWeight sets how much each instance of the registration counts against
+// a scope's instance budget (see [ScopeBudget]). The default weight is 1.
+//
+// Example:
+//
+//	di.Register[*ReportBuilder](c, NewReportBuilder, di.WithWeight(10))
+func WithWeight(weight int) RegistrationOption {
+	return func(r *registration) {
+		r.weight = weight
+	}
+}
+
+// SetBudget limits the resources spent constructing instances in the scope.
+//
+// Every scoped and transient instance constructed while resolving in the
+// scope counts against the budget; singletons are shared and do not. When a
+// construction would exceed the budget, resolution fails with
+// [ErrBudgetExceeded]. Scopes forked from this one with [Scope.Fork] share its
+// budget. Setting a budget resets the scope's usage.
+//
+// Example:
+//
+//	scope := container.CreateScope("request-" + requestID)
+//	scope.SetBudget(di.ScopeBudget{MaxInstances: 500, MaxConstructionTime: 50 * time.Millisecond})
+func (s *Scope) SetBudget(budget ScopeBudget) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.budget = &scopeBudget{limits: budget}
+}
+
+// Usage reports the resources the scope has spent against its budget. It
+// reports zero usage if the scope has no budget.
+func (s *Scope) Usage() ScopeUsage {
+	budget := s.currentBudget()
+	if budget == nil {
+		return ScopeUsage{}
+	}
+	return budget.usage()
+}
+
+// currentBudget returns the scope's budget, or nil if it has none.
+func (s *Scope) currentBudget() *scopeBudget {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.budget
+}
+
+// usage returns the budget's current usage.
+func (b *scopeBudget) usage() ScopeUsage {
+	return ScopeUsage{
+		Instances:        int(b.instances.Load()),
+		ConstructionTime: time.Duration(b.nanos.Load()),
+	}
+}
+
+// charge reserves the weight of one instance of reg for the scope named
+// scopeName, failing if the budget is spent.
+func (b *scopeBudget) charge(scopeName string, reg *registration) error {
+	exceeded := func(resource string) error {
+		return ErrBudgetExceeded{
+			Scope:    scopeName,
+			Type:     reg.targetType,
+			Resource: resource,
+			Budget:   b.limits,
+			Usage:    b.usage(),
+		}
+	}
+
+	if limit := b.limits.MaxConstructionTime; limit > 0 && time.Duration(b.nanos.Load()) >= limit {
+		return exceeded(budgetConstructionTime)
+	}
+	weight := int64(reg.instanceWeight())
+	used := b.instances.Add(weight)
+	if limit := b.limits.MaxInstances; limit > 0 && used > int64(limit) {
+		b.instances.Add(-weight)
+		return exceeded(budgetInstances)
+	}
+	return nil
+}
+
+// refund returns the weight reserved for a construction that failed.
+func (b *scopeBudget) refund(reg *registration) {
+	b.instances.Add(-int64(reg.instanceWeight()))
+}
+
+// spend records time spent in a factory.
+func (b *scopeBudget) spend(d time.Duration) {
+	b.nanos.Add(int64(d))
+}
+
+// instanceWeight returns the weight of one instance against scope budgets.
+func (r *registration) instanceWeight() int {
+	if r.weight <= 0 {
+		return 1
+	}
+	return r.weight
+}
@@ -0,0 +1,101 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Scope Budget Tests
+// =============================================================================
+
+func TestScopeBudgetMaxInstances(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton())
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.Register[Service](c, func(l Logger, g Greeter) Service {
+		return &DefaultService{logger: l, greeter: g}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request-1")
+	defer scope.Dispose()
+	scope.SetBudget(di.ScopeBudget{MaxInstances: 3})
+
+	if _, err := di.ResolveInScope[Service](c, scope); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage := scope.Usage(); usage.Instances != 2 {
+		t.Errorf("expected the service and greeter to count but not the singleton, got %+v", usage)
+	}
+
+	di.ResolveInScope[Greeter](c, scope)
+	_, err := di.ResolveInScope[Greeter](c, scope)
+	var exceeded di.ErrBudgetExceeded
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	if exceeded.Scope != "request-1" || exceeded.Resource != "instances" || exceeded.Usage.Instances != 3 {
+		t.Errorf("unexpected error details %+v", exceeded)
+	}
+}
+
+func TestScopeBudgetWeights(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithWeight(5))
+
+	scope := c.CreateScope("request-1")
+	defer scope.Dispose()
+	scope.SetBudget(di.ScopeBudget{MaxInstances: 8})
+
+	if _, err := di.ResolveInScope[Greeter](c, scope); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := di.ResolveInScope[Greeter](c, scope); err == nil {
+		t.Error("expected the second weighted instance to exceed the budget")
+	}
+	if usage := scope.Usage(); usage.Instances != 5 {
+		t.Errorf("expected the refused instance not to be charged, got %+v", usage)
+	}
+}
+
+func TestScopeBudgetConstructionTime(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter {
+		time.Sleep(5 * time.Millisecond)
+		return &SimpleGreeter{}
+	})
+
+	scope := c.CreateScope("request-1")
+	defer scope.Dispose()
+	scope.SetBudget(di.ScopeBudget{MaxConstructionTime: time.Millisecond})
+
+	if _, err := di.ResolveInScope[Greeter](c, scope); err != nil {
+		t.Fatalf("expected the first construction to finish, got %v", err)
+	}
+	_, err := di.ResolveInScope[Greeter](c, scope)
+	var exceeded di.ErrBudgetExceeded
+	if !errors.As(err, &exceeded) || exceeded.Resource != "construction time" {
+		t.Errorf("expected a construction time budget error, got %v", err)
+	}
+}
+
+func TestScopeBudgetSharedWithForks(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+
+	scope := c.CreateScope("request-1")
+	defer scope.Dispose()
+	scope.SetBudget(di.ScopeBudget{MaxInstances: 1})
+	branch := scope.Fork("request-1/branch")
+	defer branch.Dispose()
+
+	di.ResolveInScope[Greeter](c, branch)
+	if _, err := di.ResolveInScope[Greeter](c, scope); err == nil {
+		t.Error("expected the fork to spend the shared budget")
+	}
+}
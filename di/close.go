@@ -0,0 +1,89 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// Close shuts the container down: it stops hosted services (see
+// [Container.Stop]) and disposes every cached singleton that implements
+// [Disposable] or io.Closer, in reverse construction order, so a singleton is
+// disposed before the singletons it was constructed from. Values registered
+// with [RegisterInstance] are owned by the caller, and singletons bridged in
+// with [Bridge] by their source container, so neither is closed. Instances
+// prewarmed by [WithPrewarm] are disposed first.
+//
+// Services are stopped before any singleton is disposed. If a service fails to
+// stop, each singleton it depends on is reported with a
+// [WarningOutlivedDependency] warning as it is disposed, since the service may
+// still be using it.
+//
+// Closing honors ctx: a service stop or disposal that is still running when
+// ctx is done is abandoned, so a stuck connection close cannot block shutdown
+// forever. The returned error reports every service that failed to stop and
+// every instance that failed to dispose, including abandoned ones; disposal
+// failures are reported as an [ErrDisposeFailed] that names each instance by
+// type and name. Disposed singletons are removed from the cache, so resolving
+// them again constructs new instances.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if err := container.Close(ctx); err != nil {
+//	    log.Printf("shutdown: %v", err)
+//	}
+func (c *Container) Close(ctx context.Context) error {
+	lingering, stopErr := c.stop(ctx)
+
+	var failures []DisposeFailure
+	for _, reg := range c.prewarmedRegistrations() {
+		for _, instance := range reg.drainPrewarmed() {
+			if err := runUntilDone(ctx, func() error { return disposeInstance(instance) }); err != nil {
+				failures = append(failures, DisposeFailure{Type: reg.targetType, Name: reg.name, Err: err})
+			}
+		}
+	}
+	for _, reg := range c.constructedSingletons() {
+		if len(lingering) > 0 {
+			c.emitWarnings(outlivedWarnings(lingering, reg, "the service failed to stop"))
+		}
+		key := registrationKey{typ: reg.targetType, name: reg.name}
+		c.mu.Lock()
+		instance, ok := c.singletons[key]
+		delete(c.singletons, key)
+		if reg.idleTimer != nil {
+			reg.idleTimer.Stop()
+			reg.idleTimer = nil
+		}
+		c.mu.Unlock()
+		if !ok {
+			continue
+		}
+
+		if err := runUntilDone(ctx, func() error { return disposeInstance(instance) }); err != nil {
+			failures = append(failures, DisposeFailure{Type: reg.targetType, Name: reg.name, Err: err})
+		}
+	}
+	return errors.Join(stopErr, disposeFailed(failures))
+}
+
+// constructedSingletons returns the singleton registrations with a cached,
+// constructed instance, most recently constructed first.
+func (c *Container) constructedSingletons() []*registration {
+	var regs []*registration
+	c.mu.RLock()
+	for key, reg := range c.registrations {
+		if reg.lifetime != Singleton || reg.instance != nil || reg.borrowed {
+			continue
+		}
+		if _, ok := c.singletons[key]; ok {
+			regs = append(regs, reg)
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(regs, func(i, j int) bool { return regs[i].constructedAt > regs[j].constructedAt })
+	return regs
+}
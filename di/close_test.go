@@ -0,0 +1,226 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Container Close Tests
+// =============================================================================
+
+// hangingCloser blocks in Close until released.
+type hangingCloser struct{ release chan struct{} }
+
+func (h *hangingCloser) Close() error {
+	<-h.release
+	return nil
+}
+
+// hangingService blocks in Stop until released, ignoring its context.
+type hangingService struct{ release chan struct{} }
+
+func (h *hangingService) Start(ctx context.Context) error { return nil }
+
+func (h *hangingService) Stop(ctx context.Context) error {
+	<-h.release
+	return nil
+}
+
+func TestCloseDisposesSingletons(t *testing.T) {
+	c := di.New()
+	resource := &closableResource{}
+	registered := &closableResource{}
+	di.Register[*closableResource](c, func() *closableResource { return resource }, di.AsSingleton())
+	di.RegisterInstance(c, registered, di.WithName("registered"))
+	di.MustResolve[*closableResource](c)
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resource.closed.Load() {
+		t.Error("expected constructed singleton to be closed")
+	}
+	if registered.closed.Load() {
+		t.Error("expected registered instance to be left open")
+	}
+	if len(c.CachedSingletons()) != 1 {
+		t.Errorf("expected only the registered instance to stay cached, got %v", c.CachedSingletons())
+	}
+}
+
+// disposableRecorder records its disposal.
+type disposableRecorder struct {
+	name   string
+	events *[]string
+}
+
+func (d *disposableRecorder) Dispose() error {
+	*d.events = append(*d.events, "dispose "+d.name)
+	return nil
+}
+
+type repository struct{ *disposableRecorder }
+
+type connection struct{ *disposableRecorder }
+
+func TestCloseDisposesInReverseConstructionOrder(t *testing.T) {
+	c := di.New()
+	var events []string
+
+	// Registered before its dependency, constructed after it
+	di.Register[*repository](c, func(conn *connection) *repository {
+		return &repository{&disposableRecorder{name: "repository", events: &events}}
+	}, di.AsSingleton())
+	di.Register[*connection](c, func() *connection {
+		return &connection{&disposableRecorder{name: "connection", events: &events}}
+	}, di.AsSingleton())
+	di.MustResolve[*repository](c)
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(events, ","); got != "dispose repository,dispose connection" {
+		t.Errorf("expected dependents to be disposed first, got %q", got)
+	}
+}
+
+type failingDisposable struct{ err error }
+
+func (d *failingDisposable) Dispose() error { return d.err }
+
+func TestCloseReportsDisposeFailures(t *testing.T) {
+	c := di.New()
+	boom := errors.New("boom")
+	for _, name := range []string{"primary", "replica"} {
+		di.Register[*failingDisposable](c, func() *failingDisposable {
+			return &failingDisposable{err: boom}
+		}, di.AsSingleton(), di.WithName(name))
+		di.MustResolveNamed[*failingDisposable](c, name)
+	}
+
+	err := c.Close(context.Background())
+	var failed di.ErrDisposeFailed
+	if !errors.As(err, &failed) || len(failed.Failures) != 2 {
+		t.Fatalf("expected two dispose failures, got %v", err)
+	}
+	if failed.Failures[0].Name != "replica" || failed.Failures[1].Name != "primary" {
+		t.Errorf("expected failures in disposal order, got %+v", failed.Failures)
+	}
+	if !errors.Is(err, boom) {
+		t.Error("expected errors.Is to see the disposal errors")
+	}
+
+	scope := c.CreateScope("request")
+	di.Register[*failingDisposable](c, func() *failingDisposable {
+		return &failingDisposable{err: boom}
+	}, di.AsScoped())
+	di.ResolveInScope[*failingDisposable](c, scope)
+	if err := scope.Dispose(); !errors.As(err, &failed) || len(failed.Failures) != 1 {
+		t.Errorf("expected the scope to report its dispose failure, got %v", err)
+	}
+}
+
+func TestCloseAbandonsHangingDisposal(t *testing.T) {
+	c := di.New()
+	hanging := &hangingCloser{release: make(chan struct{})}
+	defer close(hanging.release)
+	di.Register[*hangingCloser](c, func() *hangingCloser { return hanging }, di.AsSingleton())
+	di.MustResolve[*hangingCloser](c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := c.Close(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "hangingCloser") {
+		t.Errorf("expected error to name the instance, got %v", err)
+	}
+}
+
+func TestStopAbandonsHangingService(t *testing.T) {
+	c := di.New()
+	hanging := &hangingService{release: make(chan struct{})}
+	defer close(hanging.release)
+	di.RegisterInstance[di.HostedService](c, hanging)
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline error, got %v", err)
+	}
+}
+
+// consumerService is a hosted service that depends on producerService.
+type consumerService struct{ *recordingService }
+
+type producerService struct{ *recordingService }
+
+func TestStopDependentsBeforeDependencies(t *testing.T) {
+	c := di.New()
+	var events []string
+
+	// Registered, and so started, before the service it depends on
+	di.Register[*consumerService](c, func(*producerService) *consumerService {
+		return &consumerService{&recordingService{name: "consumer", events: &events}}
+	}, di.AsSingleton())
+	di.Register[*producerService](c, func() *producerService {
+		return &producerService{&recordingService{name: "producer", events: &events}}
+	}, di.AsSingleton())
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "start consumer,start producer,stop consumer,stop producer"
+	if got := strings.Join(events, ","); got != want {
+		t.Errorf("expected dependents to stop first, got %q", got)
+	}
+}
+
+// stuckWorker is a hosted service that depends on a resource and ignores its
+// stop context.
+type stuckWorker struct {
+	*hangingService
+	resource *closableResource
+}
+
+func TestCloseWarnsAboutOutlivedDependencies(t *testing.T) {
+	var warnings []di.Warning
+	c := di.New(di.WithHooks(di.Hooks{OnWarning: func(w di.Warning) { warnings = append(warnings, w) }}))
+	release := make(chan struct{})
+	defer close(release)
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsSingleton())
+	di.Register[*stuckWorker](c, func(resource *closableResource) *stuckWorker {
+		return &stuckWorker{hangingService: &hangingService{release: release}, resource: resource}
+	}, di.AsSingleton())
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	c.Close(ctx)
+
+	if len(warnings) != 1 || warnings[0].Kind != di.WarningOutlivedDependency {
+		t.Fatalf("expected one outlived-dependency warning, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0].Message, "closableResource") {
+		t.Errorf("expected the warning to name the dependency, got %q", warnings[0].Message)
+	}
+}
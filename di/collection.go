@@ -0,0 +1,81 @@
+package di
+
+import (
+	"reflect"
+	"sync"
+)
+
+// AppendValue adds value to the []T collection registered under name, creating
+// the collection on first use.
+//
+// Unlike other registrations, repeated calls accumulate instead of replacing
+// each other, which lets independent modules contribute routes, middleware, or
+// plugins to one collection. Resolve the collection with [ResolveNamed] as a
+// []T; values appear in the order they were appended. A collection with the
+// empty name can also be injected into factories as a []T parameter.
+//
+// Each resolution returns a copy of the collection as it is at that moment, so
+// callers may modify the slice freely.
+//
+// Returns [ErrDuplicateRegistration] if []T is already registered under name by
+// other means, and the errors of [Register] when the collection is created.
+//
+// Example:
+//
+//	// In each module
+//	di.AppendValue[Route](c, "routes", Route{Path: "/users", Handler: usersHandler})
+//	di.AppendValue[Route](c, "routes", Route{Path: "/orders", Handler: ordersHandler})
+//
+//	// At startup
+//	routes := di.MustResolveNamed[[]Route](c, "routes")
+func AppendValue[T any](c *Container, name string, value T) error {
+	var zero []T
+	collectionType := reflect.TypeOf(&zero).Elem()
+
+	c.appendMu.Lock()
+	defer c.appendMu.Unlock()
+
+	c.mu.RLock()
+	reg, exists := c.registrations[registrationKey{typ: collectionType, name: name}]
+	c.mu.RUnlock()
+
+	if exists {
+		values, ok := reg.collection.(*valueCollection[T])
+		if !ok {
+			return ErrDuplicateRegistration{Type: collectionType, Name: name}
+		}
+		values.append(value)
+		return nil
+	}
+
+	values := &valueCollection[T]{values: []T{value}}
+	opts := []RegistrationOption{func(r *registration) {
+		r.collection = values
+		r.userFactory = false
+	}}
+	if name != "" {
+		opts = append(opts, WithName(name))
+	}
+	return Register0[[]T](c, func() ([]T, error) {
+		return values.snapshot(), nil
+	}, opts...)
+}
+
+// valueCollection holds the values appended to a collection.
+type valueCollection[T any] struct {
+	mu     sync.Mutex
+	values []T
+}
+
+func (v *valueCollection[T]) append(value T) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values = append(v.values, value)
+}
+
+// snapshot is the collection's factory.
+func (v *valueCollection[T]) snapshot() []T {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]T(nil), v.values...)
+}
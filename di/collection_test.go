@@ -0,0 +1,101 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Value Collection Tests
+// =============================================================================
+
+type route struct {
+	Path string
+}
+
+type router struct {
+	routes []route
+}
+
+func TestAppendValue(t *testing.T) {
+	c := di.New()
+
+	// Modules contribute independently
+	di.AppendValue(c, "", route{Path: "/users"})
+	di.AppendValue(c, "", route{Path: "/orders"})
+	di.Register[*router](c, func(routes []route) *router {
+		return &router{routes: routes}
+	})
+	di.AppendValue(c, "", route{Path: "/health"})
+
+	r := di.MustResolve[*router](c)
+	var paths []string
+	for _, rt := range r.routes {
+		paths = append(paths, rt.Path)
+	}
+	if strings.Join(paths, ",") != "/users,/orders,/health" {
+		t.Errorf("expected routes in append order, got %v", paths)
+	}
+
+	// Each resolution gets its own copy
+	r.routes[0].Path = "/changed"
+	if routes := di.MustResolve[[]route](c); routes[0].Path != "/users" {
+		t.Error("expected resolved collections to be independent copies")
+	}
+}
+
+func TestAppendValueNamed(t *testing.T) {
+	c := di.New()
+
+	di.AppendValue[Greeter](c, "plugins", &SimpleGreeter{})
+	di.AppendValue[Greeter](c, "plugins", &formalGreeter{})
+
+	plugins, err := di.ResolveNamed[[]Greeter](c, "plugins")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plugins) != 2 {
+		t.Errorf("expected 2 plugins, got %d", len(plugins))
+	}
+	if len(c.Registrations()) != 1 {
+		t.Errorf("expected a single collection registration, got %d", len(c.Registrations()))
+	}
+}
+
+func TestAppendValueConflictsWithRegistration(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance(c, []route{{Path: "/"}})
+
+	err := di.AppendValue(c, "", route{Path: "/users"})
+	var duplicate di.ErrDuplicateRegistration
+	if !errors.As(err, &duplicate) {
+		t.Errorf("expected ErrDuplicateRegistration, got %v", err)
+	}
+}
+
+func TestAppendValueConcurrent(t *testing.T) {
+	c := di.New()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := di.AppendValue(c, "", route{Path: "/" + strconv.Itoa(i)}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if routes := di.MustResolve[[]route](c); len(routes) != 20 {
+		t.Errorf("expected every concurrent append in one collection, got %d", len(routes))
+	}
+}
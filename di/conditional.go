@@ -0,0 +1,105 @@
+package di
+
+import (
+	"reflect"
+	"slices"
+)
+
+// WhenRegistered makes a registration conditional on an unnamed registration
+// of TDep existing, locally or in the fallback container (see [WithFallback]).
+//
+// The condition is checked each time the registration is looked up, not when
+// it is made, so modules can be registered in any order. While the condition
+// does not hold, the registration behaves as if it had not been made: it is
+// not resolved, [Has] reports false, and [Container.Start] skips it. Options
+// can be combined; every condition must hold.
+//
+// A registration does not count towards its own conditions, so a default can
+// be registered with WhenNotRegistered on its own type. Likewise, while a
+// registration's conditions are evaluated, registrations whose conditions
+// refer back to it count as absent, so conditions that depend on each other
+// cannot recurse forever.
+//
+// Example:
+//
+//	// Only instrument the repository when the application provides a sink
+//	di.Register[UserRepository](c, newInstrumentedRepository,
+//	    di.WithName("instrumented"), di.WhenRegistered[MetricsSink]())
+func WhenRegistered[TDep any]() RegistrationOption {
+	depType := reflect.TypeOf((*TDep)(nil)).Elem()
+	return func(r *registration) {
+		r.conditions = append(r.conditions, func(c *Container, evaluating []*registration) bool {
+			return c.provides(depType, evaluating)
+		})
+	}
+}
+
+// WhenNotRegistered makes a registration conditional on no unnamed
+// registration of TDep existing, locally or in the fallback container. It is
+// typically used to provide a default that applications can replace.
+// See [WhenRegistered] for how conditions are evaluated.
+//
+// Example:
+//
+//	di.Register[MetricsSink](c, newDiscardSink, di.WithName("discard"),
+//	    di.WhenNotRegistered[*PrometheusSink]())
+func WhenNotRegistered[TDep any]() RegistrationOption {
+	depType := reflect.TypeOf((*TDep)(nil)).Elem()
+	return func(r *registration) {
+		r.conditions = append(r.conditions, func(c *Container, evaluating []*registration) bool {
+			return !c.provides(depType, evaluating)
+		})
+	}
+}
+
+// enabled reports whether every condition of the registration holds. The
+// caller must not hold c.mu.
+func (r *registration) enabled(c *Container) bool {
+	return r.enabledWhile(c, nil)
+}
+
+// enabledWhile reports whether every condition of the registration holds
+// while the conditions of the registrations in evaluating are being
+// evaluated. Those registrations, including r itself, count as absent.
+func (r *registration) enabledWhile(c *Container, evaluating []*registration) bool {
+	if slices.Contains(evaluating, r) {
+		return false
+	}
+	evaluating = append(slices.Clip(evaluating), r)
+	for _, condition := range r.conditions {
+		if !condition(c, evaluating) {
+			return false
+		}
+	}
+	return true
+}
+
+// registered reports whether the container has an enabled registration for
+// typ and name, following aliases. Registrations in evaluating count as
+// absent (see enabledWhile). The caller must not hold c.mu.
+func (c *Container) registered(typ reflect.Type, name string, evaluating []*registration) bool {
+	c.mu.RLock()
+	var err error
+	if name != "" {
+		name, _, err = c.followAlias(typ, name)
+	}
+	reg, exists := c.registrations[registrationKey{typ: typ, name: name}]
+	c.mu.RUnlock()
+
+	if err != nil {
+		return false
+	}
+
+	return exists && reg.enabledWhile(c, evaluating)
+}
+
+// provides reports whether an unnamed typ is registered in the container or
+// its fallback, counting the registrations in evaluating as absent.
+func (c *Container) provides(typ reflect.Type, evaluating []*registration) bool {
+	for ; c != nil; c = c.fallback {
+		if c.registered(typ, "", evaluating) {
+			return true
+		}
+	}
+	return false
+}
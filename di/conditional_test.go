@@ -0,0 +1,107 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Conditional Registration Tests
+// =============================================================================
+
+func TestWhenRegisteredIsOrderIndependent(t *testing.T) {
+	c := di.New()
+	di.Register[Service](c, func(logger Logger) Service {
+		return &DefaultService{logger: logger}
+	}, di.WhenRegistered[Logger]())
+
+	if di.Has[Service](c) {
+		t.Error("expected the registration to be hidden while Logger is missing")
+	}
+	var notRegistered di.ErrNotRegistered
+	if _, err := di.Resolve[Service](c); !errors.As(err, &notRegistered) {
+		t.Errorf("expected ErrNotRegistered, got %v", err)
+	}
+
+	di.Register[Logger](c, func() Logger { return &TestLogger{} })
+	if !di.Has[Service](c) {
+		t.Error("expected the registration once Logger is registered")
+	}
+	if _, err := di.Resolve[Service](c); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWhenNotRegisteredProvidesDefault(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} },
+		di.WithName("default"), di.WhenNotRegistered[*formalGreeter]())
+
+	if !di.HasNamed[Greeter](c, "default") {
+		t.Error("expected the default while no replacement is registered")
+	}
+
+	di.Register[*formalGreeter](c, func() *formalGreeter { return &formalGreeter{} })
+	if _, err := di.ResolveNamed[Greeter](c, "default"); err == nil {
+		t.Error("expected the default to be hidden once replaced")
+	}
+}
+
+func TestWhenNotRegisteredOnOwnType(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.WhenNotRegistered[Logger]())
+
+	if !di.Has[Logger](c) {
+		t.Error("expected a registration not to count towards its own condition")
+	}
+	if _, err := di.Resolve[Logger](c); err != nil {
+		t.Errorf("expected the default to resolve, got %v", err)
+	}
+}
+
+func TestMutuallyDependentConditions(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.WhenRegistered[Greeter]())
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WhenRegistered[Logger]())
+
+	// Each only holds if the other does, so neither can be established
+	if di.Has[Logger](c) || di.Has[Greeter](c) {
+		t.Error("expected conditions that depend on each other not to hold")
+	}
+	var notRegistered di.ErrNotRegistered
+	if _, err := di.Resolve[Logger](c); !errors.As(err, &notRegistered) {
+		t.Errorf("expected ErrNotRegistered, got %v", err)
+	}
+}
+
+func TestWhenRegisteredConsultsFallback(t *testing.T) {
+	platform := di.New()
+	di.Register[Logger](platform, func() Logger { return &TestLogger{} })
+
+	app := di.New(di.WithFallback(platform))
+	di.Register[Service](app, func() Service { return &DefaultService{} }, di.WhenRegistered[Logger]())
+
+	if !di.Has[Service](app) {
+		t.Error("expected a dependency in the fallback container to satisfy the condition")
+	}
+}
+
+func TestConditionalHostedServiceSkippedByStart(t *testing.T) {
+	c := di.New()
+	var events []string
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "exporter", events: &events},
+		di.WhenRegistered[Logger]())
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	defer c.Stop(context.Background())
+	if len(events) != 0 {
+		t.Errorf("expected the disabled service not to start, got %v", events)
+	}
+}
@@ -1,8 +1,12 @@
 package di
 
 import (
+	"context"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Container is the dependency injection container that manages service registrations
@@ -13,32 +17,90 @@ import (
 //   - Registrations: Factory functions and metadata for creating instances
 //   - Singletons: Cached instances for singleton-scoped dependencies
 //   - Scopes: Named scopes for scoped dependency resolution
+//   - Default scope: An optional ambient scope used when none is given
+//   - Hosted services: Background services run by Start and Stop
 //
 // Use [New] to create a new Container instance.
 type Container struct {
-	mu            sync.RWMutex
-	registrations map[registrationKey]*registration
-	singletons    map[registrationKey]any
-	scopes        map[string]*Scope
-	resolving     map[reflect.Type]bool // For circular dependency detection
+	mu                 sync.RWMutex
+	registrations      map[registrationKey]*registration
+	order              []registrationKey // Registration keys in insertion order
+	appendMu           sync.Mutex        // Serializes AppendValue, so a collection is created once
+	singletons         map[registrationKey]any
+	building           map[registrationKey]*singletonFlight // Singletons under construction, guarded by mu
+	scopes             map[string]*Scope
+	defaultScope       *Scope                // Ambient scope for scope-less resolution
+	resolving          map[reflect.Type]bool // For circular dependency detection
+	stats              containerStats
+	strict             bool                    // Reject ambiguous names and duplicates
+	namingPolicy       func(name string) error // Validates registration names
+	nameResolver       NameResolver            // Rewrites names before lookup
+	nilChecks          bool                    // Reject nil factory results
+	hooks              Hooks
+	selectors          map[reflect.Type]Selector
+	lifecycleMu        sync.Mutex       // Serializes Start and Stop
+	started            []runningService // Running hosted services, in start order
+	supervisors        []*supervisor    // Services started by the last Start, guarded by mu
+	phases             []string         // Declared startup phase order
+	extensions         []Extension
+	onConstructed      []func(instance any) // Subscribers added with OnConstructed
+	decorators         map[reflect.Type][]*decorator
+	matchingDecorators []*matchingDecorator // Added with DecorateMatching
+	fallback           *Container           // Consulted for unregistered types
+	templates          map[reflect.Type]*template
+	profile            string                         // Active profile (see WithProfile)
+	declared           []*registration                // Every registration made, for ValidateProfiles
+	lazy               map[reflect.Type][]*lazyModule // Modules awaiting their triggers
+	normalizePointers  bool                           // Serve T and *T from each other's registrations
+	scopeMiddleware    []ScopeMiddleware
+	ready              atomic.Bool                // Set between a completed Start and Stop
+	starting           atomic.Bool                // Set while Start runs
+	scopeArena         bool                       // Scopes release their transients (see WithScopeArena)
+	contextScopes      atomic.Uint64              // Scopes created by ScopeForContext, for naming
+	parallelStartup    bool                       // Construct each startup phase concurrently
+	generation         uint64                     // Incremented by Clear, guarded by mu
+	gate               resolveGate                // Tracks top-level resolutions for Quiesce
+	families           map[string]*scopeFamily    // Instances shared by scope families
+	contributed        map[string]bool            // Modules applied by Contribute, by name
+	aliases            map[registrationKey]string // Alias name to target name
+	constructions      uint64                     // Singletons constructed, for disposal order; guarded by mu
+	startupBudget      time.Duration              // Limit on eager construction in Start
+	startupBudgetWarns bool                       // Warn instead of failing when it is exceeded
+	recorder           *recorder                  // Resolution log kept by WithRecording
+	eagerCycleCheck    bool                       // Reject registrations that close a cycle
 }
 
 // New creates a new dependency injection container.
 //
 // The returned container is empty and ready for registrations. It is thread-safe
-// and can be safely shared across goroutines.
+// and can be safely shared across goroutines. Options such as [WithStrictMode]
+// configure container-wide behavior.
 //
 // Example:
 //
 //	container := di.New()
 //	di.Register[Logger](container, func() Logger { return &ConsoleLogger{} })
-func New() *Container {
-	return &Container{
+func New(opts ...ContainerOption) *Container {
+	c := &Container{
 		registrations: make(map[registrationKey]*registration),
 		singletons:    make(map[registrationKey]any),
+		building:      make(map[registrationKey]*singletonFlight),
 		scopes:        make(map[string]*Scope),
+		families:      make(map[string]*scopeFamily),
+		contributed:   make(map[string]bool),
+		aliases:       make(map[registrationKey]string),
 		resolving:     make(map[reflect.Type]bool),
+		selectors:     make(map[reflect.Type]Selector),
+		decorators:    make(map[reflect.Type][]*decorator),
+		templates:     make(map[reflect.Type]*template),
+		lazy:          make(map[reflect.Type][]*lazyModule),
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // Register registers a type with the container using a factory function.
@@ -50,7 +112,13 @@ func New() *Container {
 // By default, registrations are transient (a new instance is created on each resolution).
 // Use [AsSingleton], [AsScoped], or [WithLifetime] options to change the lifetime.
 //
-// Returns an error if the factory signature is invalid (see [ErrInvalidFactory]).
+// If T is a struct that embeds [Out], each of its exported fields is registered
+// as well.
+//
+// Returns an error if the factory signature is invalid (see [ErrInvalidFactory]),
+// if the package is built with the di_noreflect tag (see [Register0]),
+// or if the registration name is rejected (see [ErrInvalidName] and
+// [ErrDuplicateRegistration]).
 //
 // Example:
 //
@@ -73,9 +141,10 @@ func Register[T any](c *Container, factory any, opts ...RegistrationOption) erro
 	targetType := reflect.TypeOf(&zero).Elem()
 
 	reg := &registration{
-		targetType: targetType,
-		factory:    factory,
-		lifetime:   Transient,
+		targetType:  targetType,
+		factory:     factory,
+		lifetime:    Transient,
+		userFactory: true,
 	}
 
 	for _, opt := range opts {
@@ -83,15 +152,39 @@ func Register[T any](c *Container, factory any, opts ...RegistrationOption) erro
 	}
 
 	// Validate factory signature
+	if !reflectiveFactories {
+		return ErrInvalidFactory{Type: targetType, Message: errReflectionDisabled}
+	}
 	if err := validateFactory(targetType, factory); err != nil {
 		return err
 	}
+	if err := c.checkRegistration(reg); err != nil {
+		return err
+	}
+	var fields []*registration
+	if isResultObject(targetType) {
+		var err error
+		if fields, err = c.resultRegistrations(reg, opts); err != nil {
+			return err
+		}
+	}
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	err := c.addRegistration(reg)
+	for _, field := range fields {
+		if err != nil {
+			break
+		}
+		err = c.addRegistration(field)
+	}
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
 
-	key := registrationKey{typ: targetType, name: reg.name}
-	c.registrations[key] = reg
+	if c.hooks.OnWarning != nil {
+		c.emitWarnings(duplicateFactoryWarnings(c.orderedRegistrations(), reg))
+	}
 
 	return nil
 }
@@ -106,6 +199,9 @@ func Register[T any](c *Container, factory any, opts ...RegistrationOption) erro
 //   - Shared resources like connection pools
 //   - Mock objects in tests
 //
+// Returns an error only if the registration name is rejected (see [ErrInvalidName]
+// and [ErrDuplicateRegistration]).
+//
 // Example:
 //
 //	config := &AppConfig{Port: 8080, Debug: true}
@@ -113,7 +209,7 @@ func Register[T any](c *Container, factory any, opts ...RegistrationOption) erro
 //
 //	// Later, resolving returns the same instance
 //	cfg := di.MustResolve[Config](container)
-func RegisterInstance[T any](c *Container, instance T, opts ...RegistrationOption) {
+func RegisterInstance[T any](c *Container, instance T, opts ...RegistrationOption) error {
 	var zero T
 	targetType := reflect.TypeOf(&zero).Elem()
 
@@ -126,23 +222,52 @@ func RegisterInstance[T any](c *Container, instance T, opts ...RegistrationOptio
 	for _, opt := range opts {
 		opt(reg)
 	}
+	if err := c.checkRegistration(reg); err != nil {
+		return err
+	}
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	if err := c.addRegistration(reg); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	active := reg.activeIn(c.profile)
+	if active {
+		c.singletons[registrationKey{typ: targetType, name: reg.name}] = instance
+	}
+	c.mu.Unlock()
 
-	key := registrationKey{typ: targetType, name: reg.name}
-	c.registrations[key] = reg
-	c.singletons[key] = instance
+	if active && c.hooks.OnWarning != nil {
+		c.emitWarnings(c.duplicateInstanceWarnings(reg))
+	}
+	return nil
 }
 
 // RegisterType registers an interface to implementation type mapping.
 //
 // This creates a registration where resolving TInterface returns a new instance
-// of TImpl. The implementation type is instantiated using reflection.
+// of TImpl. When *TImpl satisfies TInterface (for example because TImpl's methods
+// have pointer receivers), each resolution returns a new *TImpl. Otherwise, if
+// TImpl itself satisfies TInterface, a new TImpl value is returned.
+//
+// New values are ready to use rather than merely zero: an implementation that
+// is a map type starts out as an empty map, so methods can write to it; a
+// slice type starts out empty; and a pointer type points to a newly allocated
+// value. Structs and other types get their zero value.
+//
+// Use RegisterType instead of [Register] when the implementation needs no
+// dependencies or setup beyond those values, such as a stateless strategy or
+// an in-memory store: there is no factory to write, and the registration
+// also works in builds with the di_noreflect tag. An implementation that
+// needs dependencies, configuration, or error handling at construction
+// needs a factory registered with [Register].
 //
-// This is useful when the implementation has no constructor dependencies and
-// can be zero-value initialized, or when you want the container to create
-// instances automatically.
+// Returns [ErrInvalidFactory] if neither TImpl nor *TImpl is assignable to
+// TInterface, or if TImpl is a function, channel, or interface type that has
+// no meaningful value to construct, so a mismatched mapping fails at
+// registration rather than at resolution. Go does not allow a type parameter
+// to appear in a constraint's type set, so this check cannot be expressed in
+// RegisterType's signature.
 //
 // Example:
 //
@@ -158,9 +283,31 @@ func RegisterType[TInterface any, TImpl any](c *Container, opts ...RegistrationO
 	implType := reflect.TypeOf(&zeroImpl).Elem()
 
 	// Create a factory that instantiates the implementation
+	var viaPointer bool
+	switch {
+	case reflect.PointerTo(implType).AssignableTo(ifaceType):
+		viaPointer = true
+	case implType.AssignableTo(ifaceType):
+	default:
+		return ErrInvalidFactory{
+			Type:    ifaceType,
+			Message: "neither " + implType.String() + " nor *" + implType.String() + " is assignable to " + ifaceType.String(),
+		}
+	}
+	if reason := unconstructible(implType); reason != "" {
+		return ErrInvalidFactory{
+			Type:    ifaceType,
+			Message: implType.String() + " cannot be constructed automatically: " + reason + "; register a factory with Register instead",
+		}
+	}
 	factory := func() TInterface {
-		val := reflect.New(implType)
-		return val.Interface().(TInterface)
+		impl := newImplementation(implType)
+		if viaPointer {
+			ptr := reflect.New(implType)
+			ptr.Elem().Set(impl)
+			impl = ptr
+		}
+		return impl.Interface().(TInterface)
 	}
 
 	reg := &registration{
@@ -170,16 +317,100 @@ func RegisterType[TInterface any, TImpl any](c *Container, opts ...RegistrationO
 		lifetime:   Transient,
 	}
 
+	if !reflectiveFactories {
+		reg.typed = func([]any) (any, error) { return factory(), nil }
+	}
+
 	for _, opt := range opts {
 		opt(reg)
 	}
+	if err := c.checkRegistration(reg); err != nil {
+		return err
+	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := registrationKey{typ: ifaceType, name: reg.name}
-	c.registrations[key] = reg
+	return c.addRegistration(reg)
+}
+
+// unconstructible returns why RegisterType cannot construct a usable value of
+// typ, or "" if it can.
+func unconstructible(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Func:
+		return "a function has no implementation to construct"
+	case reflect.Chan:
+		return "a channel's buffer size is unknown"
+	case reflect.Interface:
+		return "an interface has no concrete type to construct"
+	case reflect.UnsafePointer:
+		return "an unsafe.Pointer has nothing to point to"
+	case reflect.Pointer:
+		return unconstructible(typ.Elem())
+	}
+	return ""
+}
+
+// newImplementation returns a ready-to-use value of typ for RegisterType.
+// Maps and slices are empty rather than nil, so writes to a map do not panic,
+// and pointers point to a newly constructed value. Other types, such as
+// structs, get their zero value.
+func newImplementation(typ reflect.Type) reflect.Value {
+	switch typ.Kind() {
+	case reflect.Map:
+		return reflect.MakeMap(typ)
+	case reflect.Slice:
+		return reflect.MakeSlice(typ, 0, 0)
+	case reflect.Pointer:
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(newImplementation(typ.Elem()))
+		return ptr
+	}
+	return reflect.New(typ).Elem()
+}
+
+// addRegistration validates the registration's name and stores it.
+// The caller must hold c.mu for writing.
+func (c *Container) addRegistration(reg *registration) error {
+	generated := len(reg.groups) > 0 && !reg.nameSet
+	if generated {
+		reg.name = c.groupMemberName(reg)
+	}
+	key := registrationKey{typ: reg.targetType, name: reg.name}
+
+	if c.strict {
+		if reg.nameSet && reg.name == "" {
+			return ErrInvalidName{Type: reg.targetType, Name: reg.name, Reason: "name must not be empty"}
+		}
+		if _, exists := c.registrations[key]; exists {
+			return ErrDuplicateRegistration{Type: reg.targetType, Name: reg.name}
+		}
+	}
+
+	if reg.name != "" && !generated && c.namingPolicy != nil {
+		if err := c.namingPolicy(reg.name); err != nil {
+			return ErrInvalidName{Type: reg.targetType, Name: reg.name, Reason: err.Error()}
+		}
+	}
+
+	if c.eagerCycleCheck && reg.activeIn(c.profile) {
+		if cycle := c.cycleThrough(reg); cycle != nil {
+			return ErrCircularDependency{Chain: cycle}
+		}
+	}
+
+	// Keep registrations of other profiles for ValidateProfiles only
+	c.declared = append(c.declared, reg)
+	if !reg.activeIn(c.profile) {
+		return nil
+	}
 
+	if _, exists := c.registrations[key]; !exists {
+		c.order = append(c.order, key)
+	}
+	reg.generation = c.generation
+	c.registrations[key] = reg
 	return nil
 }
 
@@ -222,7 +453,7 @@ func ResolveNamed[T any](c *Container, name string) (T, error) {
 	var zero T
 	targetType := reflect.TypeOf(&zero).Elem()
 
-	result, err := c.resolve(targetType, name, nil, make([]reflect.Type, 0))
+	result, err := c.resolve(context.Background(), targetType, name, nil, make([]reflect.Type, 0))
 	if err != nil {
 		return zero, err
 	}
@@ -235,13 +466,9 @@ func ResolveNamed[T any](c *Container, name string) (T, error) {
 // Use this when you're certain the type is registered and resolution will succeed,
 // such as during application startup after all registrations are complete.
 //
-// This is equivalent to:
-//
-//	result, err := di.Resolve[T](c)
-//	if err != nil {
-//	    panic(err)
-//	}
-//	return result
+// The panic value is a [ResolutionPanic] wrapping the resolution error, whose
+// message adds the resolution chain, the registrations of T, and the nearest
+// registrations to a missing type or name.
 //
 // Example:
 //
@@ -250,7 +477,7 @@ func ResolveNamed[T any](c *Container, name string) (T, error) {
 func MustResolve[T any](c *Container) T {
 	result, err := Resolve[T](c)
 	if err != nil {
-		panic(err)
+		panic(c.resolutionPanic(err, reflect.TypeOf(&result).Elem(), ""))
 	}
 	return result
 }
@@ -258,7 +485,7 @@ func MustResolve[T any](c *Container) T {
 // MustResolveNamed resolves a named dependency or panics if it fails.
 //
 // This is the panic-on-error variant of [ResolveNamed]. Use when you're certain
-// the named registration exists.
+// the named registration exists. The panic value is a [ResolutionPanic].
 //
 // Example:
 //
@@ -267,7 +494,7 @@ func MustResolve[T any](c *Container) T {
 func MustResolveNamed[T any](c *Container, name string) T {
 	result, err := ResolveNamed[T](c, name)
 	if err != nil {
-		panic(err)
+		panic(c.resolutionPanic(err, reflect.TypeOf(&result).Elem(), name))
 	}
 	return result
 }
@@ -279,7 +506,8 @@ func MustResolveNamed[T any](c *Container, name string) T {
 // when resolved within the same scope.
 //
 // The scope name should be unique (e.g., a request ID). Creating a scope
-// with the same name as an existing scope will replace the old scope.
+// with the same name as an existing scope will replace the old scope. Scope
+// middleware added with [Container.UseScope] wraps the creation and disposal.
 //
 // Example:
 //
@@ -293,19 +521,36 @@ func MustResolveNamed[T any](c *Container, name string) T {
 //	    // ctx1 == ctx2
 //	}
 func (c *Container) CreateScope(name string) *Scope {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	scope := newScope(name, c)
-	c.scopes[name] = scope
+	scope, dispose := c.scopeFactory()(name)
+	scope.setDispose(dispose)
 	return scope
 }
 
+// Scopes returns the names of the scopes the container tracks, sorted. A scope
+// is tracked from its creation until it is disposed, so a growing list points
+// to scopes that are never disposed.
+//
+// Example:
+//
+//	log.Printf("live scopes: %d", len(container.Scopes()))
+func (c *Container) Scopes() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	names := make([]string, 0, len(c.scopes))
+	for name := range c.scopes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ResolveInScope resolves a dependency within a specific scope.
 //
 // For scoped dependencies (registered with [AsScoped]), the same instance
 // is returned for all resolutions within the same scope. Singleton and
-// transient dependencies behave normally.
+// transient dependencies behave normally. Factories that take a
+// context.Context receive the scope's context (see [Scope.SetContext]).
 //
 // Example:
 //
@@ -314,10 +559,20 @@ func (c *Container) CreateScope(name string) *Scope {
 //	scope := container.CreateScope("request-123")
 //	ctx, err := di.ResolveInScope[*RequestContext](container, scope)
 func ResolveInScope[T any](c *Container, scope *Scope) (T, error) {
+	return ResolveInScopeCtx[T](scope.Context(), c, scope)
+}
+
+// ResolveInScopeCtx is like [ResolveInScope] but resolves with ctx instead of
+// the scope's context.
+//
+// Example:
+//
+//	repo, err := di.ResolveInScopeCtx[*OrderRepo](ctx, container, scope)
+func ResolveInScopeCtx[T any](ctx context.Context, c *Container, scope *Scope) (T, error) {
 	var zero T
 	targetType := reflect.TypeOf(&zero).Elem()
 
-	result, err := c.resolve(targetType, "", scope, make([]reflect.Type, 0))
+	result, err := c.resolve(ctx, targetType, "", scope, make([]reflect.Type, 0))
 	if err != nil {
 		return zero, err
 	}
@@ -325,86 +580,398 @@ func ResolveInScope[T any](c *Container, scope *Scope) (T, error) {
 	return result.(T), nil
 }
 
+// SetDefaultScope sets the container's ambient scope.
+//
+// When a default scope is set, resolutions that are not given a scope (such as
+// plain [Resolve] calls) resolve scoped dependencies within the default scope
+// instead of treating them as transient. Pass nil to remove the default scope.
+//
+// This enables incremental adoption of scoped lifetimes in code that cannot yet
+// thread a [Scope] through its call sites. Because the default scope is shared
+// by the whole container, it is best suited to single-request tools, workers
+// that process one unit of work at a time, and tests.
+//
+// Example:
+//
+//	scope := container.CreateScope("job-42")
+//	container.SetDefaultScope(scope)
+//	defer container.SetDefaultScope(nil)
+//
+//	// Resolves *UnitOfWork within "job-42"
+//	uow := di.MustResolve[*UnitOfWork](container)
+func (c *Container) SetDefaultScope(scope *Scope) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.defaultScope = scope
+}
+
+// DefaultScope returns the container's ambient scope, or nil if none is set.
+func (c *Container) DefaultScope() *Scope {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.defaultScope
+}
+
+// WithAmbientScope runs fn with scope set as the container's default scope.
+//
+// The previous default scope is restored when fn returns, even if fn panics.
+// See [Container.SetDefaultScope] for how the default scope is used.
+//
+// Example:
+//
+//	scope := container.CreateScope("request-123")
+//	container.WithAmbientScope(scope, func() {
+//	    legacyHandler() // calls di.MustResolve internally
+//	})
+func (c *Container) WithAmbientScope(scope *Scope, fn func()) {
+	c.mu.Lock()
+	previous := c.defaultScope
+	c.defaultScope = scope
+	c.mu.Unlock()
+
+	defer c.SetDefaultScope(previous)
+
+	fn()
+}
+
 // resolve is the internal resolution method.
-func (c *Container) resolve(targetType reflect.Type, name string, scope *Scope, chain []reflect.Type) (any, error) {
+func (c *Container) resolve(ctx context.Context, targetType reflect.Type, name string, scope *Scope, chain []reflect.Type) (result any, err error) {
+	// Hold top-level resolutions while the container is quiesced
+	if len(chain) == 0 {
+		if err := c.gate.enter(ctx); err != nil {
+			return nil, ErrResolutionFailed{Type: targetType, Cause: err}
+		}
+		defer c.gate.leave()
+	}
+
 	c.mu.RLock()
 	key := registrationKey{typ: targetType, name: name}
 	reg, exists := c.registrations[key]
+	selector := c.selectors[targetType]
+	if scope == nil {
+		scope = c.defaultScope
+	}
+	exts := c.extensions
+	onConstructed := c.onConstructed
+	lazy := c.lazy[targetType]
 	c.mu.RUnlock()
 
+	// Apply lazy modules triggered by this type, then look again
+	if len(lazy) > 0 {
+		if err := c.loadLazy(targetType, lazy); err != nil {
+			return nil, err
+		}
+		c.mu.RLock()
+		reg, exists = c.registrations[key]
+		selector = c.selectors[targetType]
+		c.mu.RUnlock()
+	}
+
+	// Report the outcome to resolution extensions
+	cacheHit := false
+	lifetime := Transient
+	if len(exts) > 0 {
+		start := time.Now()
+		depth := len(chain)
+		defer func() {
+			c.notifyResolve(exts, ResolveEvent{
+				Context:  ctx,
+				Type:     targetType,
+				Name:     name,
+				Scope:    scope,
+				Depth:    depth,
+				Lifetime: lifetime,
+				CacheHit: cacheHit,
+				Duration: time.Since(start),
+				Err:      err,
+			})
+		}()
+	}
+
+	// Let the error hook rewrite failures before they leave the container
+	if len(chain) == 0 && c.hooks.InterceptError != nil {
+		defer func() {
+			if err != nil {
+				err = c.interceptError(err)
+			}
+		}()
+	}
+
+	// Apply the container's naming convention
+	if c.nameResolver != nil {
+		resolved := c.resolveName(NameRequest{
+			SelectionContext: SelectionContext{Context: ctx, Type: targetType, Scope: scope},
+			Name:             name,
+		})
+		if resolved != key.name {
+			key.name = resolved
+			c.mu.RLock()
+			reg, exists = c.registrations[key]
+			c.mu.RUnlock()
+		}
+	}
+
+	// Let a selector choose among named registrations for unnamed requests
+	if key.name == "" && selector != nil {
+		selected, err := selector(SelectionContext{Context: ctx, Type: targetType, Scope: scope})
+		if err != nil {
+			return nil, ErrResolutionFailed{Type: targetType, Cause: err}
+		}
+		key.name = selected
+		c.mu.RLock()
+		reg, exists = c.registrations[key]
+		c.mu.RUnlock()
+	}
+
+	// Follow named aliases to the registration they currently refer to
+	if !exists && key.name != "" {
+		c.mu.RLock()
+		target, aliased, err := c.followAlias(targetType, key.name)
+		if aliased && err == nil {
+			key.name = target
+			reg, exists = c.registrations[key]
+		}
+		c.mu.RUnlock()
+		if err != nil {
+			return nil, ErrResolutionFailed{Type: targetType, Cause: err}
+		}
+	}
+
+	// Conditional registrations whose conditions fail are treated as absent
+	disabled := exists && len(reg.conditions) > 0 && !reg.enabled(c)
+	if disabled {
+		exists = false
+	}
+
+	if !exists && !disabled && key.name != "" {
+		var err error
+		if reg, exists, err = c.templateRegistration(key); err != nil {
+			return nil, ErrResolutionFailed{Type: targetType, Cause: err}
+		}
+	}
+
+	if !exists && c.normalizePointers {
+		if counterpart, ok := c.pointerCounterpart(targetType, key.name); ok {
+			return c.resolveCounterpart(ctx, targetType, counterpart, key.name, scope, chain)
+		}
+	}
+
 	if !exists {
-		return nil, ErrNotRegistered{Type: targetType}
+		if instance, ok, err := c.resolveMissing(exts, targetType, key.name); ok {
+			return instance, err
+		}
+		if c.fallback != nil {
+			return c.resolveFallback(ctx, targetType, key.name)
+		}
+		c.stats.unregistered.Add(1)
+		return nil, ErrNotRegistered{Type: targetType, Name: key.name}
 	}
+	reg.stats.resolutions.Add(1)
+	lifetime = reg.lifetime
 
 	// Check for circular dependencies
 	for _, t := range chain {
 		if t == targetType {
+			reg.stats.errors.Add(1)
 			return nil, ErrCircularDependency{Chain: append(chain, targetType)}
 		}
 	}
 	chain = append(chain, targetType)
 
+	// Stop walking the graph once the caller's context is done
+	if err := ctx.Err(); err != nil {
+		reg.stats.errors.Add(1)
+		return nil, contextError(ctx, err, chain)
+	}
+
+	// Serve instances the scope overrides the registration with
+	if scope.hasOverrides() && overridable(ctx) {
+		if instance, ok := scope.override(key); ok {
+			reg.stats.cacheHits.Add(1)
+			cacheHit = true
+			return instance, nil
+		}
+	}
+
 	// Handle pre-registered instances
 	if reg.instance != nil {
+		reg.stats.cacheHits.Add(1)
+		cacheHit = true
 		return reg.instance, nil
 	}
 
-	// Check singleton cache
+	// Check singleton cache, or wait for a construction already in progress
 	if reg.lifetime == Singleton {
-		c.mu.RLock()
-		if instance, ok := c.singletons[key]; ok {
-			c.mu.RUnlock()
+		instance, cached, flight, joinErr := c.joinSingleton(ctx, key, reg, chain)
+		if joinErr != nil {
+			reg.stats.errors.Add(1)
+			return nil, joinErr
+		}
+		if cached {
+			reg.stats.cacheHits.Add(1)
+			reg.touch()
+			cacheHit = true
 			return instance, nil
 		}
-		c.mu.RUnlock()
+		if flight != nil {
+			defer func() { c.finishSingleton(key, flight, err) }()
+		}
 	}
 
 	// Check scope cache for scoped dependencies
 	if reg.lifetime == Scoped && scope != nil {
 		if instance, ok := scope.get(key); ok {
+			reg.stats.cacheHits.Add(1)
+			cacheHit = true
+			return instance, nil
+		}
+	}
+
+	// Check the family cache shared by related scopes
+	if reg.lifetime == ScopeFamily && scope != nil {
+		if instance, ok := c.familyInstance(scope, reg, key); ok {
+			reg.stats.cacheHits.Add(1)
+			cacheHit = true
 			return instance, nil
 		}
 	}
 
+	// Serve prewarmed transients from their buffer
+	if reg.prewarm > 0 && reg.lifetime == Transient {
+		if instance, ok := c.takePrewarmed(reg); ok {
+			reg.stats.cacheHits.Add(1)
+			cacheHit = true
+			return instance, nil
+		}
+	}
+
+	// Charge the scope's budget for instances it does not share
+	var budget *scopeBudget
+	if reg.lifetime == Transient || reg.lifetime == Scoped {
+		budget = scope.currentBudget()
+	}
+	if budget != nil {
+		if err := budget.charge(scope.name, reg); err != nil {
+			reg.stats.errors.Add(1)
+			return nil, err
+		}
+	}
+
 	// Create new instance using factory
-	instance, err := c.invokeFactory(reg.factory, scope, chain)
+	start := time.Now()
+	// Keep scope overrides out of instances shared by the whole process
+	if reg.lifetime == Singleton && scope.hasOverrides() {
+		ctx = context.WithValue(ctx, buildingSingletonKey{}, true)
+	}
+	instance, err := c.invokeFactory(ctx, reg, scope, chain, budget)
+	if err == nil {
+		instance, err = c.decorate(ctx, reg, instance, scope, chain)
+	}
+	if err == nil && len(reg.validators) > 0 {
+		if err = reg.validate(instance); err != nil {
+			reg.stats.recordConstructionError(err)
+		}
+	}
+	end := time.Now()
+	elapsed := end.Sub(start)
+	if reg.shadow != "" {
+		c.startShadow(ctx, reg, scope, err, elapsed)
+	}
 	if err != nil {
+		if budget != nil {
+			budget.refund(reg)
+		}
+		reg.stats.errors.Add(1)
 		return nil, ErrResolutionFailed{Type: targetType, Cause: err}
 	}
 
+	// Discard instances built for registrations that a concurrent Clear removed
+	if reg.lifetime != Singleton && c.stale(reg) {
+		return nil, c.discardStale(reg, instance)
+	}
+	reg.stats.recordConstruction(elapsed, end)
+
 	// Cache based on lifetime
 	switch reg.lifetime {
 	case Singleton:
 		c.mu.Lock()
+		if reg.generation != c.generation {
+			c.mu.Unlock()
+			return nil, c.discardStale(reg, instance)
+		}
+		// A resolution that could not wait for this one cached an instance first
+		if existing, ok := c.singletons[key]; ok {
+			c.mu.Unlock()
+			if err := disposeInstance(instance); err != nil {
+				c.reportDisposeError(reg.targetType, reg.name, err)
+			}
+			return existing, nil
+		}
 		c.singletons[key] = instance
+		c.constructions++
+		reg.constructedAt = c.constructions
+		if reg.idleTimeout > 0 {
+			c.scheduleIdleEviction(key, reg)
+		}
 		c.mu.Unlock()
+		if c.hooks.OnWarning != nil {
+			c.emitWarnings(c.duplicateInstanceWarnings(reg))
+		}
+	case Transient:
+		if scope != nil && c.scopeArena {
+			scope.track(key, instance)
+		}
 	case Scoped:
 		if scope != nil {
 			scope.set(key, instance)
 		}
+	case ScopeFamily:
+		if scope != nil && !c.setFamilyInstance(scope, reg, key, instance) {
+			return nil, c.discardStale(reg, instance)
+		}
+	}
+
+	for _, fn := range onConstructed {
+		fn(instance)
 	}
 
 	return instance, nil
 }
 
-// invokeFactory calls a factory function, resolving its dependencies.
-func (c *Container) invokeFactory(factory any, scope *Scope, chain []reflect.Type) (any, error) {
-	factoryValue := reflect.ValueOf(factory)
+// invokeFactory calls a registration's factory function, resolving its
+// dependencies.
+//
+// Parameters of type context.Context receive the resolution context,
+// parameters of type *Scope the resolving scope, and parameters of type
+// [Metadata] a description of reg, instead of being resolved from the container.
+func (c *Container) invokeFactory(ctx context.Context, reg *registration, scope *Scope, chain []reflect.Type, budget *scopeBudget) (any, error) {
+	if reg.typed != nil {
+		return c.invokeTyped(ctx, reg, scope, chain, budget)
+	}
+
+	factoryValue := reflect.ValueOf(reg.factory)
 	factoryType := factoryValue.Type()
 
 	// Resolve all parameters
 	args := make([]reflect.Value, factoryType.NumIn())
-	for i := 0; i < factoryType.NumIn(); i++ {
-		paramType := factoryType.In(i)
-		resolved, err := c.resolve(paramType, "", scope, chain)
-		if err != nil {
-			return nil, err
-		}
-		args[i] = reflect.ValueOf(resolved)
+	if err := c.resolveParams(ctx, factoryType, args, scope, chain, reg.metadata()); err != nil {
+		return nil, err
 	}
 
-	// Call factory
-	results := factoryValue.Call(args)
+	// Call factory, charging its own running time to the scope's budget
+	var start time.Time
+	if budget != nil {
+		start = time.Now()
+	}
+	results, err := callFactory(ctx, factoryValue, args, chain)
+	if budget != nil {
+		budget.spend(time.Since(start))
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	// Handle (T) or (T, error) return signatures
 	if len(results) == 0 {
@@ -414,13 +981,93 @@ func (c *Container) invokeFactory(factory any, scope *Scope, chain []reflect.Typ
 	// Check for error return
 	if len(results) == 2 {
 		if !results[1].IsNil() {
-			return nil, results[1].Interface().(error)
+			err := results[1].Interface().(error)
+			reg.stats.recordConstructionError(err)
+			return nil, err
+		}
+	}
+
+	if c.nilChecks {
+		if kind, ok := nilResult(results[0]); ok {
+			err := ErrNilInstance{Type: reg.targetType, Name: reg.name, Factory: factoryType, Kind: kind}
+			reg.stats.recordConstructionError(err)
+			return nil, err
 		}
 	}
 
 	return results[0].Interface(), nil
 }
 
+// nilResult reports whether a factory result is nil, looking through
+// interface values so that typed nils are caught, and returns the type of the
+// nil value. Nil slices and maps are usable empty values and are not reported.
+func nilResult(v reflect.Value) (reflect.Type, bool) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.Type(), v.IsNil()
+	}
+	return nil, false
+}
+
+// resolveParams fills the unset entries of args with the parameters of fnType.
+//
+// Parameters of type context.Context receive ctx, parameters of type *Scope
+// receive scope, and parameters of type Metadata receive meta; the others are
+// resolved from the container.
+func (c *Container) resolveParams(ctx context.Context, fnType reflect.Type, args []reflect.Value, scope *Scope, chain []reflect.Type, meta Metadata) error {
+	for i, param := range planParams(fnType) {
+		if args[i].IsValid() {
+			continue
+		}
+		switch param.kind {
+		case paramContext:
+			args[i] = reflect.ValueOf(&ctx).Elem()
+			continue
+		case paramScope:
+			args[i] = reflect.ValueOf(scope)
+			continue
+		case paramMetadata:
+			args[i] = reflect.ValueOf(meta)
+			continue
+		case paramProvider:
+			if !c.provides(param.typ, nil) {
+				args[i] = c.newProvider(param.typ, scope)
+				continue
+			}
+		case paramLazy:
+			args[i] = reflect.ValueOf(param.lazy.bind(c, scope))
+			continue
+		case paramOptional:
+			value, err := c.resolveOptional(ctx, param.typ, param.optional, scope, chain)
+			if err != nil {
+				return err
+			}
+			args[i] = value
+			continue
+		case paramObject:
+			value := reflect.New(param.typ).Elem()
+			if err := c.resolveInto(ctx, value, scope, chain); err != nil {
+				return err
+			}
+			args[i] = value
+			continue
+		}
+
+		resolved, err := c.resolve(ctx, param.typ, "", scope, chain)
+		if err != nil {
+			return err
+		}
+		args[i] = reflect.ValueOf(resolved)
+	}
+	return nil
+}
+
 // validateFactory ensures the factory has a valid signature.
 func validateFactory(targetType reflect.Type, factory any) error {
 	factoryValue := reflect.ValueOf(factory)
@@ -487,19 +1134,23 @@ func HasNamed[T any](c *Container, name string) bool {
 	var zero T
 	targetType := reflect.TypeOf(&zero).Elem()
 
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	key := registrationKey{typ: targetType, name: name}
-	_, exists := c.registrations[key]
-	return exists
+	return c.registered(targetType, name, nil)
 }
 
-// Clear removes all registrations, cached singletons, and scopes from the container.
+// Clear removes all registrations, aliases, lazy modules, templates, decorators,
+// selectors, cached singletons and scope family instances, and scopes from the
+// container, including the default scope.
+// Statistics reported by [Container.Stats] are reset.
 //
 // After calling Clear, the container is empty and new registrations must be made
 // before resolving any dependencies.
 //
+// Resolutions in flight while Clear runs do not cache or return instances of
+// the cleared registrations: they fail with an error wrapping
+// [ErrContainerReset], and the instances they constructed are disposed, as are
+// instances prewarmed by [WithPrewarm]. To let in-flight resolutions finish
+// first, quiesce the container before clearing it (see [Container.Quiesce]).
+//
 // This is useful in testing scenarios where you want to reset the container
 // between tests.
 //
@@ -512,9 +1163,41 @@ func HasNamed[T any](c *Container, name string) bool {
 //	}
 func (c *Container) Clear() {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	var prewarmed []*registration
+	for _, reg := range c.registrations {
+		if reg.idleTimer != nil {
+			reg.idleTimer.Stop()
+			reg.idleTimer = nil
+		}
+		if reg.prewarm > 0 {
+			prewarmed = append(prewarmed, reg)
+		}
+	}
 
 	c.registrations = make(map[registrationKey]*registration)
+	c.order = nil
+	c.declared = nil
 	c.singletons = make(map[registrationKey]any)
+	c.building = make(map[registrationKey]*singletonFlight)
 	c.scopes = make(map[string]*Scope)
+	c.selectors = make(map[reflect.Type]Selector)
+	c.decorators = make(map[reflect.Type][]*decorator)
+	c.matchingDecorators = nil
+	c.templates = make(map[reflect.Type]*template)
+	c.lazy = make(map[reflect.Type][]*lazyModule)
+	c.families = make(map[string]*scopeFamily)
+	c.contributed = make(map[string]bool)
+	c.aliases = make(map[registrationKey]string)
+	c.defaultScope = nil
+	c.generation++
+	c.stats.reset()
+	c.mu.Unlock()
+
+	for _, reg := range prewarmed {
+		for _, instance := range reg.drainPrewarmed() {
+			if err := disposeInstance(instance); err != nil {
+				c.reportDisposeError(reg.targetType, reg.name, err)
+			}
+		}
+	}
 }
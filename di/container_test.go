@@ -1,60 +1,18 @@
+//go:build !di_noreflect
+
 package di_test
 
 import (
 	"errors"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/pegasusheavy/go-dependency-injector/di"
 )
 
-// =============================================================================
-// Test Interfaces and Implementations
-// =============================================================================
-
-type Greeter interface {
-	Greet(name string) string
-}
-
-type SimpleGreeter struct{}
-
-func (g *SimpleGreeter) Greet(name string) string {
-	return "Hello, " + name
-}
-
-type Logger interface {
-	Log(msg string)
-}
-
-type TestLogger struct {
-	Messages []string
-}
-
-func (l *TestLogger) Log(msg string) {
-	l.Messages = append(l.Messages, msg)
-}
-
-type Service interface {
-	DoWork() string
-}
-
-type DefaultService struct {
-	logger  Logger
-	greeter Greeter
-}
-
-func (s *DefaultService) DoWork() string {
-	s.logger.Log("doing work")
-	return s.greeter.Greet("World")
-}
-
-type formalGreeter struct{}
-
-func (g *formalGreeter) Greet(name string) string {
-	return "Good day, " + name
-}
-
 // =============================================================================
 // Container Tests
 // =============================================================================
@@ -1059,3 +1017,116 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+// =============================================================================
+// RegisterType Implementation Checks
+// =============================================================================
+
+type valueGreeter struct{}
+
+func (g valueGreeter) Greet(name string) string {
+	return "Hi, " + name
+}
+
+func TestRegisterTypePointerReceiver(t *testing.T) {
+	c := di.New()
+
+	if err := di.RegisterType[Greeter, SimpleGreeter](c); err != nil {
+		t.Fatalf("failed to register type: %v", err)
+	}
+
+	greeter := di.MustResolve[Greeter](c)
+	if _, ok := greeter.(*SimpleGreeter); !ok {
+		t.Errorf("expected *SimpleGreeter, got %T", greeter)
+	}
+}
+
+func TestRegisterTypeValueReceiver(t *testing.T) {
+	c := di.New()
+
+	if err := di.RegisterType[valueGreeter, valueGreeter](c); err != nil {
+		t.Fatalf("failed to register type: %v", err)
+	}
+
+	greeter := di.MustResolve[valueGreeter](c)
+	if greeter.Greet("Test") != "Hi, Test" {
+		t.Error("value greeter not working correctly")
+	}
+}
+
+func TestRegisterTypeNotImplemented(t *testing.T) {
+	c := di.New()
+
+	err := di.RegisterType[Greeter, TestLogger](c)
+	if err == nil {
+		t.Fatal("expected error for implementation that does not satisfy the interface")
+	}
+
+	var invalid di.ErrInvalidFactory
+	if !errors.As(err, &invalid) {
+		t.Errorf("expected ErrInvalidFactory, got %T", err)
+	}
+	if di.Has[Greeter](c) {
+		t.Error("expected invalid mapping not to be registered")
+	}
+}
+
+type counterSet map[string]int
+
+func (s counterSet) Inc(key string) int {
+	s[key]++
+	return s[key]
+}
+
+type countingGreeter map[string]int
+
+func (g *countingGreeter) Greet(name string) string {
+	(*g)[name]++
+	return "Hello #" + strconv.Itoa((*g)[name]) + ", " + name
+}
+
+type handlerFunc func(name string) string
+
+func (f handlerFunc) Greet(name string) string { return f(name) }
+
+func TestRegisterTypeMapImplementation(t *testing.T) {
+	c := di.New()
+	if err := di.RegisterType[counterSet, counterSet](c); err != nil {
+		t.Fatalf("failed to register type: %v", err)
+	}
+	if err := di.RegisterType[Greeter, countingGreeter](c); err != nil {
+		t.Fatalf("failed to register type: %v", err)
+	}
+
+	if n := di.MustResolve[counterSet](c).Inc("hits"); n != 1 {
+		t.Errorf("expected an empty map, got count %d", n)
+	}
+	if got := di.MustResolve[Greeter](c).Greet("World"); got != "Hello #1, World" {
+		t.Errorf("expected an empty map behind the pointer, got %q", got)
+	}
+}
+
+func TestRegisterTypePointerImplementation(t *testing.T) {
+	c := di.New()
+	if err := di.RegisterType[Greeter, *SimpleGreeter](c); err != nil {
+		t.Fatalf("failed to register type: %v", err)
+	}
+
+	greeter, ok := di.MustResolve[Greeter](c).(*SimpleGreeter)
+	if !ok || greeter == nil {
+		t.Fatalf("expected an allocated *SimpleGreeter, got %#v", greeter)
+	}
+}
+
+func TestRegisterTypeRejectsFunctionImplementation(t *testing.T) {
+	c := di.New()
+
+	err := di.RegisterType[Greeter, handlerFunc](c)
+	var invalid di.ErrInvalidFactory
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected ErrInvalidFactory, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "cannot be constructed automatically") {
+		t.Errorf("expected the error to explain why, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// contextType is the reflect.Type of context.Context. Factory parameters of this
+// type receive the resolution context rather than being resolved from the container.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// ResolveCtx resolves a dependency using the given context.
+//
+// The context is passed to every factory in the resolution chain that declares a
+// context.Context parameter, and resolution stops as soon as the context is done.
+// A factory that ignores the context is abandoned only at the context's
+// deadline; one running when the context is cancelled finishes, and its
+// instance is disposed.
+// Factories do not need to be registered for context.Context; it is always
+// supplied by the container.
+//
+// If the context's deadline passes, the returned error is an [ErrResolutionTimeout]
+// identifying the dependency that was being resolved at the time.
+//
+// Example:
+//
+//	di.Register[*sql.DB](c, func(ctx context.Context, cfg Config) (*sql.DB, error) {
+//	    db, err := sql.Open("postgres", cfg.DatabaseURL())
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return db, db.PingContext(ctx)
+//	}, di.AsSingleton())
+//
+//	db, err := di.ResolveCtx[*sql.DB](ctx, c)
+func ResolveCtx[T any](ctx context.Context, c *Container) (T, error) {
+	return ResolveNamedCtx[T](ctx, c, "")
+}
+
+// ResolveNamedCtx resolves a named dependency using the given context.
+//
+// This is the context-aware variant of [ResolveNamed]. See [ResolveCtx] for how
+// the context is used.
+func ResolveNamedCtx[T any](ctx context.Context, c *Container, name string) (T, error) {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	result, err := c.resolve(ctx, targetType, name, nil, make([]reflect.Type, 0))
+	if err != nil {
+		return zero, err
+	}
+
+	return result.(T), nil
+}
+
+// ResolveWithDeadline resolves a dependency, aborting if the whole resolution
+// chain does not complete before the deadline.
+//
+// Factories that declare a context.Context parameter receive a context that is
+// cancelled at the deadline. Factories that ignore the context are abandoned when
+// the deadline passes; their eventual results are disposed and never cached.
+//
+// Example:
+//
+//	svc, err := di.ResolveWithDeadline[UserService](c, time.Now().Add(2*time.Second))
+//	if err != nil {
+//	    var timeout di.ErrResolutionTimeout
+//	    if errors.As(err, &timeout) {
+//	        log.Printf("startup stalled while constructing %s", timeout.Type)
+//	    }
+//	}
+func ResolveWithDeadline[T any](c *Container, deadline time.Time) (T, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	return ResolveCtx[T](ctx, c)
+}
+
+// callFactory invokes a factory, abandoning it if ctx's deadline passes before
+// it returns.
+//
+// The factory runs on its own goroutine only when ctx has a deadline, so that a
+// factory which ignores its context cannot hold the caller past the deadline;
+// whatever an abandoned factory eventually returns is disposed. Panics are
+// re-raised on the caller's goroutine. Without a deadline the factory is called
+// directly, and ctx is checked before and after the call: if ctx is done by the
+// time the factory returns, its instance is disposed.
+func callFactory(ctx context.Context, factory reflect.Value, args []reflect.Value, chain []reflect.Type) ([]reflect.Value, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, contextError(ctx, err, chain)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		results := callFunc(factory, args)
+		if err := ctx.Err(); err != nil {
+			disposeResults(results)
+			return nil, contextError(ctx, err, chain)
+		}
+		return results, nil
+	}
+
+	done := make(chan factoryOutcome, 1)
+	go func() {
+		var out factoryOutcome
+		defer func() {
+			out.panicked = recover()
+			done <- out
+		}()
+		out.results = callFunc(factory, args)
+	}()
+
+	select {
+	case out := <-done:
+		if out.panicked != nil {
+			panic(out.panicked)
+		}
+		return out.results, nil
+	case <-ctx.Done():
+		go disposeAbandoned(done)
+		return nil, contextError(ctx, ctx.Err(), chain)
+	}
+}
+
+// factoryOutcome is the result of a factory called on its own goroutine.
+type factoryOutcome struct {
+	results  []reflect.Value
+	panicked any
+}
+
+// disposeAbandoned waits for an abandoned factory and disposes the instance it
+// returns, which no caller will receive.
+func disposeAbandoned(done <-chan factoryOutcome) {
+	if out := <-done; out.panicked == nil {
+		disposeResults(out.results)
+	}
+}
+
+// disposeResults disposes the instance returned by a factory, unless the
+// factory failed.
+func disposeResults(results []reflect.Value) {
+	if len(results) == 0 {
+		return
+	}
+	if len(results) == 2 && !results[1].IsNil() {
+		return
+	}
+	if instance := results[0]; instance.IsValid() && instance.CanInterface() {
+		_ = disposeInstance(instance.Interface())
+	}
+}
+
+// contextError converts a context error into the error reported for the last
+// type in chain.
+func contextError(ctx context.Context, err error, chain []reflect.Type) error {
+	inFlight := chain[len(chain)-1]
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		deadline, _ := ctx.Deadline()
+		return ErrResolutionTimeout{
+			Type:     inFlight,
+			Chain:    append([]reflect.Type(nil), chain...),
+			Deadline: deadline,
+		}
+	}
+
+	return ErrResolutionFailed{Type: inFlight, Cause: err}
+}
+
+// ScopeForContext creates a scope that is disposed automatically when ctx is
+// done, so code that already bounds work with a context does not have to
+// remember to call [Scope.Dispose].
+//
+// The scope is created with [Container.CreateScope] under a generated name of
+// the form "context-<n>", and ctx becomes the scope's context (see
+// [Scope.SetContext]), so factories resolved in the scope receive it.
+// Disposal happens in its own goroutine shortly after ctx is done; a disposal
+// error is reported through [Hooks.OnDisposeError] with the *Scope type and
+// the scope's name. If ctx can never be done, such as context.Background(),
+// the scope must be disposed explicitly. Disposing the scope earlier is
+// allowed.
+//
+// Example:
+//
+//	func (s *Server) handle(ctx context.Context, job Job) error {
+//	    scope := di.ScopeForContext(s.container, ctx)
+//	    worker, err := di.ResolveInScope[*JobWorker](s.container, scope)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return worker.Run(ctx, job)
+//	}
+func ScopeForContext(c *Container, ctx context.Context) *Scope {
+	name := fmt.Sprintf("context-%d", c.contextScopes.Add(1))
+	scope := c.CreateScope(name)
+	scope.SetContext(ctx)
+
+	context.AfterFunc(ctx, func() {
+		if err := scope.Dispose(); err != nil {
+			c.reportDisposeError(scopeType, name, err)
+		}
+	})
+	return scope
+}
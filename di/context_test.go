@@ -0,0 +1,292 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Context-Aware Resolution Tests
+// =============================================================================
+
+type ctxService struct {
+	ctx context.Context
+}
+
+type ctxKey struct{}
+
+func TestResolveCtxInjectsContext(t *testing.T) {
+	c := di.New()
+
+	di.Register[*ctxService](c, func(ctx context.Context) *ctxService {
+		return &ctxService{ctx: ctx}
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	svc, err := di.ResolveCtx[*ctxService](ctx, c)
+	if err != nil {
+		t.Fatalf("failed to resolve: %v", err)
+	}
+
+	if svc.ctx.Value(ctxKey{}) != "value" {
+		t.Error("expected factory to receive the resolution context")
+	}
+}
+
+func TestResolveInjectsBackgroundContext(t *testing.T) {
+	c := di.New()
+
+	di.Register[*ctxService](c, func(ctx context.Context) *ctxService {
+		return &ctxService{ctx: ctx}
+	})
+
+	svc, err := di.Resolve[*ctxService](c)
+	if err != nil {
+		t.Fatalf("failed to resolve: %v", err)
+	}
+
+	if svc.ctx == nil {
+		t.Error("expected a non-nil context")
+	}
+}
+
+func TestResolveCtxCancelled(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := di.ResolveCtx[Greeter](ctx, c)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestResolveCtxCancelledDuringFactory(t *testing.T) {
+	c := di.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	var built *closableResource
+	di.Register[*closableResource](c, func() *closableResource {
+		cancel()
+		built = &closableResource{}
+		return built
+	})
+
+	// Without a deadline the factory runs on the caller's goroutine, so its
+	// instance is disposed before ResolveCtx returns
+	_, err := di.ResolveCtx[*closableResource](ctx, c)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if !built.closed.Load() {
+		t.Error("expected the instance constructed after cancellation to be disposed")
+	}
+}
+
+func TestResolveWithDeadline(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+
+	greeter, err := di.ResolveWithDeadline[Greeter](c, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("failed to resolve: %v", err)
+	}
+	if greeter.Greet("Test") != "Hello, Test" {
+		t.Error("greeter not working correctly")
+	}
+}
+
+func TestResolveWithDeadlineSlowFactory(t *testing.T) {
+	c := di.New()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	di.Register[Logger](c, func() Logger {
+		<-release
+		return &TestLogger{}
+	})
+	di.Register[Service](c, func(log Logger) Service {
+		return &DefaultService{logger: log}
+	})
+
+	_, err := di.ResolveWithDeadline[Service](c, time.Now().Add(20*time.Millisecond))
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+
+	var timeout di.ErrResolutionTimeout
+	if !errors.As(err, &timeout) {
+		t.Fatalf("expected ErrResolutionTimeout, got %T: %v", err, err)
+	}
+
+	loggerType := reflect.TypeOf((*Logger)(nil)).Elem()
+	if timeout.Type != loggerType {
+		t.Errorf("expected in-flight type %v, got %v", loggerType, timeout.Type)
+	}
+	if len(timeout.Chain) != 2 {
+		t.Errorf("expected chain of length 2, got %v", timeout.Chain)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expected error to wrap context.DeadlineExceeded")
+	}
+}
+
+func TestResolveWithDeadlineCancelsContextAwareFactory(t *testing.T) {
+	c := di.New()
+
+	cancelled := make(chan struct{})
+	di.Register[Logger](c, func(ctx context.Context) (Logger, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+
+	_, err := di.ResolveWithDeadline[Logger](c, time.Now().Add(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("expected factory context to be cancelled")
+	}
+}
+
+func TestResolveWithDeadlineDoesNotCacheAbandonedSingleton(t *testing.T) {
+	c := di.New()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	abandoned := &TestLogger{}
+
+	di.Register[*TestLogger](c, func() *TestLogger {
+		if calls.Add(1) == 1 {
+			<-release
+			return abandoned
+		}
+		return &TestLogger{}
+	}, di.AsSingleton())
+
+	_, err := di.ResolveWithDeadline[*TestLogger](c, time.Now().Add(5*time.Millisecond))
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	close(release)
+
+	logger, err := di.Resolve[*TestLogger](c)
+	if err != nil {
+		t.Fatalf("expected later resolution to succeed: %v", err)
+	}
+	if logger == abandoned {
+		t.Error("expected abandoned instance not to be cached")
+	}
+}
+
+func TestErrResolutionTimeoutError(t *testing.T) {
+	typ := reflect.TypeOf("")
+	err := di.ErrResolutionTimeout{Type: typ, Chain: []reflect.Type{typ}}
+	if !contains(err.Error(), "deadline exceeded") {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+}
+
+// =============================================================================
+// ScopeForContext Tests
+// =============================================================================
+
+func TestScopeForContextDisposesOnCancel(t *testing.T) {
+	disposeErrs := make(chan string, 1)
+	c := di.New(di.WithHooks(di.Hooks{
+		OnDisposeError: func(typ reflect.Type, name string, err error) { disposeErrs <- name },
+	}))
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsScoped())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scope := di.ScopeForContext(c, ctx)
+	resource, err := di.ResolveInScope[*closableResource](c, scope)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second := di.ScopeForContext(c, ctx)
+	if second.Name() == scope.Name() {
+		t.Errorf("expected unique scope names, got %q twice", scope.Name())
+	}
+
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for !resource.closed.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the scope to be disposed when the context is cancelled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case name := <-disposeErrs:
+		t.Errorf("unexpected dispose error for %s", name)
+	default:
+	}
+}
+
+// =============================================================================
+// Scope Context Tests
+// =============================================================================
+
+type requestIDKey struct{}
+
+type requestTagged struct {
+	requestID any
+}
+
+func TestResolveInScopeInjectsScopeContext(t *testing.T) {
+	c := di.New()
+	di.Register[*requestTagged](c, func(ctx context.Context) *requestTagged {
+		return &requestTagged{requestID: ctx.Value(requestIDKey{})}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request-1")
+	scope.SetContext(context.WithValue(context.Background(), requestIDKey{}, "req-1"))
+
+	tagged, err := di.ResolveInScope[*requestTagged](c, scope)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tagged.requestID != "req-1" {
+		t.Errorf("expected the factory to receive the scope's context, got %v", tagged.requestID)
+	}
+
+	branch := scope.Fork("request-1/branch")
+	if branch.Context().Value(requestIDKey{}) != "req-1" {
+		t.Error("expected forks to inherit the scope's context")
+	}
+	if c.CreateScope("other").Context() != context.Background() {
+		t.Error("expected scopes without a context to use context.Background()")
+	}
+}
+
+func TestResolveInScopeHonorsScopeDeadline(t *testing.T) {
+	c := di.New()
+	di.Register[*requestTagged](c, func() *requestTagged { return &requestTagged{} }, di.AsScoped())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	scope := di.ScopeForContext(c, ctx)
+
+	if _, err := di.ResolveInScope[*requestTagged](c, scope); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the scope's cancelled context to stop resolution, got %v", err)
+	}
+	if _, err := di.ResolveInScopeCtx[*requestTagged](context.Background(), c, scope); err != nil {
+		t.Errorf("expected an explicit context to take precedence, got %v", err)
+	}
+}
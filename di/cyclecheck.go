@@ -0,0 +1,67 @@
+package di
+
+import "reflect"
+
+// WithEagerCycleCheck makes the container reject, at registration, a
+// registration whose factory would close a dependency cycle with the
+// registrations already made.
+//
+// Without it, cycles are only found when a type on the cycle is resolved. With
+// it, the registration that completes a cycle fails with
+// [ErrCircularDependency] and is not stored, so the mistake is reported where
+// it is made. Only parameters resolved by type are followed: [Optional],
+// [Lazy], and provider parameters, which are the ways to break a cycle, are
+// not. Each registration walks the graph reachable from it, so large
+// containers pay for the check at startup; [Container.Validate] checks the
+// whole graph once instead.
+//
+// Example:
+//
+//	c := di.New(di.WithEagerCycleCheck())
+//	di.Register[*Orders](c, func(*Billing) *Orders { ... })
+//	err := di.Register[*Billing](c, func(*Orders) *Billing { ... })
+//	// err is an ErrCircularDependency: *Billing -> *Orders -> *Billing
+func WithEagerCycleCheck() ContainerOption {
+	return func(c *Container) {
+		c.eagerCycleCheck = true
+	}
+}
+
+// cycleThrough returns the dependency cycle that storing reg would close, from
+// reg's type back to it, or nil if there is none. The caller must hold c.mu.
+func (c *Container) cycleThrough(reg *registration) []reflect.Type {
+	key := registrationKey{typ: reg.targetType, name: reg.name}
+	lookup := func(typ reflect.Type) *registration {
+		if dep := (registrationKey{typ: typ}); dep != key {
+			return c.registrations[dep]
+		}
+		return reg
+	}
+
+	visited := make(map[*registration]bool)
+	chain := []reflect.Type{reg.targetType}
+	var visit func(from *registration) bool
+	visit = func(from *registration) bool {
+		for _, dep := range from.dependencyTypes() {
+			target := lookup(dep)
+			if target == nil || (target != reg && visited[target]) {
+				continue
+			}
+			chain = append(chain, dep)
+			if target == reg {
+				return true
+			}
+			visited[target] = true
+			if visit(target) {
+				return true
+			}
+			chain = chain[:len(chain)-1]
+		}
+		return false
+	}
+
+	if visit(reg) {
+		return chain
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Eager Cycle Check Tests
+// =============================================================================
+
+func TestEagerCycleCheck(t *testing.T) {
+	c := di.New(di.WithEagerCycleCheck())
+	di.Register[*chickenService](c, func(*eggService) *chickenService { return &chickenService{} })
+
+	err := di.Register[*eggService](c, func(*chickenService) *eggService { return &eggService{} })
+	var circular di.ErrCircularDependency
+	if !errors.As(err, &circular) || len(circular.Chain) != 3 {
+		t.Fatalf("expected the closing registration to be rejected, got %v", err)
+	}
+	if di.Has[*eggService](c) {
+		t.Error("expected the rejected registration not to be stored")
+	}
+
+	// A lazy parameter breaks the cycle
+	err = di.Register[*eggService](c, func(di.Lazy[*chickenService]) *eggService { return &eggService{} })
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := di.Register[Greeter](c, func(Greeter) Greeter { return &SimpleGreeter{} }); !errors.As(err, &circular) {
+		t.Errorf("expected a self-dependency to be rejected, got %v", err)
+	}
+}
+
+func TestWithoutEagerCycleCheck(t *testing.T) {
+	c := di.New()
+	di.Register[*chickenService](c, func(*eggService) *chickenService { return &chickenService{} })
+	if err := di.Register[*eggService](c, func(*chickenService) *eggService { return &eggService{} }); err != nil {
+		t.Errorf("expected cycles to be accepted at registration, got %v", err)
+	}
+}
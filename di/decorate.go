@@ -0,0 +1,189 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// decorator wraps the instances of a type as they are constructed.
+type decorator struct {
+	// fn is the decorator function. Its first parameter receives the instance
+	// being decorated; the others are resolved like factory parameters.
+	fn reflect.Value
+
+	// when reports whether the decorator applies. Nil means always.
+	when func(c *Container) bool
+}
+
+// Decorate wraps every newly constructed T with decorator, to add behavior
+// such as caching or logging around an already registered service without
+// registering it again.
+//
+// The decorator is a function whose first parameter is T and that returns T
+// or (T, error). Its other parameters are resolved from the container like
+// factory parameters. Decorators stack in the order they were added, so the
+// last one added is outermost. See [DecorateWhen] for the details, which apply
+// unchanged.
+//
+// Returns [ErrInvalidFactory] if decorator does not have a valid signature.
+//
+// Example:
+//
+//	di.Register[UserRepository](c, NewPostgresUserRepository, di.AsSingleton())
+//	di.Decorate[UserRepository](c, func(inner UserRepository, cache Cache) UserRepository {
+//	    return &cachedUserRepository{inner: inner, cache: cache}
+//	})
+//	di.Decorate[UserRepository](c, func(inner UserRepository, log Logger) UserRepository {
+//	    return &loggedUserRepository{inner: inner, log: log} // wraps the cache
+//	})
+func Decorate[T any](c *Container, decorator any) error {
+	return DecorateWhen[T](c, nil, decorator)
+}
+
+// DecorateWhen wraps every newly constructed T with decorator, but only when
+// predicate holds.
+//
+// The decorator is a function whose first parameter is T and that returns T
+// or (T, error), such as a caching layer around a repository. Its other
+// parameters are resolved from the container like factory parameters. It
+// applies to every registration of T, named or not, including registrations
+// made after DecorateWhen; decorators of the same type stack in the order they
+// were added, so the last one added is outermost.
+//
+// The predicate is evaluated when an instance is constructed, before the
+// decorator is called, so it can consult configuration or check for optional
+// registrations with [Has]. A singleton is therefore decorated according to
+// the predicate at its first resolution. Values registered with
+// [RegisterInstance] are not constructed and are never decorated.
+//
+// Returns [ErrInvalidFactory] if decorator does not have a valid signature.
+//
+// Example:
+//
+//	di.DecorateWhen[UserRepository](c,
+//	    func(c *di.Container) bool { return di.Has[Cache](c) },
+//	    func(inner UserRepository, cache Cache) UserRepository {
+//	        return &cachedUserRepository{inner: inner, cache: cache}
+//	    })
+func DecorateWhen[T any](c *Container, predicate func(c *Container) bool, decorator any) error {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	if err := validateDecorator(targetType, decorator); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.decorators[targetType] = append(c.decorators[targetType], newDecorator(decorator, predicate))
+	return nil
+}
+
+// DecorateMatching wraps every newly constructed instance of the
+// registrations that pred selects with wrap, to apply a uniform wrapper such
+// as a tracing or metrics proxy across many services at once.
+//
+// pred receives the description of the registration being constructed (see
+// [Container.Registrations]); a nil pred selects every registration. wrap
+// receives the instance and returns its replacement, which must be assignable
+// to the registered type, or construction fails with [ErrResolutionFailed].
+// Matching decorators run after the type decorators added with [DecorateWhen],
+// in the order they were added, and apply to registrations made later too.
+// Values registered with [RegisterInstance] are not constructed and are never
+// decorated. A nil wrap is ignored.
+//
+// Example:
+//
+//	c.DecorateMatching(
+//	    func(info di.RegistrationInfo) bool { return slices.Contains(info.Tags, "traced") },
+//	    func(instance any) any { return tracing.Proxy(instance) },
+//	)
+func (c *Container) DecorateMatching(pred func(RegistrationInfo) bool, wrap func(any) any) {
+	if wrap == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.matchingDecorators = append(c.matchingDecorators, &matchingDecorator{pred: pred, wrap: wrap})
+}
+
+// matchingDecorator wraps the instances of the registrations it selects.
+type matchingDecorator struct {
+	pred func(RegistrationInfo) bool
+	wrap func(any) any
+}
+
+// newDecorator creates a decorator for a validated decorator function.
+func newDecorator(fn any, when func(c *Container) bool) *decorator {
+	return &decorator{fn: reflect.ValueOf(fn), when: when}
+}
+
+// validateDecorator ensures a decorator function has a valid signature for
+// targetType.
+func validateDecorator(targetType reflect.Type, fn any) error {
+	if !reflectiveFactories {
+		return ErrInvalidFactory{Type: targetType, Message: errReflectionDisabled}
+	}
+	if err := validateFactory(targetType, fn); err != nil {
+		return err
+	}
+	fnType := reflect.TypeOf(fn)
+	if fnType.NumIn() == 0 || fnType.In(0) != targetType {
+		return ErrInvalidFactory{Type: targetType, Message: "decorator's first parameter must be " + targetType.String()}
+	}
+	return nil
+}
+
+// decorate applies the decorators of reg's type to a newly constructed
+// instance. The caller must not hold c.mu.
+func (c *Container) decorate(ctx context.Context, reg *registration, instance any, scope *Scope, chain []reflect.Type) (any, error) {
+	targetType := reg.targetType
+	c.mu.RLock()
+	decorators := c.decorators[targetType]
+	matching := c.matchingDecorators
+	c.mu.RUnlock()
+
+	for _, d := range decorators {
+		if d.when != nil && !d.when(c) {
+			continue
+		}
+
+		fnType := d.fn.Type()
+		args := make([]reflect.Value, fnType.NumIn())
+		args[0] = reflect.New(targetType).Elem()
+		if instance != nil {
+			args[0].Set(reflect.ValueOf(instance))
+		}
+		if err := c.resolveParams(ctx, fnType, args, scope, chain, reg.metadata()); err != nil {
+			return nil, err
+		}
+
+		results, err := callFactory(ctx, d.fn, args, chain)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) == 2 && !results[1].IsNil() {
+			return nil, results[1].Interface().(error)
+		}
+		instance = results[0].Interface()
+	}
+
+	if len(matching) == 0 {
+		return instance, nil
+	}
+	info := reg.info()
+	for _, d := range matching {
+		if d.pred != nil && !d.pred(info) {
+			continue
+		}
+		wrapped := d.wrap(instance)
+		if (wrapped == nil && instance != nil) || (wrapped != nil && !reflect.TypeOf(wrapped).AssignableTo(targetType)) {
+			return nil, fmt.Errorf("di: matching decorator returned %T, which is not assignable to %s", wrapped, targetType)
+		}
+		instance = wrapped
+	}
+	return instance, nil
+}
@@ -0,0 +1,138 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Decorator Tests
+// =============================================================================
+
+// prefixGreeter wraps a Greeter and prefixes its greetings.
+type prefixGreeter struct {
+	inner  Greeter
+	prefix string
+}
+
+func (g *prefixGreeter) Greet(name string) string {
+	return g.prefix + g.inner.Greet(name)
+}
+
+func TestDecorate(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	for _, prefix := range []string{"[cached] ", "[logged] "} {
+		err := di.Decorate[Greeter](c, func(inner Greeter, logger Logger) Greeter {
+			return &prefixGreeter{inner: inner, prefix: prefix}
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := di.MustResolve[Greeter](c).Greet("Ada"); got != "[logged] [cached] Hello, Ada" {
+		t.Errorf("expected decorators to stack in registration order, got %q", got)
+	}
+}
+
+func TestDecorateWhen(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsSingleton())
+
+	enabled := true
+	err := di.DecorateWhen[Greeter](c, func(*di.Container) bool { return enabled },
+		func(inner Greeter, logger Logger) Greeter {
+			return &prefixGreeter{inner: inner, prefix: "[logged] "}
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	greeter := di.MustResolve[Greeter](c)
+	if got := greeter.Greet("World"); got != "[logged] Hello, World" {
+		t.Errorf("expected decorated greeting, got %q", got)
+	}
+}
+
+func TestDecorateWhenPredicateFalse(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.DecorateWhen[Greeter](c, func(c *di.Container) bool { return di.Has[Logger](c) },
+		func(inner Greeter) Greeter { return &prefixGreeter{inner: inner, prefix: "> "} })
+
+	if got := di.MustResolve[Greeter](c).Greet("World"); got != "Hello, World" {
+		t.Errorf("expected undecorated greeting, got %q", got)
+	}
+
+	di.RegisterInstance[Logger](c, &TestLogger{})
+	if got := di.MustResolve[Greeter](c).Greet("World"); got != "> Hello, World" {
+		t.Errorf("expected decorated greeting, got %q", got)
+	}
+}
+
+func TestDecorateWhenStacksInOrder(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("simple"))
+	for _, prefix := range []string{"a ", "b "} {
+		di.DecorateWhen[Greeter](c, nil, func(inner Greeter) Greeter {
+			return &prefixGreeter{inner: inner, prefix: prefix}
+		})
+	}
+
+	if got := di.MustResolveNamed[Greeter](c, "simple").Greet("World"); got != "b a Hello, World" {
+		t.Errorf("expected stacked decorators, got %q", got)
+	}
+}
+
+func TestDecorateWhenErrors(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+
+	var invalid di.ErrInvalidFactory
+	if err := di.DecorateWhen[Greeter](c, nil, func(Logger) Greeter { return nil }); !errors.As(err, &invalid) {
+		t.Errorf("expected ErrInvalidFactory, got %v", err)
+	}
+
+	boom := errors.New("boom")
+	di.DecorateWhen[Greeter](c, nil, func(inner Greeter) (Greeter, error) { return nil, boom })
+	if _, err := di.Resolve[Greeter](c); !errors.Is(err, boom) {
+		t.Errorf("expected decorator error, got %v", err)
+	}
+}
+
+func TestDecorateMatching(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithTags("traced"))
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("plain"))
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.WithTags("traced"))
+
+	c.DecorateMatching(
+		func(info di.RegistrationInfo) bool { return len(info.Tags) > 0 && info.Tags[0] == "traced" },
+		func(instance any) any {
+			if g, ok := instance.(Greeter); ok {
+				return &prefixGreeter{inner: g, prefix: "[traced] "}
+			}
+			return instance
+		})
+
+	if got := di.MustResolve[Greeter](c).Greet("World"); got != "[traced] Hello, World" {
+		t.Errorf("expected the matching registration to be wrapped, got %q", got)
+	}
+	if got := di.MustResolveNamed[Greeter](c, "plain").Greet("World"); got != "Hello, World" {
+		t.Errorf("expected other registrations to be left alone, got %q", got)
+	}
+
+	c.DecorateMatching(nil, func(any) any { return "not a greeter" })
+	var failed di.ErrResolutionFailed
+	if _, err := di.Resolve[Greeter](c); !errors.As(err, &failed) {
+		t.Errorf("expected ErrResolutionFailed for an unassignable wrapper, got %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WarningKind identifies the kind of problem a [Warning] reports.
+type WarningKind string
+
+const (
+	// WarningDuplicateFactory reports that the same factory function is registered
+	// for several types where at least one registration caches its instance.
+	// Each registration caches its own instance, so a resource that was meant to
+	// be shared ends up constructed more than once.
+	WarningDuplicateFactory WarningKind = "duplicate-factory"
+
+	// WarningDuplicateInstance reports that several registrations have cached
+	// the same underlying pointer, typically because their factories return a
+	// package-level instance. Each registration is disposed on its own, so the
+	// shared instance is disposed more than once and in an order no single
+	// registration controls.
+	WarningDuplicateInstance WarningKind = "duplicate-instance"
+
+	// WarningStartupBudget reports that constructing the eager singletons and
+	// hosted services took longer than the budget set with
+	// [WithStartupBudgetWarning]. It names the slowest registrations. It is
+	// only reported through [Hooks.OnWarning], when Start completes, and is
+	// not listed by [Container.Warnings].
+	WarningStartupBudget WarningKind = "startup-budget"
+
+	// WarningOutlivedDependency reports that a singleton a hosted service
+	// depends on is disposed while the service may still be running: because
+	// the service failed to stop before [Container.Close] disposed the
+	// singleton, or because the singleton was evicted (see
+	// [WithIdleEviction]). It is only reported through [Hooks.OnWarning] and is
+	// not listed by [Container.Warnings].
+	WarningOutlivedDependency WarningKind = "outlived-dependency"
+)
+
+// Warning describes a likely wiring mistake detected by the container.
+//
+// Warnings do not prevent registration or resolution. They are reported through
+// [Hooks.OnWarning] as soon as they are detected and can be listed at any time
+// with [Container.Warnings].
+type Warning struct {
+	// Kind identifies the problem.
+	Kind WarningKind
+	// Message is a human-readable description of the problem.
+	Message string
+	// Registrations are the registrations involved.
+	Registrations []RegistrationInfo
+}
+
+// String returns the warning's kind and message.
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Kind, w.Message)
+}
+
+// Warnings inspects the current registrations and cached singletons and
+// returns every detected wiring problem, in a stable order.
+//
+// Example:
+//
+//	for _, w := range container.Warnings() {
+//	    log.Printf("di: %s", w)
+//	}
+func (c *Container) Warnings() []Warning {
+	warnings := duplicateFactoryWarnings(c.orderedRegistrations(), nil)
+	return append(warnings, c.duplicateInstanceWarnings(nil)...)
+}
+
+// factoryIdentity identifies a user-supplied factory function. Function values
+// are not comparable, so the code pointer and signature are used instead.
+type factoryIdentity struct {
+	code uintptr
+	typ  reflect.Type
+}
+
+// identifyFactory returns the identity of a factory function.
+func identifyFactory(factory any) factoryIdentity {
+	v := reflect.ValueOf(factory)
+	return factoryIdentity{code: v.Pointer(), typ: v.Type()}
+}
+
+// duplicateFactoryWarnings reports factories registered for more than one type
+// where the registrations would not share a single instance. If only is non-nil,
+// only warnings involving that registration are returned.
+func duplicateFactoryWarnings(regs []*registration, only *registration) []Warning {
+	groups := make(map[factoryIdentity][]*registration)
+	var order []factoryIdentity
+	for _, reg := range regs {
+		if !reg.userFactory {
+			continue
+		}
+		id := identifyFactory(reg.factory)
+		if _, seen := groups[id]; !seen {
+			order = append(order, id)
+		}
+		groups[id] = append(groups[id], reg)
+	}
+
+	var warnings []Warning
+	for _, id := range order {
+		group := groups[id]
+		if !sharesAcrossTypes(group) || (only != nil && !containsRegistration(group, only)) {
+			continue
+		}
+
+		infos := make([]RegistrationInfo, len(group))
+		descriptions := make([]string, len(group))
+		for i, reg := range group {
+			infos[i] = reg.info()
+			descriptions[i] = fmt.Sprintf("%s (%s)", describeRegistration(reg.targetType, reg.name), reg.lifetime)
+		}
+
+		warnings = append(warnings, Warning{
+			Kind: WarningDuplicateFactory,
+			Message: fmt.Sprintf("factory %s is registered for %s; each registration caches its own instance",
+				id.typ, strings.Join(descriptions, ", ")),
+			Registrations: infos,
+		})
+	}
+
+	return warnings
+}
+
+// sharesAcrossTypes reports whether a group of registrations sharing a factory
+// spans several types and would construct more than one cached instance.
+func sharesAcrossTypes(group []*registration) bool {
+	if len(group) < 2 {
+		return false
+	}
+
+	types := make(map[reflect.Type]bool)
+	lifetimes := make(map[Lifetime]bool)
+	singleton := false
+	for _, reg := range group {
+		types[reg.targetType] = true
+		lifetimes[reg.lifetime] = true
+		singleton = singleton || reg.lifetime == Singleton
+	}
+
+	return len(types) > 1 && (len(lifetimes) > 1 || singleton)
+}
+
+// containsRegistration reports whether reg is in regs.
+func containsRegistration(regs []*registration, reg *registration) bool {
+	for _, r := range regs {
+		if r == reg {
+			return true
+		}
+	}
+	return false
+}
+
+// describeRegistration renders a type and optional name for messages.
+func describeRegistration(typ reflect.Type, name string) string {
+	if name == "" {
+		return typ.String()
+	}
+	return fmt.Sprintf("%s[%q]", typ, name)
+}
+
+// duplicateInstanceWarnings reports cached singletons that share an underlying
+// pointer with a singleton of another registration. If only is non-nil, only
+// warnings involving that registration are returned.
+func (c *Container) duplicateInstanceWarnings(only *registration) []Warning {
+	regs := c.orderedRegistrations()
+
+	groups := make(map[uintptr][]*registration)
+	var order []uintptr
+	c.mu.RLock()
+	for _, reg := range regs {
+		instance, ok := c.singletons[registrationKey{typ: reg.targetType, name: reg.name}]
+		if !ok {
+			continue
+		}
+		id, ok := instanceIdentity(instance)
+		if !ok {
+			continue
+		}
+		if _, seen := groups[id]; !seen {
+			order = append(order, id)
+		}
+		groups[id] = append(groups[id], reg)
+	}
+	c.mu.RUnlock()
+
+	var warnings []Warning
+	for _, id := range order {
+		group := groups[id]
+		if len(group) < 2 || (only != nil && !containsRegistration(group, only)) {
+			continue
+		}
+
+		infos := make([]RegistrationInfo, len(group))
+		descriptions := make([]string, len(group))
+		for i, reg := range group {
+			infos[i] = reg.info()
+			descriptions[i] = describeRegistration(reg.targetType, reg.name)
+		}
+
+		warnings = append(warnings, Warning{
+			Kind: WarningDuplicateInstance,
+			Message: fmt.Sprintf("%s cache the same instance; each registration disposes it separately",
+				strings.Join(descriptions, ", ")),
+			Registrations: infos,
+		})
+	}
+
+	return warnings
+}
+
+// instanceIdentity returns the address an instance refers to, if it is a
+// reference whose address identifies it. Pointers to zero-size values are
+// excluded because distinct zero-size allocations may share an address.
+func instanceIdentity(instance any) (uintptr, bool) {
+	v := reflect.ValueOf(instance)
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || v.Type().Elem().Size() == 0 {
+			return 0, false
+		}
+	case reflect.Map, reflect.Chan, reflect.UnsafePointer:
+		if v.IsNil() {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return v.Pointer(), true
+}
@@ -0,0 +1,139 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Duplicate Factory Detection Tests
+// =============================================================================
+
+func newSharedLogger() *TestLogger {
+	return &TestLogger{}
+}
+
+func TestDuplicateFactoryWarning(t *testing.T) {
+	var warnings []di.Warning
+	c := di.New(di.WithHooks(di.Hooks{
+		OnWarning: func(w di.Warning) { warnings = append(warnings, w) },
+	}))
+
+	di.Register[*TestLogger](c, newSharedLogger, di.AsSingleton())
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings after first registration, got %v", warnings)
+	}
+
+	di.Register[Logger](c, newSharedLogger)
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d", len(warnings))
+	}
+
+	w := warnings[0]
+	if w.Kind != di.WarningDuplicateFactory {
+		t.Errorf("expected duplicate-factory warning, got %s", w.Kind)
+	}
+	if len(w.Registrations) != 2 {
+		t.Errorf("expected 2 registrations in warning, got %d", len(w.Registrations))
+	}
+	if !contains(w.String(), "duplicate-factory") {
+		t.Errorf("unexpected warning string: %s", w)
+	}
+
+	if listed := c.Warnings(); len(listed) != 1 {
+		t.Errorf("expected Warnings to list 1 warning, got %d", len(listed))
+	}
+}
+
+func TestDuplicateFactoryTwoSingletons(t *testing.T) {
+	c := di.New()
+
+	di.Register[*TestLogger](c, newSharedLogger, di.AsSingleton())
+	di.Register[Logger](c, newSharedLogger, di.AsSingleton())
+
+	if warnings := c.Warnings(); len(warnings) != 1 {
+		t.Errorf("expected two singletons from one factory to warn, got %v", warnings)
+	}
+}
+
+func TestDuplicateFactoryTransientNoWarning(t *testing.T) {
+	c := di.New()
+
+	di.Register[*TestLogger](c, newSharedLogger)
+	di.Register[Logger](c, newSharedLogger)
+	di.Register[*TestLogger](c, newSharedLogger, di.WithName("other"))
+
+	if warnings := c.Warnings(); len(warnings) != 0 {
+		t.Errorf("expected no warnings for transient registrations, got %v", warnings)
+	}
+}
+
+func TestRegisterTypeNoDuplicateFactoryWarning(t *testing.T) {
+	c := di.New()
+
+	di.RegisterType[Greeter, SimpleGreeter](c, di.AsSingleton())
+	di.RegisterType[Logger, TestLogger](c)
+
+	if warnings := c.Warnings(); len(warnings) != 0 {
+		t.Errorf("expected no warnings for synthesized factories, got %v", warnings)
+	}
+}
+
+// =============================================================================
+// Duplicate Instance Detection Tests
+// =============================================================================
+
+var sharedLogger = &TestLogger{}
+
+func TestDuplicateInstanceWarning(t *testing.T) {
+	var warnings []di.Warning
+	c := di.New(di.WithHooks(di.Hooks{
+		OnWarning: func(w di.Warning) { warnings = append(warnings, w) },
+	}))
+
+	di.Register[Logger](c, func() Logger { return sharedLogger }, di.AsSingleton())
+	di.Register[*TestLogger](c, func() *TestLogger { return sharedLogger }, di.AsSingleton())
+
+	di.MustResolve[Logger](c)
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings before the second instance is cached, got %v", warnings)
+	}
+
+	di.MustResolve[*TestLogger](c)
+	if len(warnings) != 1 || warnings[0].Kind != di.WarningDuplicateInstance {
+		t.Fatalf("expected 1 duplicate-instance warning, got %v", warnings)
+	}
+	if len(warnings[0].Registrations) != 2 {
+		t.Errorf("expected 2 registrations in warning, got %d", len(warnings[0].Registrations))
+	}
+
+	if listed := c.Warnings(); len(listed) != 1 || listed[0].Kind != di.WarningDuplicateInstance {
+		t.Errorf("expected Warnings to list the duplicate instance, got %v", listed)
+	}
+}
+
+func TestDuplicateInstanceRegisteredInstance(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[*TestLogger](c, sharedLogger)
+	di.Register[Logger](c, func() Logger { return sharedLogger }, di.AsSingleton())
+	di.MustResolve[Logger](c)
+
+	if warnings := c.Warnings(); len(warnings) != 1 {
+		t.Errorf("expected a registered instance to count as cached, got %v", warnings)
+	}
+}
+
+func TestDuplicateInstanceDistinctInstancesNoWarning(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton())
+	di.Register[*TestLogger](c, func() *TestLogger { return &TestLogger{} }, di.AsSingleton())
+	di.MustResolve[Logger](c)
+	di.MustResolve[*TestLogger](c)
+
+	if warnings := c.Warnings(); len(warnings) != 0 {
+		t.Errorf("expected no warnings for distinct instances, got %v", warnings)
+	}
+}
@@ -0,0 +1,127 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ResolveImplementing resolves every registration whose instances satisfy the
+// interface iface.
+//
+// This supports discovery-style frameworks built on the container, such as
+// running every registered migration or scheduling every registered job,
+// without a central list that has to be kept in sync with the registrations.
+//
+// A registration matches when its registered type implements iface, or when
+// the concrete type it produces is known to: the type of a [RegisterInstance]
+// value, the implementation type of a [RegisterType] registration, or the
+// declared return type of a [Register] factory. A Logger registration whose
+// factory is declared to return *FileLogger therefore matches io.Closer if
+// *FileLogger has a Close method, even though Logger does not.
+//
+// Matching registrations are resolved in the order of [Container.Registrations]
+// and honor their lifetimes: singletons are constructed once and shared with
+// other consumers. Resolution stops at the first error.
+//
+// Returns [ErrResolutionFailed] if iface is not an interface type.
+//
+// Example:
+//
+//	jobs, err := container.ResolveImplementing(reflect.TypeOf((*Job)(nil)).Elem())
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, job := range jobs {
+//	    scheduler.Add(job.(Job))
+//	}
+func (c *Container) ResolveImplementing(iface reflect.Type) ([]any, error) {
+	if iface == nil || iface.Kind() != reflect.Interface {
+		return nil, ErrResolutionFailed{Type: iface, Cause: fmt.Errorf("%v is not an interface type", iface)}
+	}
+
+	var results []any
+	for _, reg := range c.orderedRegistrations() {
+		if !reg.implements(iface) {
+			continue
+		}
+
+		instance, err := c.resolve(context.Background(), reg.targetType, reg.name, nil, make([]reflect.Type, 0))
+		if err != nil {
+			return nil, err
+		}
+		// The static check can be satisfied by a type the factory declares
+		// while the value it returns differs; only report real matches.
+		if instance != nil && reflect.TypeOf(instance).Implements(iface) {
+			results = append(results, instance)
+		}
+	}
+
+	return results, nil
+}
+
+// ResolveAll resolves every registration, named and unnamed, whose instances
+// are assignable to T.
+//
+// This collects the implementations that independent modules contribute, such
+// as every health checker or migration, without the modules having to agree on
+// names. For an interface T, registrations match as described for
+// [Container.ResolveImplementing]; for any other T, registrations of T itself
+// (and of types assignable to it) match. Registrations whose conditions do not
+// hold (see [WhenRegistered]) are skipped.
+//
+// Matching registrations are resolved in the order of [Container.Registrations]
+// and honor their lifetimes. Resolution stops at the first error. If nothing
+// matches, ResolveAll returns an empty slice and no error.
+//
+// Example:
+//
+//	di.Register[HealthChecker](c, newDBCheck, di.WithName("db"))
+//	di.Register[HealthChecker](c, newCacheCheck, di.WithName("cache"))
+//	di.Register[*QueueCheck](c, newQueueCheck)
+//
+//	checks, err := di.ResolveAll[HealthChecker](c) // all three
+func ResolveAll[T any](c *Container) ([]T, error) {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	results := make([]T, 0)
+	for _, reg := range c.orderedRegistrations() {
+		if !reg.assignableTo(targetType) || !reg.enabled(c) {
+			continue
+		}
+
+		instance, err := c.resolve(context.Background(), reg.targetType, reg.name, nil, make([]reflect.Type, 0))
+		if err != nil {
+			return nil, err
+		}
+		if value, ok := instance.(T); ok {
+			results = append(results, value)
+		}
+	}
+	return results, nil
+}
+
+// assignableTo reports whether instances of the registration are known to be
+// assignable to typ without constructing one.
+func (r *registration) assignableTo(typ reflect.Type) bool {
+	return r.targetType.AssignableTo(typ) || (typ.Kind() == reflect.Interface && r.implements(typ))
+}
+
+// implements reports whether instances of the registration are known to
+// satisfy iface without constructing one.
+func (r *registration) implements(iface reflect.Type) bool {
+	if r.targetType.Implements(iface) {
+		return true
+	}
+
+	switch {
+	case r.instance != nil:
+		return reflect.TypeOf(r.instance).Implements(iface)
+	case r.implType != nil:
+		return r.implType.Implements(iface) || reflect.PointerTo(r.implType).Implements(iface)
+	case r.factory != nil:
+		return reflect.TypeOf(r.factory).Out(0).Implements(iface)
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// ResolveImplementing Tests
+// =============================================================================
+
+type job interface {
+	Run() string
+}
+
+type cleanupJob struct{}
+
+func (cleanupJob) Run() string { return "cleanup" }
+
+type reportJob struct{}
+
+func (*reportJob) Run() string         { return "report" }
+func (*reportJob) Greet(string) string { return "" }
+
+func TestResolveImplementing(t *testing.T) {
+	c := di.New()
+
+	di.RegisterInstance[cleanupJob](c, cleanupJob{})
+	di.Register[Greeter](c, func() *reportJob { return &reportJob{} }, di.WithName("report"), di.AsSingleton())
+	di.RegisterType[Greeter, SimpleGreeter](c)
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	jobType := reflect.TypeOf((*job)(nil)).Elem()
+	jobs, err := c.ResolveImplementing(jobType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d: %v", len(jobs), jobs)
+	}
+
+	var names []string
+	for _, j := range jobs {
+		names = append(names, j.(job).Run())
+	}
+	if names[0] != "cleanup" || names[1] != "report" {
+		t.Errorf("expected registration order [cleanup report], got %v", names)
+	}
+
+	// Singletons are shared with regular resolution
+	again, _ := c.ResolveImplementing(jobType)
+	if again[1] != jobs[1] {
+		t.Error("expected singleton job to be reused")
+	}
+	if g := di.MustResolveNamed[Greeter](c, "report"); g != jobs[1] {
+		t.Error("expected discovered singleton to match resolved singleton")
+	}
+}
+
+func TestResolveImplementingNoMatches(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	closers, err := c.ResolveImplementing(reflect.TypeOf((*io.Closer)(nil)).Elem())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(closers) != 0 {
+		t.Errorf("expected no matches, got %v", closers)
+	}
+}
+
+func TestResolveImplementingErrors(t *testing.T) {
+	c := di.New()
+
+	if _, err := c.ResolveImplementing(reflect.TypeOf(cleanupJob{})); err == nil {
+		t.Error("expected error for non-interface type")
+	}
+
+	di.Register[job](c, func(l Logger) job { return cleanupJob{} })
+	if _, err := c.ResolveImplementing(reflect.TypeOf((*job)(nil)).Elem()); err == nil {
+		t.Error("expected resolution error to be returned")
+	}
+}
+
+// =============================================================================
+// ResolveAll Tests
+// =============================================================================
+
+func TestResolveAll(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[job](c, cleanupJob{}, di.WithName("cleanup"))
+	di.Register[*reportJob](c, func() *reportJob { return &reportJob{} })
+	di.RegisterInstance[Logger](c, &TestLogger{})
+	di.RegisterInstance[job](c, cleanupJob{}, di.WithName("disabled"), di.WhenRegistered[Greeter]())
+
+	jobs, err := di.ResolveAll[job](c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Run() != "cleanup" || jobs[1].Run() != "report" {
+		t.Errorf("expected [cleanup report], got %v", jobs)
+	}
+
+	reports, err := di.ResolveAll[*reportJob](c)
+	if err != nil || len(reports) != 1 {
+		t.Errorf("expected the concrete registration, got %v, %v", reports, err)
+	}
+	if none, err := di.ResolveAll[io.Reader](c); err != nil || len(none) != 0 {
+		t.Errorf("expected no matches, got %v, %v", none, err)
+	}
+}
@@ -0,0 +1,65 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Disposable is implemented by instances that release resources when the
+// container discards them, as an alternative to io.Closer for types whose
+// Close method means something else.
+//
+// The container disposes the instances it owns when they leave its caches:
+// singletons on [Container.Close] or eviction, scoped instances on
+// [Scope.Dispose]. An instance that implements both Disposable and io.Closer
+// is disposed with Dispose only.
+//
+// Example:
+//
+//	type Pool struct{ conns []net.Conn }
+//
+//	func (p *Pool) Dispose() error {
+//	    var errs []error
+//	    for _, conn := range p.conns {
+//	        errs = append(errs, conn.Close())
+//	    }
+//	    return errors.Join(errs...)
+//	}
+type Disposable interface {
+	Dispose() error
+}
+
+// disposeInstance releases the resources held by an instance the container is
+// discarding. Instances that implement Disposable are disposed, and those that
+// implement io.Closer are closed; others are left for the garbage collector.
+func disposeInstance(instance any) error {
+	switch v := instance.(type) {
+	case Disposable:
+		return v.Dispose()
+	case io.Closer:
+		return v.Close()
+	}
+	return nil
+}
+
+// runUntilDone calls fn and returns its error, or abandons it and returns
+// ctx's error once ctx is done. An abandoned fn keeps running on its own
+// goroutine; its result is discarded.
+func runUntilDone(ctx context.Context, fn func() error) error {
+	if ctx.Done() == nil {
+		return fn()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("abandoned: %w", ctx.Err())
+	}
+}
@@ -93,6 +93,17 @@
 //
 //	di.RegisterType[UserRepository, PostgresUserRepository](c, di.AsSingleton())
 //
+// Register several services from one constructor with a result object (see
+// [Out]):
+//
+//	type DBHandles struct {
+//	    di.Out
+//	    Reader *sql.DB `name:"reader"`
+//	    Writer *sql.DB `name:"writer"`
+//	}
+//
+//	di.Register[DBHandles](c, NewDBHandles, di.AsSingleton())
+//
 // # Named Registrations
 //
 // Multiple implementations of the same interface can be registered with names:
@@ -109,6 +120,53 @@
 //	consoleLogger, _ := di.ResolveNamed[Logger](c, "console")
 //	fileLogger, _ := di.ResolveNamed[Logger](c, "file")
 //
+// # Context-Aware Resolution
+//
+// Factories may declare a context.Context parameter. The container supplies the
+// context passed to [ResolveCtx], or context.Background() for plain [Resolve]:
+//
+//	di.Register[*sql.DB](c, func(ctx context.Context, cfg Config) (*sql.DB, error) {
+//	    return openAndPing(ctx, cfg.DatabaseURL())
+//	}, di.AsSingleton())
+//
+// Use [ResolveWithDeadline] to bound how long a whole resolution chain may take:
+//
+//	db, err := di.ResolveWithDeadline[*sql.DB](c, time.Now().Add(5*time.Second))
+//
+// [ResolveInScope] supplies the scope's context instead (see
+// [Scope.SetContext]), which [ScopeForContext] and the dihttp middleware set to
+// the job's or request's context.
+//
+// # Optional, Lazy, and Provider Parameters
+//
+// A factory parameter of type [Optional][T] receives a zero value instead of
+// failing when T is not registered, and a [Lazy][T] parameter resolves T only
+// on its first Get. A parameter of type func() (T, error), unless that
+// function type is itself registered, receives a provider that resolves T
+// within the factory's scope on every call, so a service can mint fresh
+// transient instances without holding a reference to the container:
+//
+//	di.Register[*Dispatcher](c, func(newJob func() (*Job, error)) *Dispatcher {
+//	    return &Dispatcher{newJob: newJob}
+//	}, di.AsScoped())
+//
+// None of these parameters is reported as a dependency by
+// [Container.Registrations], since the registration can be constructed
+// without resolving them.
+//
+// # Hosted Services
+//
+// Registrations that implement [HostedService] are background services run by
+// the container. [Container.Start] resolves and starts them, and
+// [Container.Stop] stops them in reverse order:
+//
+//	di.Register[*QueueConsumer](c, NewQueueConsumer, di.AsSingleton())
+//
+//	if err := c.Start(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer c.Stop(context.Background())
+//
 // # Error Handling
 //
 // The package provides typed errors for precise error handling:
@@ -124,6 +182,8 @@
 //	        // Factory returned an error or dependency failed
 //	    case di.ErrInvalidFactory:
 //	        // Factory signature is invalid
+//	    case di.ErrResolutionTimeout:
+//	        // Resolution did not finish before the deadline
 //	    }
 //	}
 //
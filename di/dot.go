@@ -0,0 +1,68 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GraphDOT returns the container's dependency graph in Graphviz DOT format.
+//
+// Each registration is a node labeled with its type, name, and lifetime, and
+// each factory parameter resolved from the container is an edge from the
+// registration to the registration that satisfies it. Dependencies that are
+// not registered appear as dashed red nodes. Render the output with Graphviz
+// to see why a service is constructed and what it pulls in.
+//
+// Example:
+//
+//	os.WriteFile("wiring.dot", []byte(container.GraphDOT()), 0o644)
+//	// $ dot -Tsvg wiring.dot -o wiring.svg
+func (c *Container) GraphDOT() string {
+	regs := c.orderedRegistrations()
+	graph := newDependencyGraph(regs)
+
+	var b strings.Builder
+	b.WriteString("digraph di {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, fontname=\"Helvetica\"];\n")
+
+	ids := make(map[*registration]string, len(regs))
+	for i, reg := range regs {
+		ids[reg] = fmt.Sprintf("n%d", i)
+		label := reg.targetType.String()
+		if reg.name != "" {
+			label += fmt.Sprintf("\n%q", reg.name)
+		}
+		label += "\n" + reg.lifetime.String()
+		fmt.Fprintf(&b, "\t%s [label=%s];\n", ids[reg], dotQuote(label))
+	}
+
+	missing := make(map[reflect.Type]string)
+	for _, reg := range regs {
+		for _, edge := range graph.dependencies(reg) {
+			target := ids[edge.target]
+			if edge.target == nil {
+				var ok bool
+				if target, ok = missing[edge.typ]; !ok {
+					target = fmt.Sprintf("m%d", len(missing))
+					missing[edge.typ] = target
+					fmt.Fprintf(&b, "\t%s [label=%s, style=dashed, color=red];\n",
+						target, dotQuote(edge.typ.String()+"\nnot registered"))
+				}
+			}
+			fmt.Fprintf(&b, "\t%s -> %s;\n", ids[reg], target)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote returns s as a quoted DOT string, with newlines as line breaks.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,38 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// DOT Export Tests
+// =============================================================================
+
+func TestGraphDOT(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton())
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("formal"))
+	di.Register[Service](c, func(logger Logger, greeter Greeter) Service {
+		return &DefaultService{logger: logger, greeter: greeter}
+	}, di.AsScoped())
+
+	dot := c.GraphDOT()
+	for _, want := range []string{
+		"digraph di {",
+		`n0 [label="di_test.Logger\nSingleton"];`,
+		`n1 [label="di_test.Greeter\n\"formal\"\nTransient"];`,
+		`n2 [label="di_test.Service\nScoped"];`,
+		"n2 -> n0;",
+		`m0 [label="di_test.Greeter\nnot registered", style=dashed, color=red];`,
+		"n2 -> m0;",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("expected DOT output to contain %q, got:\n%s", want, dot)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DryRunAction describes what a resolution would do for one step of a
+// [DryRunReport].
+type DryRunAction string
+
+const (
+	// DryRunConstruct means the registration's factory would be invoked.
+	DryRunConstruct DryRunAction = "construct"
+	// DryRunCacheHit means a cached singleton or scoped instance would be
+	// returned, including one constructed earlier in the same resolution.
+	DryRunCacheHit DryRunAction = "cache-hit"
+	// DryRunInstance means a [RegisterInstance] value would be returned.
+	DryRunInstance DryRunAction = "instance"
+	// DryRunFail means the step would fail; see [DryRunStep.Err].
+	DryRunFail DryRunAction = "fail"
+)
+
+// DryRunStep is one dependency visited by a dry run.
+type DryRunStep struct {
+	// Type is the type being resolved.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Lifetime is the registration's lifetime. It is meaningless for failed
+	// steps whose type is not registered.
+	Lifetime Lifetime
+	// Action is what the resolution would do for this dependency.
+	Action DryRunAction
+	// Depth is the distance from the requested type, which has depth 0.
+	Depth int
+	// Err is the error the step would fail with, for DryRunFail steps.
+	Err error
+}
+
+// DryRunReport is the resolution plan produced by [DryRun].
+type DryRunReport struct {
+	// Steps lists every dependency visited, in the order the resolution would
+	// visit them. A step's dependencies follow it at greater depth.
+	Steps []DryRunStep
+}
+
+// Err returns the errors the resolution would fail with, joined, or nil if it
+// would succeed.
+func (r DryRunReport) Err() error {
+	var errs []error
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			errs = append(errs, step.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Constructions returns the steps whose factories would run.
+func (r DryRunReport) Constructions() []DryRunStep {
+	var steps []DryRunStep
+	for _, step := range r.Steps {
+		if step.Action == DryRunConstruct {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// String renders the plan as an indented tree.
+func (r DryRunReport) String() string {
+	var b strings.Builder
+	for _, step := range r.Steps {
+		fmt.Fprintf(&b, "%s%s [%s] %s", strings.Repeat("  ", step.Depth),
+			describeRegistration(step.Type, step.Name), step.Lifetime, step.Action)
+		if step.Err != nil {
+			fmt.Fprintf(&b, ": %v", step.Err)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// DryRun walks the resolution plan for T without constructing anything.
+//
+// The report lists which factories would run, which cached instances would be
+// reused, and where the resolution would fail: missing registrations,
+// circular dependencies, and selector errors. Factory errors cannot be
+// predicted because factories are not called. Selectors are consulted, so a
+// randomized selector such as [WithRollout] reports one possible plan.
+//
+// Resolution statistics are not affected. This makes DryRun a safe preflight
+// check for operators and CLIs.
+//
+// Example:
+//
+//	report := di.DryRun[*Server](container)
+//	if err := report.Err(); err != nil {
+//	    fmt.Print(report)
+//	    os.Exit(1)
+//	}
+func DryRun[T any](c *Container) DryRunReport {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	run := &dryRun{
+		c:       c,
+		planned: make(map[registrationKey]bool),
+	}
+	run.walk(targetType, "", nil)
+	return DryRunReport{Steps: run.steps}
+}
+
+// dryRun holds the state of a dry run.
+type dryRun struct {
+	c       *Container
+	steps   []DryRunStep
+	planned map[registrationKey]bool // Cached instances the plan would construct
+}
+
+// walk mirrors Container.resolve without invoking factories.
+func (d *dryRun) walk(targetType reflect.Type, name string, chain []reflect.Type) {
+	c := d.c
+	step := DryRunStep{Type: targetType, Name: name, Depth: len(chain)}
+
+	c.mu.RLock()
+	key := registrationKey{typ: targetType, name: name}
+	reg, exists := c.registrations[key]
+	selector := c.selectors[targetType]
+	scope := c.defaultScope
+	c.mu.RUnlock()
+
+	if name == "" && selector != nil {
+		selected, err := selector(SelectionContext{Context: context.Background(), Type: targetType, Scope: scope})
+		if err != nil {
+			d.fail(step, ErrResolutionFailed{Type: targetType, Cause: err})
+			return
+		}
+		key.name = selected
+		step.Name = selected
+		c.mu.RLock()
+		reg, exists = c.registrations[key]
+		c.mu.RUnlock()
+	}
+
+	if !exists {
+		d.fail(step, ErrNotRegistered{Type: targetType, Name: key.name})
+		return
+	}
+	step.Lifetime = reg.lifetime
+
+	for _, t := range chain {
+		if t == targetType {
+			cycle := append(append([]reflect.Type(nil), chain...), targetType)
+			d.fail(step, ErrCircularDependency{Chain: cycle})
+			return
+		}
+	}
+
+	switch {
+	case reg.instance != nil:
+		step.Action = DryRunInstance
+		d.steps = append(d.steps, step)
+		return
+	case d.cached(key, reg, scope):
+		step.Action = DryRunCacheHit
+		d.steps = append(d.steps, step)
+		return
+	}
+
+	step.Action = DryRunConstruct
+	d.steps = append(d.steps, step)
+	if reg.lifetime == Singleton || ((reg.lifetime == Scoped || reg.lifetime == ScopeFamily) && scope != nil) {
+		d.planned[key] = true
+	}
+
+	chain = append(chain, targetType)
+	for _, dep := range reg.dependencyTypes() {
+		d.walk(dep, "", chain)
+	}
+}
+
+// cached reports whether resolving reg would return a cached instance.
+func (d *dryRun) cached(key registrationKey, reg *registration, scope *Scope) bool {
+	if d.planned[key] {
+		return true
+	}
+	switch reg.lifetime {
+	case Singleton:
+		d.c.mu.RLock()
+		defer d.c.mu.RUnlock()
+		_, ok := d.c.singletons[key]
+		return ok
+	case Scoped:
+		if scope == nil {
+			return false
+		}
+		_, ok := scope.get(key)
+		return ok
+	case ScopeFamily:
+		if scope == nil {
+			return false
+		}
+		d.c.mu.RLock()
+		defer d.c.mu.RUnlock()
+		family, ok := d.c.families[reg.family(scope.name)]
+		if !ok {
+			return false
+		}
+		_, ok = family.instances[key]
+		return ok
+	}
+	return false
+}
+
+// fail records a failed step.
+func (d *dryRun) fail(step DryRunStep, err error) {
+	step.Action = DryRunFail
+	step.Err = err
+	d.steps = append(d.steps, step)
+}
@@ -0,0 +1,107 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Dry Run Tests
+// =============================================================================
+
+func TestDryRunPlansWithoutConstructing(t *testing.T) {
+	c := di.New()
+	constructed := 0
+
+	di.Register[Logger](c, func() Logger {
+		constructed++
+		return &TestLogger{}
+	}, di.AsSingleton())
+	di.Register[Greeter](c, func(log Logger) Greeter {
+		constructed++
+		return &SimpleGreeter{}
+	})
+	di.Register[Service](c, func(log Logger, g Greeter) Service {
+		constructed++
+		return &DefaultService{logger: log, greeter: g}
+	})
+
+	report := di.DryRun[Service](c)
+	if err := report.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if constructed != 0 {
+		t.Errorf("expected no factories to run, %d did", constructed)
+	}
+
+	want := []struct {
+		action di.DryRunAction
+		depth  int
+	}{
+		{di.DryRunConstruct, 0}, // Service
+		{di.DryRunConstruct, 1}, // Logger
+		{di.DryRunConstruct, 1}, // Greeter
+		{di.DryRunCacheHit, 2},  // Logger, constructed earlier in the plan
+	}
+	if len(report.Steps) != len(want) {
+		t.Fatalf("expected %d steps, got:\n%s", len(want), report)
+	}
+	for i, w := range want {
+		if report.Steps[i].Action != w.action || report.Steps[i].Depth != w.depth {
+			t.Errorf("step %d: expected %s at depth %d, got:\n%s", i, w.action, w.depth, report)
+		}
+	}
+	if len(report.Constructions()) != 3 {
+		t.Errorf("expected 3 constructions, got %d", len(report.Constructions()))
+	}
+
+	if stats := c.Stats(); stats.Resolutions != 0 {
+		t.Errorf("expected dry run to leave stats untouched, got %d resolutions", stats.Resolutions)
+	}
+}
+
+func TestDryRunReportsCachedInstances(t *testing.T) {
+	c := di.New()
+
+	di.RegisterInstance[Logger](c, &TestLogger{})
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsSingleton())
+	di.MustResolve[Greeter](c)
+	di.Register[Service](c, func(log Logger, g Greeter) Service {
+		return &DefaultService{logger: log, greeter: g}
+	})
+
+	report := di.DryRun[Service](c)
+	if report.Steps[1].Action != di.DryRunInstance {
+		t.Errorf("expected Logger instance step, got:\n%s", report)
+	}
+	if report.Steps[2].Action != di.DryRunCacheHit {
+		t.Errorf("expected Greeter cache hit, got:\n%s", report)
+	}
+}
+
+func TestDryRunReportsErrors(t *testing.T) {
+	c := di.New()
+
+	di.Register[Service](c, func(log Logger, g Greeter) Service {
+		return &DefaultService{logger: log, greeter: g}
+	})
+	di.Register[Greeter](c, func(s Service) Greeter { return &SimpleGreeter{} })
+
+	report := di.DryRun[Service](c)
+
+	var notRegistered di.ErrNotRegistered
+	if !errors.As(report.Err(), &notRegistered) {
+		t.Errorf("expected missing Logger to be reported, got %v", report.Err())
+	}
+	var circular di.ErrCircularDependency
+	if !errors.As(report.Err(), &circular) {
+		t.Errorf("expected cycle to be reported, got %v", report.Err())
+	}
+	if !contains(report.String(), "fail") {
+		t.Errorf("expected rendered report to show failures, got:\n%s", report)
+	}
+}
@@ -1,9 +1,11 @@
 package di
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 )
 
 // ErrNotRegistered is returned when attempting to resolve an unregistered type.
@@ -23,10 +25,27 @@ import (
 type ErrNotRegistered struct {
 	// Type is the reflect.Type that was not found in the container.
 	Type reflect.Type
+	// Name is the requested registration name, or "" for unnamed lookups.
+	Name string
+	// Fallback is the error returned by the fallback container (see
+	// [WithFallback]) when it was consulted and also lacks the registration.
+	Fallback error
 }
 
 func (e ErrNotRegistered) Error() string {
-	return fmt.Sprintf("di: type %s is not registered", e.Type)
+	msg := fmt.Sprintf("di: type %s is not registered", e.Type)
+	if e.Name != "" {
+		msg = fmt.Sprintf("di: type %s named %q is not registered", e.Type, e.Name)
+	}
+	if e.Fallback != nil {
+		msg += fmt.Sprintf(" (fallback container: %v)", e.Fallback)
+	}
+	return msg
+}
+
+// Unwrap returns the fallback container's error, if one was consulted.
+func (e ErrNotRegistered) Unwrap() error {
+	return e.Fallback
 }
 
 // ErrCircularDependency is returned when a circular dependency is detected.
@@ -129,6 +148,91 @@ func (e ErrInvalidFactory) Error() string {
 	return fmt.Sprintf("di: invalid factory for %s: %s", e.Type, e.Message)
 }
 
+// ErrResolutionTimeout is returned when resolution does not finish before the
+// deadline of the context passed to [ResolveCtx] or [ResolveWithDeadline].
+//
+// Type identifies the dependency that was in flight when the deadline passed,
+// which is usually the slow constructor. It wraps [context.DeadlineExceeded],
+// so errors.Is(err, context.DeadlineExceeded) reports true.
+//
+// Example:
+//
+//	_, err := di.ResolveWithDeadline[Server](container, time.Now().Add(time.Second))
+//	if err != nil {
+//	    var timeout di.ErrResolutionTimeout
+//	    if errors.As(err, &timeout) {
+//	        fmt.Printf("Timed out constructing %s\n", timeout.Type)
+//	    }
+//	}
+type ErrResolutionTimeout struct {
+	// Type is the type that was being resolved when the deadline passed.
+	Type reflect.Type
+	// Chain contains the dependency path leading to Type.
+	Chain []reflect.Type
+	// Deadline is the deadline that was exceeded.
+	Deadline time.Time
+}
+
+func (e ErrResolutionTimeout) Error() string {
+	names := make([]string, len(e.Chain))
+	for i, t := range e.Chain {
+		names[i] = t.String()
+	}
+	return fmt.Sprintf("di: resolution deadline exceeded while resolving %s: %s", e.Type, strings.Join(names, " -> "))
+}
+
+// Unwrap returns [context.DeadlineExceeded].
+func (e ErrResolutionTimeout) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// ErrInvalidName is returned when a registration name is rejected.
+//
+// This error occurs when:
+//   - [WithName] is given an empty name in strict mode (see [WithStrictMode])
+//   - The container's naming policy rejects the name (see [WithNamingPolicy])
+//
+// Example:
+//
+//	err := di.Register[Logger](container, factory, di.WithName("Console_Logger"))
+//	if err != nil {
+//	    var invalid di.ErrInvalidName
+//	    if errors.As(err, &invalid) {
+//	        fmt.Printf("Bad name %q: %s\n", invalid.Name, invalid.Reason)
+//	    }
+//	}
+type ErrInvalidName struct {
+	// Type is the type being registered.
+	Type reflect.Type
+	// Name is the rejected name.
+	Name string
+	// Reason describes why the name was rejected.
+	Reason string
+}
+
+func (e ErrInvalidName) Error() string {
+	return fmt.Sprintf("di: invalid name %q for %s: %s", e.Name, e.Type, e.Reason)
+}
+
+// ErrDuplicateRegistration is returned in strict mode when a type and name
+// combination is registered more than once.
+//
+// Outside strict mode, later registrations silently replace earlier ones.
+// See [WithStrictMode].
+type ErrDuplicateRegistration struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+}
+
+func (e ErrDuplicateRegistration) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("di: %s is already registered", e.Type)
+	}
+	return fmt.Sprintf("di: %s named %q is already registered", e.Type, e.Name)
+}
+
 // ErrScopeNotFound is returned when trying to use a scope that doesn't exist.
 //
 // This error occurs when attempting to resolve a scoped dependency with a scope
@@ -141,3 +245,179 @@ type ErrScopeNotFound struct {
 func (e ErrScopeNotFound) Error() string {
 	return fmt.Sprintf("di: scope %q not found", e.Name)
 }
+
+// ErrRegistrationRejected is returned when an extension rejects a registration.
+//
+// See [RegistrationExtension].
+type ErrRegistrationRejected struct {
+	// Type is the type being registered.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// By names what rejected the registration, such as an extension.
+	By string
+	// Cause is the reason given for the rejection.
+	Cause error
+}
+
+func (e ErrRegistrationRejected) Error() string {
+	return fmt.Sprintf("di: %s rejected by %s: %v", describeRegistration(e.Type, e.Name), e.By, e.Cause)
+}
+
+// Unwrap returns the reason given for the rejection.
+func (e ErrRegistrationRejected) Unwrap() error {
+	return e.Cause
+}
+
+// ErrUnsatisfiedDependency is returned by validation when a registration
+// depends on a type that has no registration.
+//
+// See [Container.Validate] and [Container.ValidateProfiles].
+type ErrUnsatisfiedDependency struct {
+	// Profile is the profile being validated, or "" if the container has no
+	// active profile.
+	Profile string
+	// Type is the registered type whose dependency is missing.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Dependency is the type that is not registered.
+	Dependency reflect.Type
+}
+
+func (e ErrUnsatisfiedDependency) Error() string {
+	if e.Profile == "" {
+		return fmt.Sprintf("di: %s depends on unregistered %s", describeRegistration(e.Type, e.Name), e.Dependency)
+	}
+	return fmt.Sprintf("di: profile %q: %s depends on unregistered %s",
+		e.Profile, describeRegistration(e.Type, e.Name), e.Dependency)
+}
+
+// ErrBudgetExceeded is returned when resolving in a scope would exceed the
+// scope's resource budget.
+//
+// See [Scope.SetBudget].
+//
+// Example:
+//
+//	_, err := di.ResolveInScope[*ReportBuilder](container, scope)
+//	var exceeded di.ErrBudgetExceeded
+//	if errors.As(err, &exceeded) {
+//	    log.Printf("request %s exceeded its %s budget", exceeded.Scope, exceeded.Resource)
+//	}
+type ErrBudgetExceeded struct {
+	// Scope is the name of the scope whose budget was exceeded.
+	Scope string
+	// Type is the type whose construction was refused.
+	Type reflect.Type
+	// Resource names the exhausted budget: "instances" or "construction time".
+	Resource string
+	// Budget is the scope's budget.
+	Budget ScopeBudget
+	// Usage is the scope's usage when the construction was refused.
+	Usage ScopeUsage
+}
+
+func (e ErrBudgetExceeded) Error() string {
+	if e.Resource == budgetInstances {
+		return fmt.Sprintf("di: scope %q %s budget exceeded constructing %s: %d used of %d",
+			e.Scope, e.Resource, e.Type, e.Usage.Instances, e.Budget.MaxInstances)
+	}
+	return fmt.Sprintf("di: scope %q %s budget exceeded constructing %s: %s used of %s",
+		e.Scope, e.Resource, e.Type, e.Usage.ConstructionTime, e.Budget.MaxConstructionTime)
+}
+
+// ErrNilInstance is returned when a factory returns a nil instance and nil
+// checks are enabled with [WithNilChecks].
+//
+// Example:
+//
+//	_, err := di.Resolve[Store](container)
+//	var nilErr di.ErrNilInstance
+//	if errors.As(err, &nilErr) {
+//	    log.Printf("fix the factory for %s", nilErr.Type)
+//	}
+type ErrNilInstance struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Factory is the type of the factory function.
+	Factory reflect.Type
+	// Kind is the concrete type of a typed-nil interface value, or nil if the
+	// factory returned a plain nil.
+	Kind reflect.Type
+}
+
+func (e ErrNilInstance) Error() string {
+	if e.Kind == nil || e.Kind == e.Type {
+		return fmt.Sprintf("di: factory %s for %s returned nil",
+			e.Factory, describeRegistration(e.Type, e.Name))
+	}
+	return fmt.Sprintf("di: factory %s for %s returned a nil %s wrapped in a non-nil interface",
+		e.Factory, describeRegistration(e.Type, e.Name), e.Kind)
+}
+
+// DisposeFailure identifies an instance that failed to dispose and why.
+type DisposeFailure struct {
+	// Type is the registered type of the instance.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Err is the error returned by Dispose or Close, or the context error if
+	// the disposal was abandoned.
+	Err error
+}
+
+func (f DisposeFailure) Error() string {
+	return fmt.Sprintf("%s: %v", describeRegistration(f.Type, f.Name), f.Err)
+}
+
+// ErrDisposeFailed is returned by [Container.Close] and [Scope.Dispose] when
+// instances fail to dispose. Every instance is disposed even if an earlier one
+// fails, and each failure is listed with the registration it belongs to, so
+// shutdown problems can be traced to their owner.
+//
+// errors.Is and errors.As see through ErrDisposeFailed to the individual
+// disposal errors.
+//
+// Example:
+//
+//	var failed di.ErrDisposeFailed
+//	if errors.As(container.Close(ctx), &failed) {
+//	    for _, f := range failed.Failures {
+//	        log.Printf("could not dispose %s: %v", f.Type, f.Err)
+//	    }
+//	}
+type ErrDisposeFailed struct {
+	// Failures lists the failed disposals in the order they were attempted.
+	Failures []DisposeFailure
+}
+
+func (e ErrDisposeFailed) Error() string {
+	if len(e.Failures) == 1 {
+		return "di: failed to dispose " + e.Failures[0].Error()
+	}
+	failures := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		failures[i] = f.Error()
+	}
+	return fmt.Sprintf("di: failed to dispose %d instances: %s", len(e.Failures), strings.Join(failures, "; "))
+}
+
+func (e ErrDisposeFailed) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// disposeFailed returns an ErrDisposeFailed for failures, or nil if there are
+// none.
+func disposeFailed(failures []DisposeFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return ErrDisposeFailed{Failures: failures}
+}
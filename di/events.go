@@ -0,0 +1,30 @@
+package di
+
+// OnConstructed subscribes fn to every newly constructed instance that is a T.
+//
+// T may be an interface, in which case fn receives instances of every
+// registration whose instances implement it, whatever type they were
+// registered as. This lets cross-cutting registries be built without touching
+// factories, such as collecting every constructed http.Handler into a mux or
+// every health checker into a health endpoint.
+//
+// fn is called synchronously after the instance is constructed, validated, and
+// cached, without holding container locks. Cache hits and instances supplied
+// with [RegisterInstance] are not reported, since they are not constructed.
+//
+// Example:
+//
+//	var checks []HealthChecker
+//	di.OnConstructed(c, func(h HealthChecker) {
+//	    checks = append(checks, h)
+//	})
+func OnConstructed[T any](c *Container, fn func(T)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onConstructed = append(c.onConstructed, func(instance any) {
+		if typed, ok := instance.(T); ok {
+			fn(typed)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Construction Event Tests
+// =============================================================================
+
+func TestOnConstructed(t *testing.T) {
+	c := di.New()
+
+	var greeters []Greeter
+	di.OnConstructed(c, func(g Greeter) {
+		greeters = append(greeters, g)
+	})
+
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsSingleton())
+	// Registered as a concrete type, but still a Greeter
+	di.Register[*formalGreeter](c, func() *formalGreeter { return &formalGreeter{} })
+	di.Register[Logger](c, func() Logger { return &TestLogger{} })
+	di.RegisterInstance[Greeter](c, &SimpleGreeter{}, di.WithName("preset"))
+
+	di.MustResolve[Greeter](c)
+	di.MustResolve[Greeter](c) // cache hit
+	di.MustResolve[*formalGreeter](c)
+	di.MustResolve[Logger](c)
+	di.MustResolveNamed[Greeter](c, "preset")
+
+	if len(greeters) != 2 {
+		t.Fatalf("expected 2 constructed greeters, got %d", len(greeters))
+	}
+	if _, ok := greeters[1].(*formalGreeter); !ok {
+		t.Errorf("expected formal greeter, got %T", greeters[1])
+	}
+}
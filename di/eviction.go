@@ -0,0 +1,199 @@
+package di
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// CachedSingleton describes a singleton instance currently held by the container.
+type CachedSingleton struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Instance is the cached instance.
+	Instance any
+	// Preregistered reports whether the instance was supplied with
+	// [RegisterInstance]. Such instances cannot be evicted.
+	Preregistered bool
+}
+
+// CachedSingletons returns every singleton instance the container currently
+// holds, in registration order.
+//
+// Singletons that have not been resolved yet are not included.
+//
+// Example:
+//
+//	for _, s := range container.CachedSingletons() {
+//	    fmt.Printf("%s %q: %T\n", s.Type, s.Name, s.Instance)
+//	}
+func (c *Container) CachedSingletons() []CachedSingleton {
+	regs := c.orderedRegistrations()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached := make([]CachedSingleton, 0, len(c.singletons))
+	for _, reg := range regs {
+		instance, ok := c.singletons[registrationKey{typ: reg.targetType, name: reg.name}]
+		if !ok {
+			continue
+		}
+		cached = append(cached, CachedSingleton{
+			Type:          reg.targetType,
+			Name:          reg.name,
+			Instance:      instance,
+			Preregistered: reg.instance != nil,
+		})
+	}
+	return cached
+}
+
+// EvictSingleton drops the cached singleton instance of T registered under name,
+// so the next resolution constructs a fresh instance with the factory.
+//
+// Unlike [Container.Clear], the registration itself is left untouched, and so
+// is every cached instance that does not depend on the evicted one. This is
+// useful after rotating credentials or reloading configuration that a
+// singleton captured at construction time.
+//
+// Cached singletons that depend on the evicted instance, directly or through
+// their dependencies, are evicted with it, so none of them keeps using a
+// disposed instance and the next resolution rebuilds them around the fresh
+// one. The evicted instances are disposed if they implement [Disposable] or
+// io.Closer, dependents first, as when a singleton is evicted after going idle
+// (see [WithIdleEviction]). Running hosted services are not evicted: each one
+// that depends on an evicted instance keeps it, and is reported with a
+// [WarningOutlivedDependency] warning.
+//
+// EvictSingleton reports whether an instance was evicted. It returns false if
+// nothing is cached, and for instances supplied with [RegisterInstance], which
+// have no factory to recreate them.
+//
+// Example:
+//
+//	rotateDatabasePassword()
+//	di.EvictSingleton[*sql.DB](container, "")
+//	db := di.MustResolve[*sql.DB](container) // reconnects with new credentials
+func EvictSingleton[T any](c *Container, name string) bool {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+	key := registrationKey{typ: targetType, name: name}
+
+	regs := c.orderedRegistrations()
+	graph := newDependencyGraph(regs)
+	running := c.runningServices(graph)
+
+	c.mu.Lock()
+	reg, exists := c.registrations[key]
+	if !exists || reg.instance != nil {
+		c.mu.Unlock()
+		return false
+	}
+	if _, cached := c.singletons[key]; !cached {
+		c.mu.Unlock()
+		return false
+	}
+
+	evicted := []*registration{reg}
+	for _, dependent := range regs {
+		_, cached := c.singletons[registrationKey{typ: dependent.targetType, name: dependent.name}]
+		if cached && dependent.instance == nil && !isRunning(running, dependent) && graph.reachable(dependent)[key] {
+			evicted = append(evicted, dependent)
+		}
+	}
+	sort.Slice(evicted, func(i, j int) bool { return evicted[i].constructedAt > evicted[j].constructedAt })
+
+	instances := make([]any, len(evicted))
+	for i, r := range evicted {
+		k := registrationKey{typ: r.targetType, name: r.name}
+		instances[i] = c.singletons[k]
+		delete(c.singletons, k)
+		if r.idleTimer != nil {
+			r.idleTimer.Stop()
+			r.idleTimer = nil
+		}
+	}
+	c.mu.Unlock()
+
+	for i, r := range evicted {
+		c.disposeEvicted(running, r, instances[i], "it was evicted")
+	}
+	return true
+}
+
+// scheduleIdleEviction starts tracking idleness for a freshly cached singleton.
+// The caller must hold c.mu for writing.
+func (c *Container) scheduleIdleEviction(key registrationKey, reg *registration) {
+	reg.touch()
+	if reg.idleTimer != nil {
+		reg.idleTimer.Stop()
+	}
+	reg.idleTimer = time.AfterFunc(reg.idleTimeout, func() {
+		c.evictIfIdle(key, reg)
+	})
+}
+
+// evictIfIdle evicts and disposes the singleton if it has been idle for its
+// full timeout, and otherwise waits for the remainder of the timeout. A
+// singleton that a cached singleton depends on is kept for another timeout.
+func (c *Container) evictIfIdle(key registrationKey, reg *registration) {
+	regs := c.orderedRegistrations()
+	graph := newDependencyGraph(regs)
+
+	c.mu.Lock()
+
+	// The registration was replaced or the container cleared
+	if c.registrations[key] != reg {
+		c.mu.Unlock()
+		return
+	}
+
+	idle := time.Since(time.Unix(0, reg.lastUsed.Load()))
+	remaining := reg.idleTimeout - idle
+	if remaining <= 0 && c.hasCachedDependents(graph, regs, key) {
+		remaining = reg.idleTimeout
+	}
+	if remaining > 0 {
+		reg.idleTimer = time.AfterFunc(remaining, func() {
+			c.evictIfIdle(key, reg)
+		})
+		c.mu.Unlock()
+		return
+	}
+
+	instance, cached := c.singletons[key]
+	delete(c.singletons, key)
+	reg.idleTimer = nil
+	c.mu.Unlock()
+
+	if cached {
+		c.disposeEvicted(c.runningServices(graph), reg, instance, "it was evicted after going idle")
+	}
+}
+
+// hasCachedDependents reports whether a cached singleton among regs depends on
+// the registration with key, directly or transitively. The caller must hold
+// c.mu.
+func (c *Container) hasCachedDependents(graph *dependencyGraph, regs []*registration, key registrationKey) bool {
+	for _, reg := range regs {
+		if _, cached := c.singletons[registrationKey{typ: reg.targetType, name: reg.name}]; cached && graph.reachable(reg)[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// disposeEvicted disposes a singleton instance removed from the cache, warning
+// about the running services that depend on it. The caller must not hold
+// c.mu.
+func (c *Container) disposeEvicted(running []runningService, reg *registration, instance any, reason string) {
+	if c.hooks.OnWarning != nil {
+		c.emitWarnings(outlivedWarnings(running, reg, reason))
+	}
+	if err := disposeInstance(instance); err != nil {
+		c.reportDisposeError(reg.targetType, reg.name, err)
+	}
+}
@@ -0,0 +1,308 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Singleton Eviction Tests
+// =============================================================================
+
+func TestCachedSingletons(t *testing.T) {
+	c := di.New()
+
+	preregistered := &TestLogger{}
+	di.RegisterInstance[Logger](c, preregistered)
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsSingleton())
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.AsSingleton(), di.WithName("formal"))
+
+	if cached := c.CachedSingletons(); len(cached) != 1 {
+		t.Fatalf("expected only the preregistered instance before resolution, got %d", len(cached))
+	}
+
+	greeter := di.MustResolve[Greeter](c)
+
+	cached := c.CachedSingletons()
+	if len(cached) != 2 {
+		t.Fatalf("expected 2 cached singletons, got %d", len(cached))
+	}
+
+	if !cached[0].Preregistered || cached[0].Instance != preregistered {
+		t.Errorf("unexpected first entry: %+v", cached[0])
+	}
+	if cached[1].Type != reflect.TypeOf((*Greeter)(nil)).Elem() || cached[1].Instance != greeter {
+		t.Errorf("unexpected second entry: %+v", cached[1])
+	}
+}
+
+func TestEvictSingleton(t *testing.T) {
+	c := di.New()
+
+	count := 0
+	di.Register[*TestLogger](c, func() *TestLogger {
+		count++
+		return &TestLogger{}
+	}, di.AsSingleton(), di.WithName("app"))
+
+	if di.EvictSingleton[*TestLogger](c, "app") {
+		t.Error("expected nothing to evict before first resolution")
+	}
+
+	first := di.MustResolveNamed[*TestLogger](c, "app")
+	if !di.EvictSingleton[*TestLogger](c, "app") {
+		t.Fatal("expected cached singleton to be evicted")
+	}
+
+	second := di.MustResolveNamed[*TestLogger](c, "app")
+	if first == second || count != 2 {
+		t.Error("expected a fresh instance after eviction")
+	}
+	if !di.HasNamed[*TestLogger](c, "app") {
+		t.Error("expected registration to survive eviction")
+	}
+}
+
+func TestEvictSingletonDisposesInstance(t *testing.T) {
+	var warnings []di.Warning
+	c := di.New(di.WithHooks(di.Hooks{OnWarning: func(w di.Warning) { warnings = append(warnings, w) }}))
+	release := make(chan struct{})
+	defer close(release)
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsSingleton())
+	di.Register[*stuckWorker](c, func(resource *closableResource) *stuckWorker {
+		return &stuckWorker{hangingService: &hangingService{release: release}, resource: resource}
+	}, di.AsSingleton())
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	resource := di.MustResolve[*closableResource](c)
+	if !di.EvictSingleton[*closableResource](c, "") {
+		t.Fatal("expected cached singleton to be evicted")
+	}
+
+	if !resource.closed.Load() {
+		t.Error("expected the evicted instance to be disposed")
+	}
+	if len(warnings) != 1 || warnings[0].Kind != di.WarningOutlivedDependency {
+		t.Errorf("expected the running worker to be warned about, got %v", warnings)
+	}
+}
+
+// resourceClient is a singleton built around a closableResource. It records
+// whether the resource was already closed when the client was.
+type resourceClient struct {
+	resource       *closableResource
+	closed         atomic.Bool
+	closedResource atomic.Bool
+}
+
+func (r *resourceClient) Close() error {
+	r.closedResource.Store(r.resource.closed.Load())
+	r.closed.Store(true)
+	return nil
+}
+
+func TestEvictSingletonEvictsDependents(t *testing.T) {
+	c := di.New()
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsSingleton())
+	di.Register[*resourceClient](c, func(resource *closableResource) *resourceClient {
+		return &resourceClient{resource: resource}
+	}, di.AsSingleton())
+
+	client := di.MustResolve[*resourceClient](c)
+	if !di.EvictSingleton[*closableResource](c, "") {
+		t.Fatal("expected cached singleton to be evicted")
+	}
+
+	if !client.closed.Load() || client.closedResource.Load() {
+		t.Error("expected the dependent to be disposed before the evicted instance")
+	}
+	fresh := di.MustResolve[*resourceClient](c)
+	if fresh == client || fresh.resource == client.resource || fresh.resource.closed.Load() {
+		t.Error("expected the dependent to be rebuilt around a fresh instance")
+	}
+}
+
+// evictingService evicts a singleton from its Start.
+type evictingService struct {
+	c *di.Container
+}
+
+func (s *evictingService) Start(ctx context.Context) error {
+	di.EvictSingleton[*closableResource](s.c, "")
+	return nil
+}
+
+func (s *evictingService) Stop(ctx context.Context) error { return nil }
+
+func TestEvictSingletonFromServiceStart(t *testing.T) {
+	warnings := make(chan di.Warning, 4)
+	c := di.New(di.WithHooks(di.Hooks{OnWarning: func(w di.Warning) { warnings <- w }}))
+	release := make(chan struct{})
+	defer close(release)
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsSingleton())
+	di.Register[*stuckWorker](c, func(resource *closableResource) *stuckWorker {
+		return &stuckWorker{hangingService: &hangingService{release: release}, resource: resource}
+	}, di.AsSingleton())
+	di.Register[*evictingService](c, func(*stuckWorker) *evictingService { return &evictingService{c: c} }, di.AsSingleton())
+
+	started := make(chan error, 1)
+	go func() { started <- c.Start(context.Background()) }()
+	select {
+	case err := <-started:
+		if err != nil {
+			t.Fatalf("unexpected start error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected EvictSingleton not to block a service's Start")
+	}
+	if w := <-warnings; w.Kind != di.WarningOutlivedDependency {
+		t.Errorf("expected the running worker to be warned about, got %v", w)
+	}
+}
+
+func TestEvictSingletonLeavesOthers(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsSingleton())
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton())
+
+	greeter := di.MustResolve[Greeter](c)
+	di.MustResolve[Logger](c)
+
+	di.EvictSingleton[Logger](c, "")
+
+	if di.MustResolve[Greeter](c) != greeter {
+		t.Error("expected other singletons to stay cached")
+	}
+}
+
+func TestEvictSingletonPreregisteredInstance(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	if di.EvictSingleton[Logger](c, "") {
+		t.Error("expected preregistered instance not to be evictable")
+	}
+	if _, err := di.Resolve[Logger](c); err != nil {
+		t.Errorf("expected instance to remain resolvable: %v", err)
+	}
+}
+
+// =============================================================================
+// Idle Eviction Tests
+// =============================================================================
+
+type failingCloser struct{}
+
+func (failingCloser) Close() error {
+	return errors.New("close failed")
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestIdleEviction(t *testing.T) {
+	c := di.New()
+
+	di.Register[*closableResource](c, func() *closableResource {
+		return &closableResource{}
+	}, di.AsSingleton(), di.WithIdleEviction(20*time.Millisecond))
+
+	first := di.MustResolve[*closableResource](c)
+
+	waitFor(t, first.closed.Load)
+
+	if len(c.CachedSingletons()) != 0 {
+		t.Error("expected idle singleton to be evicted")
+	}
+
+	second := di.MustResolve[*closableResource](c)
+	if second == first || second.closed.Load() {
+		t.Error("expected a fresh instance after idle eviction")
+	}
+}
+
+func TestIdleEvictionKeepsActiveSingletons(t *testing.T) {
+	c := di.New()
+
+	di.Register[*closableResource](c, func() *closableResource {
+		return &closableResource{}
+	}, di.AsSingleton(), di.WithIdleEviction(50*time.Millisecond))
+
+	first := di.MustResolve[*closableResource](c)
+	for range 10 {
+		time.Sleep(10 * time.Millisecond)
+		if di.MustResolve[*closableResource](c) != first {
+			t.Fatal("expected active singleton to stay cached")
+		}
+	}
+	if first.closed.Load() {
+		t.Error("expected active singleton not to be disposed")
+	}
+}
+
+func TestIdleEvictionKeepsDependencies(t *testing.T) {
+	c := di.New()
+	di.Register[*closableResource](c, func() *closableResource {
+		return &closableResource{}
+	}, di.AsSingleton(), di.WithIdleEviction(10*time.Millisecond))
+	di.Register[*resourceClient](c, func(resource *closableResource) *resourceClient {
+		return &resourceClient{resource: resource}
+	}, di.AsSingleton())
+
+	client := di.MustResolve[*resourceClient](c)
+	time.Sleep(40 * time.Millisecond)
+	if client.resource.closed.Load() || len(c.CachedSingletons()) != 2 {
+		t.Error("expected a singleton held by a cached dependent not to be evicted")
+	}
+}
+
+func TestIdleEvictionDisposeError(t *testing.T) {
+	var reported atomic.Value
+	c := di.New(di.WithHooks(di.Hooks{
+		OnDisposeError: func(typ reflect.Type, name string, err error) {
+			reported.Store(err)
+		},
+	}))
+
+	di.Register[failingCloser](c, func() failingCloser {
+		return failingCloser{}
+	}, di.AsSingleton(), di.WithIdleEviction(10*time.Millisecond))
+
+	di.MustResolve[failingCloser](c)
+
+	waitFor(t, func() bool { return reported.Load() != nil })
+}
+
+func TestClearStopsIdleEviction(t *testing.T) {
+	c := di.New()
+
+	di.Register[*closableResource](c, func() *closableResource {
+		return &closableResource{}
+	}, di.AsSingleton(), di.WithIdleEviction(10*time.Millisecond))
+
+	resource := di.MustResolve[*closableResource](c)
+	c.Clear()
+
+	time.Sleep(30 * time.Millisecond)
+	if resource.closed.Load() {
+		t.Error("expected Clear to stop idle eviction")
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !di_noreflect
+
 package di_test
 
 import (
@@ -0,0 +1,226 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Extension is an add-on that observes or customizes a container, such as
+// tracing, policy enforcement, or multitenancy support.
+//
+// An extension hooks into the container by also implementing any of
+// [RegistrationExtension], [ResolutionExtension], [LifecycleExtension], and
+// [MissingExtension].
+// Install extensions with [Container.AddExtension].
+type Extension interface {
+	// Name identifies the extension in errors and diagnostics.
+	Name() string
+}
+
+// RegistrationExtension is implemented by extensions that inspect
+// registrations as they are made.
+type RegistrationExtension interface {
+	Extension
+	// OnRegister is called before a registration is stored. Returning an
+	// error rejects the registration with [ErrRegistrationRejected].
+	OnRegister(info RegistrationInfo) error
+}
+
+// ResolutionExtension is implemented by extensions that observe resolutions.
+type ResolutionExtension interface {
+	Extension
+	// OnResolve is called after every resolution, including the resolution
+	// of factory parameters.
+	OnResolve(event ResolveEvent)
+}
+
+// LifecycleExtension is implemented by extensions that take part in the
+// container's lifecycle.
+type LifecycleExtension interface {
+	Extension
+	// OnStart is called by [Container.Start] before hosted services start.
+	// Returning an error aborts the start.
+	OnStart(ctx context.Context) error
+	// OnStop is called by [Container.Stop] after hosted services stop.
+	OnStop(ctx context.Context) error
+}
+
+// MissingExtension is implemented by extensions that supply instances of types
+// the container has no registration for, such as test helpers that fabricate
+// fakes.
+type MissingExtension interface {
+	Extension
+	// OnMissing is called when typ is resolved under name but not registered.
+	// Returning true supplies instance, which must be non-nil and assignable
+	// to typ, as the result of the resolution. The instance is not cached.
+	OnMissing(typ reflect.Type, name string) (instance any, ok bool)
+}
+
+// ResolveEvent describes a completed resolution for [ResolutionExtension].
+type ResolveEvent struct {
+	// Context is the resolution context.
+	Context context.Context
+	// Type is the resolved type.
+	Type reflect.Type
+	// Name is the requested registration name, or "" for unnamed requests.
+	Name string
+	// Scope is the scope the resolution ran in, or nil.
+	Scope *Scope
+	// Depth is the number of resolutions this one is nested in; resolutions
+	// requested directly by callers have depth 0.
+	Depth int
+	// Lifetime is the lifetime of the registration that served the
+	// resolution, for breaking cache hit ratios down by lifetime. It is
+	// Transient when no registration was found.
+	Lifetime Lifetime
+	// CacheHit reports whether the instance came from a cache rather than a
+	// factory.
+	CacheHit bool
+	// Duration is how long the resolution took, including dependencies.
+	Duration time.Duration
+	// Err is the error the resolution failed with, or nil.
+	Err error
+}
+
+// AddExtension installs an extension on the container.
+//
+// Registrations made before the extension was added are replayed to its
+// OnRegister method, so extensions can be added at any time. If a replayed
+// registration is rejected, the extension is not installed and the rejection
+// is returned. Extensions are called in the order they were added.
+//
+// Example:
+//
+//	type tracing struct{ tracer trace.Tracer }
+//
+//	func (tracing) Name() string { return "tracing" }
+//
+//	func (t tracing) OnResolve(e di.ResolveEvent) {
+//	    _, span := t.tracer.Start(e.Context, "di.resolve "+e.Type.String())
+//	    span.End()
+//	}
+//
+//	c.AddExtension(tracing{tracer: otel.Tracer("di")})
+func (c *Container) AddExtension(ext Extension) error {
+	if ext == nil {
+		return errors.New("di: extension must not be nil")
+	}
+
+	if regExt, ok := ext.(RegistrationExtension); ok {
+		for _, reg := range c.orderedRegistrations() {
+			if err := regExt.OnRegister(reg.info()); err != nil {
+				return ErrRegistrationRejected{Type: reg.targetType, Name: reg.name, By: extensionName(ext), Cause: err}
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.extensions = append(c.extensions, ext)
+	return nil
+}
+
+// extensionSnapshot returns the installed extensions.
+func (c *Container) extensionSnapshot() []Extension {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.extensions
+}
+
+// checkRegistration lets registration extensions inspect reg before it is stored.
+// The caller must not hold c.mu.
+func (c *Container) checkRegistration(reg *registration) error {
+	for _, ext := range c.extensionSnapshot() {
+		if regExt, ok := ext.(RegistrationExtension); ok {
+			if err := regExt.OnRegister(reg.info()); err != nil {
+				return ErrRegistrationRejected{Type: reg.targetType, Name: reg.name, By: extensionName(ext), Cause: err}
+			}
+		}
+	}
+	return nil
+}
+
+// notifyResolve reports a completed resolution to resolution extensions.
+func (c *Container) notifyResolve(exts []Extension, event ResolveEvent) {
+	for _, ext := range exts {
+		if resExt, ok := ext.(ResolutionExtension); ok {
+			resExt.OnResolve(event)
+		}
+	}
+}
+
+// resolveMissing asks missing extensions for an instance of an unregistered
+// type. It reports whether one supplied an instance.
+func (c *Container) resolveMissing(exts []Extension, typ reflect.Type, name string) (any, bool, error) {
+	for _, ext := range exts {
+		missingExt, ok := ext.(MissingExtension)
+		if !ok {
+			continue
+		}
+		instance, ok := missingExt.OnMissing(typ, name)
+		if !ok {
+			continue
+		}
+		if instance == nil || !reflect.TypeOf(instance).AssignableTo(typ) {
+			return nil, true, ErrResolutionFailed{
+				Type:  typ,
+				Cause: fmt.Errorf("extension %s supplied %T, which is not assignable", extensionName(ext), instance),
+			}
+		}
+		return instance, true, nil
+	}
+	return nil, false, nil
+}
+
+// startExtensions calls OnStart on lifecycle extensions, stopping the ones
+// already started if one fails.
+func (c *Container) startExtensions(ctx context.Context) error {
+	var started []LifecycleExtension
+	for _, ext := range c.extensionSnapshot() {
+		lcExt, ok := ext.(LifecycleExtension)
+		if !ok {
+			continue
+		}
+		if err := lcExt.OnStart(ctx); err != nil {
+			return errors.Join(fmt.Errorf("di: %s failed to start: %w", extensionName(ext), err),
+				stopExtensions(ctx, started))
+		}
+		started = append(started, lcExt)
+	}
+	return nil
+}
+
+// stopLifecycleExtensions calls OnStop on the container's lifecycle extensions
+// in reverse order.
+func (c *Container) stopLifecycleExtensions(ctx context.Context) error {
+	var exts []LifecycleExtension
+	for _, ext := range c.extensionSnapshot() {
+		if lcExt, ok := ext.(LifecycleExtension); ok {
+			exts = append(exts, lcExt)
+		}
+	}
+	return stopExtensions(ctx, exts)
+}
+
+// stopExtensions calls OnStop on exts in reverse order.
+func stopExtensions(ctx context.Context, exts []LifecycleExtension) error {
+	var errs []error
+	for i := len(exts) - 1; i >= 0; i-- {
+		if err := exts[i].OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("di: %s failed to stop: %w", extensionName(exts[i]), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// extensionName describes an extension for error messages.
+func extensionName(ext Extension) string {
+	if _, ok := ext.(*policyExtension); ok {
+		return ext.Name()
+	}
+	return fmt.Sprintf("extension %q", ext.Name())
+}
@@ -0,0 +1,166 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Extension Tests
+// =============================================================================
+
+// auditExtension implements every extension interface and records calls.
+type auditExtension struct {
+	mu         sync.Mutex
+	registered []string
+	resolved   []di.ResolveEvent
+	lifecycle  []string
+	reject     string
+}
+
+func (e *auditExtension) Name() string { return "audit" }
+
+func (e *auditExtension) OnRegister(info di.RegistrationInfo) error {
+	if e.reject != "" && info.Name == e.reject {
+		return errors.New("name is reserved")
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.registered = append(e.registered, info.Type.String())
+	return nil
+}
+
+func (e *auditExtension) OnResolve(event di.ResolveEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.resolved = append(e.resolved, event)
+}
+
+func (e *auditExtension) OnStart(ctx context.Context) error {
+	e.lifecycle = append(e.lifecycle, "start")
+	return nil
+}
+
+func (e *auditExtension) OnStop(ctx context.Context) error {
+	e.lifecycle = append(e.lifecycle, "stop")
+	return nil
+}
+
+func TestExtensionRegistrationHooks(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	ext := &auditExtension{reject: "forbidden"}
+	if err := c.AddExtension(ext); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	di.RegisterType[Greeter, SimpleGreeter](c)
+
+	if len(ext.registered) != 2 || ext.registered[0] != "di_test.Logger" {
+		t.Errorf("expected existing and new registrations, got %v", ext.registered)
+	}
+
+	err := di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("forbidden"))
+	var rejected di.ErrRegistrationRejected
+	if !errors.As(err, &rejected) || rejected.Name != "forbidden" {
+		t.Fatalf("expected ErrRegistrationRejected, got %v", err)
+	}
+	if di.HasNamed[Greeter](c, "forbidden") {
+		t.Error("expected rejected registration not to be stored")
+	}
+
+	// Replayed registrations can also be rejected
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("legacy"))
+	if err := c.AddExtension(&auditExtension{reject: "legacy"}); err == nil {
+		t.Error("expected replay rejection to be returned")
+	}
+}
+
+func TestExtensionResolutionHooks(t *testing.T) {
+	c := di.New()
+	ext := &auditExtension{}
+	c.AddExtension(ext)
+
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton())
+	di.Register[Service](c, func(log Logger) Service { return &DefaultService{logger: log} })
+
+	di.MustResolve[Service](c)
+	di.MustResolve[Logger](c)
+	di.Resolve[Greeter](c)
+
+	if len(ext.resolved) != 4 {
+		t.Fatalf("expected 4 resolve events, got %d", len(ext.resolved))
+	}
+
+	nested, top, cached, missing := ext.resolved[0], ext.resolved[1], ext.resolved[2], ext.resolved[3]
+	if nested.Type != reflect.TypeOf((*Logger)(nil)).Elem() || nested.Depth != 1 || nested.CacheHit {
+		t.Errorf("unexpected nested event: %+v", nested)
+	}
+	if top.Depth != 0 || top.Err != nil {
+		t.Errorf("unexpected top-level event: %+v", top)
+	}
+	if !cached.CacheHit {
+		t.Errorf("expected cache hit event: %+v", cached)
+	}
+	if missing.Err == nil {
+		t.Errorf("expected failed event: %+v", missing)
+	}
+}
+
+func TestExtensionLifecycleHooks(t *testing.T) {
+	c := di.New()
+	ext := &auditExtension{}
+	c.AddExtension(ext)
+
+	c.Start(context.Background())
+	c.Stop(context.Background())
+
+	if len(ext.lifecycle) != 2 || ext.lifecycle[0] != "start" || ext.lifecycle[1] != "stop" {
+		t.Errorf("expected start and stop, got %v", ext.lifecycle)
+	}
+}
+
+// fallbackExtension supplies a logger for missing Logger resolutions.
+type fallbackExtension struct{ instance any }
+
+func (fallbackExtension) Name() string { return "fallback" }
+
+func (e fallbackExtension) OnMissing(typ reflect.Type, name string) (any, bool) {
+	if typ != reflect.TypeOf((*Logger)(nil)).Elem() {
+		return nil, false
+	}
+	return e.instance, true
+}
+
+func TestExtensionMissingHook(t *testing.T) {
+	c := di.New()
+	logger := &TestLogger{}
+	c.AddExtension(fallbackExtension{instance: logger})
+
+	resolved, err := di.Resolve[Logger](c)
+	if err != nil || resolved != logger {
+		t.Fatalf("expected supplied logger, got %v, %v", resolved, err)
+	}
+
+	var notRegistered di.ErrNotRegistered
+	if _, err := di.Resolve[Greeter](c); !errors.As(err, &notRegistered) {
+		t.Errorf("expected ErrNotRegistered, got %v", err)
+	}
+}
+
+func TestExtensionMissingHookWrongType(t *testing.T) {
+	c := di.New()
+	c.AddExtension(fallbackExtension{instance: "not a logger"})
+
+	var failed di.ErrResolutionFailed
+	if _, err := di.Resolve[Logger](c); !errors.As(err, &failed) {
+		t.Errorf("expected ErrResolutionFailed, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// WithFallback makes the container resolve types it has no registration for
+// from other.
+//
+// This lets an application container build on a shared container maintained
+// elsewhere, such as a platform team's container of logging, metrics, and
+// database clients, while registering its own services locally. Local
+// registrations always win. Instances resolved from the fallback follow the
+// fallback's lifetimes and caches; scoped dependencies there are resolved in
+// the fallback's default scope, not the requesting scope.
+//
+// Errors name the container that was consulted: when neither container has a
+// registration, [ErrNotRegistered] carries the fallback's error in its
+// Fallback field, and other fallback failures are reported as
+// [ErrResolutionFailed] wrapping the fallback's error. [Has] and [HasNamed]
+// only report local registrations.
+//
+// Example:
+//
+//	platform := newPlatformContainer() // logging, metrics, *sql.DB
+//
+//	app := di.New(di.WithFallback(platform))
+//	di.Register[*UserService](app, NewUserService) // depends on *sql.DB
+func WithFallback(other *Container) ContainerOption {
+	return func(c *Container) {
+		c.fallback = other
+	}
+}
+
+// resolveFallback resolves an unregistered type from the fallback container.
+func (c *Container) resolveFallback(ctx context.Context, targetType reflect.Type, name string) (any, error) {
+	instance, err := c.fallback.resolve(ctx, targetType, name, nil, make([]reflect.Type, 0))
+	if err == nil {
+		return instance, nil
+	}
+
+	if notRegistered, ok := err.(ErrNotRegistered); ok {
+		c.stats.unregistered.Add(1)
+		return nil, ErrNotRegistered{Type: targetType, Name: name, Fallback: notRegistered}
+	}
+	return nil, ErrResolutionFailed{Type: targetType, Cause: fmt.Errorf("fallback container: %w", err)}
+}
+
+// NewChild returns a container that resolves from c whatever it has no
+// registration for, as if created with [WithFallback], so a module or a test
+// can override registrations locally without mutating a shared container.
+//
+// The child starts with c's strict mode, naming policy, name resolver, nil
+// checks, hooks, and active profile; opts are applied afterwards and can
+// change them. The child owns only what it constructs: closing or clearing it
+// leaves c untouched. Instances resolved from c are wired from c's
+// registrations, so an override in the child is seen by the child's own
+// services but not by services constructed in c.
+//
+// Example:
+//
+//	func TestCheckout(t *testing.T) {
+//	    c := app.NewChild()
+//	    di.RegisterInstance[PaymentGateway](c, &fakeGateway{})
+//	    checkout := di.MustResolve[*CheckoutService](c) // registered in the child
+//	    // ...
+//	}
+func (c *Container) NewChild(opts ...ContainerOption) *Container {
+	inherit := func(child *Container) {
+		child.strict = c.strict
+		child.namingPolicy = c.namingPolicy
+		child.nameResolver = c.nameResolver
+		child.nilChecks = c.nilChecks
+		child.hooks = c.hooks
+		child.profile = c.profile
+		child.fallback = c
+	}
+	return New(append([]ContainerOption{inherit}, opts...)...)
+}
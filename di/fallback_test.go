@@ -0,0 +1,92 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Fallback Container Tests
+// =============================================================================
+
+func TestFallbackResolvesMissingTypes(t *testing.T) {
+	platform := di.New()
+	di.Register[Logger](platform, func() Logger { return &TestLogger{} }, di.AsSingleton())
+
+	app := di.New(di.WithFallback(platform))
+	di.Register[Service](app, func(logger Logger) Service {
+		return &DefaultService{logger: logger}
+	})
+
+	service := di.MustResolve[Service](app).(*DefaultService)
+	if service.logger != di.MustResolve[Logger](platform) {
+		t.Error("expected the fallback container's singleton")
+	}
+}
+
+func TestFallbackLocalRegistrationsWin(t *testing.T) {
+	platform := di.New()
+	di.Register[Greeter](platform, func() Greeter { return &formalGreeter{} })
+
+	app := di.New(di.WithFallback(platform))
+	di.Register[Greeter](app, func() Greeter { return &SimpleGreeter{} })
+
+	if _, ok := di.MustResolve[Greeter](app).(*SimpleGreeter); !ok {
+		t.Error("expected local registration to win")
+	}
+}
+
+func TestFallbackErrors(t *testing.T) {
+	platform := di.New()
+	boom := errors.New("boom")
+	di.Register[Greeter](platform, func() (Greeter, error) { return nil, boom })
+
+	app := di.New(di.WithFallback(platform))
+
+	_, err := di.Resolve[Logger](app)
+	var notRegistered di.ErrNotRegistered
+	if !errors.As(err, &notRegistered) || notRegistered.Fallback == nil {
+		t.Fatalf("expected ErrNotRegistered with fallback error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "fallback container") {
+		t.Errorf("expected error to mention the fallback container, got %v", err)
+	}
+
+	_, err = di.Resolve[Greeter](app)
+	if !errors.Is(err, boom) || !strings.Contains(err.Error(), "fallback container") {
+		t.Errorf("expected wrapped fallback failure, got %v", err)
+	}
+}
+
+// =============================================================================
+// Child Container Tests
+// =============================================================================
+
+func TestNewChildOverridesLocally(t *testing.T) {
+	parent := di.New(di.WithStrictMode())
+	di.Register[Greeter](parent, func() Greeter { return &formalGreeter{} })
+	di.Register[Logger](parent, func() Logger { return &TestLogger{} }, di.AsSingleton())
+
+	child := parent.NewChild()
+	di.Register[Greeter](child, func() Greeter { return &SimpleGreeter{} })
+
+	if _, ok := di.MustResolve[Greeter](child).(*SimpleGreeter); !ok {
+		t.Error("expected the child's registration to win")
+	}
+	if _, ok := di.MustResolve[Greeter](parent).(*formalGreeter); !ok {
+		t.Error("expected the parent to be unaffected")
+	}
+	if di.MustResolve[Logger](child) != di.MustResolve[Logger](parent) {
+		t.Error("expected the child to share the parent's singleton")
+	}
+
+	err := di.Register[Greeter](child, func() Greeter { return &SimpleGreeter{} })
+	if !errors.As(err, new(di.ErrDuplicateRegistration)) {
+		t.Errorf("expected the child to inherit strict mode, got %v", err)
+	}
+}
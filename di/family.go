@@ -0,0 +1,124 @@
+package di
+
+import "strings"
+
+// AsScopeFamily registers the dependency with the [ScopeFamily] lifetime:
+// one instance is shared by every scope in the same family.
+//
+// A scope's family is the part of its name before the first occurrence of
+// separator, or its whole name if separator does not occur, so scopes named
+// "tenant-42/request-1" and "tenant-42/request-2" share the instances of the
+// "tenant-42" family with separator "/". This suits per-tenant caches and
+// connections that should outlive a single request but not be shared with
+// other tenants.
+//
+// Family instances are created on first resolution in a scope of the family
+// and disposed when the last scope that used them is disposed. Resolved
+// without a scope, the dependency behaves like a transient one. As with
+// singletons that depend on scoped services, a family instance captures the
+// scoped dependencies of the scope that constructed it.
+//
+// Example:
+//
+//	di.Register[*TenantCache](c, newTenantCache, di.AsScopeFamily("/"))
+//
+//	scope := container.CreateScope("tenant-" + tenantID + "/" + requestID)
+//	defer scope.Dispose()
+//	cache, _ := di.ResolveInScope[*TenantCache](container, scope)
+func AsScopeFamily(separator string) RegistrationOption {
+	return func(r *registration) {
+		r.lifetime = ScopeFamily
+		r.familySeparator = separator
+	}
+}
+
+// scopeFamily holds the instances shared by the scopes of one family.
+type scopeFamily struct {
+	instances map[registrationKey]any
+	order     []registrationKey // Instance keys in creation order
+	members   map[*Scope]bool   // Live scopes that used the family
+}
+
+// family returns the family of the named scope.
+func (r *registration) family(scopeName string) string {
+	if r.familySeparator == "" {
+		return scopeName
+	}
+	family, _, _ := strings.Cut(scopeName, r.familySeparator)
+	return family
+}
+
+// familyInstance returns the instance cached for key in the family of scope,
+// and records that scope uses the family.
+func (c *Container) familyInstance(scope *Scope, reg *registration, key registrationKey) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	family, ok := c.families[reg.family(scope.name)]
+	if !ok {
+		return nil, false
+	}
+	instance, ok := family.instances[key]
+	if ok {
+		family.members[scope] = true
+	}
+	return instance, ok
+}
+
+// setFamilyInstance caches instance for key in the family of scope. It
+// returns false if a Clear made reg stale since it was constructed.
+func (c *Container) setFamilyInstance(scope *Scope, reg *registration, key registrationKey, instance any) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if reg.generation != c.generation {
+		return false
+	}
+	name := reg.family(scope.name)
+	family, ok := c.families[name]
+	if !ok {
+		family = &scopeFamily{
+			instances: make(map[registrationKey]any),
+			members:   make(map[*Scope]bool),
+		}
+		c.families[name] = family
+	}
+	if _, exists := family.instances[key]; !exists {
+		family.order = append(family.order, key)
+	}
+	family.instances[key] = instance
+	family.members[scope] = true
+	return true
+}
+
+// leaveFamilies removes scope from the families it used and disposes the
+// instances of families it was the last member of, newest first.
+func (c *Container) leaveFamilies(scope *Scope) []DisposeFailure {
+	var released []registrationKey
+	var instances []any
+	c.mu.Lock()
+	for name, family := range c.families {
+		if !family.members[scope] {
+			continue
+		}
+		delete(family.members, scope)
+		if len(family.members) > 0 {
+			continue
+		}
+		delete(c.families, name)
+		for i := len(family.order) - 1; i >= 0; i-- {
+			key := family.order[i]
+			released = append(released, key)
+			instances = append(instances, family.instances[key])
+		}
+	}
+	c.mu.Unlock()
+
+	var failures []DisposeFailure
+	for i, key := range released {
+		if err := disposeInstance(instances[i]); err != nil {
+			failures = append(failures, DisposeFailure{Type: key.typ, Name: key.name, Err: err})
+		}
+	}
+	return failures
+}
@@ -0,0 +1,67 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Scope Family Tests
+// =============================================================================
+
+func TestScopeFamilySharesInstancesWithinFamily(t *testing.T) {
+	c := di.New()
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsScopeFamily("/"))
+
+	first := c.CreateScope("tenant-42/request-1")
+	second := c.CreateScope("tenant-42/request-2")
+	other := c.CreateScope("tenant-7/request-3")
+
+	a, _ := di.ResolveInScope[*closableResource](c, first)
+	b, _ := di.ResolveInScope[*closableResource](c, second)
+	o, _ := di.ResolveInScope[*closableResource](c, other)
+	if a != b {
+		t.Error("expected scopes of the same family to share the instance")
+	}
+	if a == o {
+		t.Error("expected other families to get their own instance")
+	}
+
+	if err := first.Dispose(); err != nil {
+		t.Fatalf("unexpected dispose error: %v", err)
+	}
+	if a.closed.Load() {
+		t.Error("expected the family instance to stay open while a member scope is live")
+	}
+	if err := second.Dispose(); err != nil {
+		t.Fatalf("unexpected dispose error: %v", err)
+	}
+	if !a.closed.Load() {
+		t.Error("expected the family instance to be disposed with the last member scope")
+	}
+	if o.closed.Load() {
+		t.Error("expected other families to be unaffected")
+	}
+
+	third := c.CreateScope("tenant-42/request-4")
+	if fresh, _ := di.ResolveInScope[*closableResource](c, third); fresh == a {
+		t.Error("expected a disposed family to start over")
+	}
+}
+
+func TestScopeFamilyWithoutScopeIsTransient(t *testing.T) {
+	c := di.New()
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsScopeFamily("/"))
+
+	first, _ := di.Resolve[*closableResource](c)
+	second, _ := di.Resolve[*closableResource](c)
+	if first == second {
+		t.Error("expected resolution without a scope not to cache")
+	}
+	if got := di.ScopeFamily.String(); got != "ScopeFamily" {
+		t.Errorf("expected ScopeFamily, got %q", got)
+	}
+}
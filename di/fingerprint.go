@@ -0,0 +1,36 @@
+package di
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// Fingerprint returns a stable hash of the container's registration set.
+//
+// The fingerprint covers the type, name, and lifetime of every registration
+// and nothing else, so it does not depend on registration order, factory
+// implementations, or resolution state. Deployments can log it at startup and
+// compare it across releases and environments to detect wiring changes.
+//
+// The result is a hex-encoded SHA-256 digest.
+//
+// Example:
+//
+//	log.Printf("di wiring fingerprint: %s", container.Fingerprint())
+func (c *Container) Fingerprint() string {
+	regs := c.orderedRegistrations()
+
+	lines := make([]string, len(regs))
+	for i, reg := range regs {
+		lines[i] = fmt.Sprintf("%s\x00%q\x00%s", reg.targetType, reg.name, reg.lifetime)
+	}
+	sort.Strings(lines)
+
+	h := sha256.New()
+	for _, line := range lines {
+		fmt.Fprintln(h, line)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,51 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Fingerprint Tests
+// =============================================================================
+
+func TestFingerprintIgnoresOrder(t *testing.T) {
+	a := di.New()
+	di.Register[Logger](a, func() Logger { return &TestLogger{} }, di.AsSingleton())
+	di.Register[Greeter](a, func() Greeter { return &SimpleGreeter{} }, di.WithName("simple"))
+
+	b := di.New()
+	di.Register[Greeter](b, func() Greeter { return &formalGreeter{} }, di.WithName("simple"))
+	di.Register[Logger](b, func() Logger { return &TestLogger{} }, di.AsSingleton())
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("expected equal fingerprints, got %s and %s", a.Fingerprint(), b.Fingerprint())
+	}
+	if len(a.Fingerprint()) != 64 {
+		t.Errorf("expected a hex SHA-256 digest, got %q", a.Fingerprint())
+	}
+}
+
+func TestFingerprintDetectsChanges(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} })
+	before := c.Fingerprint()
+
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton())
+	afterLifetime := c.Fingerprint()
+	if afterLifetime == before {
+		t.Error("expected lifetime change to change the fingerprint")
+	}
+
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.WithName("other"))
+	if c.Fingerprint() == afterLifetime {
+		t.Error("expected new registration to change the fingerprint")
+	}
+
+	if di.New().Fingerprint() == before {
+		t.Error("expected empty container to have a different fingerprint")
+	}
+}
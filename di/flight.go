@@ -0,0 +1,66 @@
+package di
+
+import (
+	"context"
+	"reflect"
+)
+
+// singletonFlight is a singleton construction in progress. Resolutions of the
+// same singleton wait for it rather than constructing a second instance that
+// would replace the first in the cache and never be disposed.
+type singletonFlight struct {
+	done chan struct{}
+	err  error
+}
+
+// joinSingleton returns the cached instance of a singleton, waiting for a
+// construction of it already in progress. Otherwise it returns a flight the
+// caller must construct the singleton under and finish with finishSingleton.
+//
+// A construction is not waited for when the singleton lies on a dependency
+// cycle, since the goroutine building it may be waiting for the caller; the
+// caller then constructs it without a flight, and finds the cycle itself.
+func (c *Container) joinSingleton(ctx context.Context, key registrationKey, reg *registration, chain []reflect.Type) (instance any, cached bool, flight *singletonFlight, err error) {
+	for {
+		c.mu.Lock()
+		if instance, ok := c.singletons[key]; ok {
+			c.mu.Unlock()
+			return instance, true, nil, nil
+		}
+		inFlight, building := c.building[key]
+		if !building {
+			flight := &singletonFlight{done: make(chan struct{})}
+			c.building[key] = flight
+			c.mu.Unlock()
+			return nil, false, flight, nil
+		}
+		cyclic := c.cycleThrough(reg) != nil
+		c.mu.Unlock()
+		if cyclic {
+			return nil, false, nil, nil
+		}
+
+		select {
+		case <-inFlight.done:
+		case <-ctx.Done():
+			return nil, false, nil, contextError(ctx, ctx.Err(), chain)
+		}
+		if inFlight.err != nil {
+			return nil, false, nil, inFlight.err
+		}
+		// The instance was cached, unless it has been evicted since
+	}
+}
+
+// finishSingleton ends the flight of a singleton construction, releasing the
+// resolutions waiting for it. The caller must have cached the instance first.
+func (c *Container) finishSingleton(key registrationKey, flight *singletonFlight, err error) {
+	c.mu.Lock()
+	if c.building[key] == flight {
+		delete(c.building, key)
+	}
+	c.mu.Unlock()
+
+	flight.err = err
+	close(flight.done)
+}
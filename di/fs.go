@@ -0,0 +1,108 @@
+package di
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// RegisterFS registers a file system as the [fs.FS] named name, so services
+// such as template renderers and migration runners can depend on it.
+//
+// Any fs.FS works: an embed.FS compiled into the binary, os.DirFS for files on
+// disk, or fstest.MapFS in tests. Services receive named file systems through
+// a parameter object (see [In]) with a name tag, or by resolving them with
+// [ResolveFS]. An empty name registers the default, unnamed file system.
+//
+// Example:
+//
+//	//go:embed templates
+//	var templates embed.FS
+//
+//	di.RegisterFS(c, "templates", templates)
+//
+//	type RendererParams struct {
+//	    di.In
+//	    Templates fs.FS `name:"templates"`
+//	}
+//
+//	di.Register[*Renderer](c, func(p RendererParams) (*Renderer, error) {
+//	    return NewRenderer(p.Templates)
+//	}, di.AsSingleton())
+func RegisterFS(c *Container, name string, fsys fs.FS, opts ...RegistrationOption) error {
+	if name != "" {
+		opts = append([]RegistrationOption{WithName(name)}, opts...)
+	}
+	return RegisterInstance(c, fsys, opts...)
+}
+
+// RegisterSubFS registers the subtree of fsys rooted at dir as the [fs.FS]
+// named name (see [RegisterFS]).
+//
+// This strips the directory prefix that embedding leaves in place, so a
+// service sees "index.html" rather than "static/index.html". Returns an error
+// if dir is not a valid path or is not a directory in fsys, so a misspelt
+// asset root fails at startup rather than at the first request.
+//
+// Example:
+//
+//	//go:embed static
+//	var static embed.FS
+//
+//	di.RegisterSubFS(c, "static", static, "static")
+func RegisterSubFS(c *Container, name string, fsys fs.FS, dir string, opts ...RegistrationOption) error {
+	info, err := fs.Stat(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("di: asset root %q: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("di: asset root %q is not a directory", dir)
+	}
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("di: asset root %q: %w", dir, err)
+	}
+	return RegisterFS(c, name, sub, opts...)
+}
+
+// ResolveFS resolves the [fs.FS] named name.
+//
+// Example:
+//
+//	migrations, err := di.ResolveFS(c, "migrations")
+func ResolveFS(c *Container, name string) (fs.FS, error) {
+	return ResolveNamed[fs.FS](c, name)
+}
+
+// AssetsModule returns a module that registers each of the given directories
+// of fsys as its own [fs.FS], named after the directory's base name (see
+// [RegisterSubFS]).
+//
+// It wires the common asset roots of a web application from a single embedded
+// file system in one step. The module fails if a root is missing, is not a
+// directory, or shares its base name with another root.
+//
+// Example:
+//
+//	//go:embed assets/templates assets/static migrations
+//	var assets embed.FS
+//
+//	c.Apply(di.AssetsModule(assets, "assets/templates", "assets/static", "migrations"))
+//
+//	static, _ := di.ResolveFS(c, "static")
+func AssetsModule(fsys fs.FS, roots ...string) Module {
+	return func(c *Container) error {
+		seen := make(map[string]string, len(roots))
+		for _, root := range roots {
+			name := path.Base(root)
+			if other, ok := seen[name]; ok {
+				return fmt.Errorf("di: asset roots %q and %q are both named %q", other, root, name)
+			}
+			seen[name] = root
+			if err := RegisterSubFS(c, name, fsys, root); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
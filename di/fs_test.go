@@ -0,0 +1,63 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// File System Tests
+// =============================================================================
+
+var testAssets = fstest.MapFS{
+	"web/templates/index.html": {Data: []byte("<h1>{{.}}</h1>")},
+	"web/static/app.css":       {Data: []byte("body{}")},
+	"migrations/001_init.sql":  {Data: []byte("CREATE TABLE users;")},
+	"assets/static/logo.svg":   {Data: []byte("<svg/>")},
+}
+
+type templateRenderer struct{ templates fs.FS }
+
+type rendererParams struct {
+	di.In
+	Templates fs.FS `name:"templates"`
+}
+
+func TestAssetsModule(t *testing.T) {
+	c := di.New()
+	if err := c.Apply(di.AssetsModule(testAssets, "web/templates", "web/static", "migrations")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	di.Register[*templateRenderer](c, func(p rendererParams) *templateRenderer {
+		return &templateRenderer{templates: p.Templates}
+	})
+
+	renderer := di.MustResolve[*templateRenderer](c)
+	if data, err := fs.ReadFile(renderer.templates, "index.html"); err != nil || string(data) != "<h1>{{.}}</h1>" {
+		t.Errorf("expected the templates root to be injected, got %q, %v", data, err)
+	}
+	migrations, err := di.ResolveFS(c, "migrations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fs.Stat(migrations, "001_init.sql"); err != nil {
+		t.Errorf("expected the migrations root to be registered, got %v", err)
+	}
+}
+
+func TestAssetsModuleInvalidRoot(t *testing.T) {
+	for _, roots := range [][]string{
+		{"web/scripts"},                 // missing
+		{"web/static/app.css"},          // not a directory
+		{"web/static", "assets/static"}, // same name
+	} {
+		if err := di.New().Apply(di.AssetsModule(testAssets, roots...)); err == nil {
+			t.Errorf("expected an error for roots %v", roots)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package di
+
+import "reflect"
+
+// dependencyGraph is a static view of a set of registrations and the edges
+// formed by their factory parameters. It is built from a snapshot and does not
+// observe later registrations.
+type dependencyGraph struct {
+	regs   map[registrationKey]*registration
+	depths map[*registration]int
+
+	// decorated holds the dependencies of the decorators of each type, which
+	// are added to the edges of its registrations. Nil leaves them out.
+	decorated map[reflect.Type][]reflect.Type
+}
+
+// dependencyEdge is a factory parameter and the registration that satisfies it.
+type dependencyEdge struct {
+	typ    reflect.Type
+	target *registration // nil if the type is not registered
+}
+
+// newDependencyGraph builds a graph over the given registrations.
+func newDependencyGraph(regs []*registration) *dependencyGraph {
+	g := &dependencyGraph{
+		regs:   make(map[registrationKey]*registration, len(regs)),
+		depths: make(map[*registration]int, len(regs)),
+	}
+	for _, reg := range regs {
+		g.regs[registrationKey{typ: reg.targetType, name: reg.name}] = reg
+	}
+	return g
+}
+
+// dependencies returns the outgoing edges of reg. Factory parameters are
+// resolved without a name, so each edge points at the unnamed registration.
+func (g *dependencyGraph) dependencies(reg *registration) []dependencyEdge {
+	types := append(reg.dependencyTypes(), g.decorated[reg.targetType]...)
+	edges := make([]dependencyEdge, len(types))
+	for i, typ := range types {
+		edges[i] = dependencyEdge{typ: typ, target: g.regs[registrationKey{typ: typ}]}
+	}
+	return edges
+}
+
+// depth returns the length of the longest dependency path starting at reg.
+// Registrations without dependencies have depth 0. Edges that close a cycle
+// are ignored.
+func (g *dependencyGraph) depth(reg *registration) int {
+	if d, ok := g.depths[reg]; ok {
+		return max(d, 0)
+	}
+
+	g.depths[reg] = -1 // in progress
+	d := 0
+	for _, edge := range g.dependencies(reg) {
+		if edge.target != nil {
+			d = max(d, g.depth(edge.target)+1)
+		}
+	}
+	g.depths[reg] = d
+	return d
+}
@@ -0,0 +1,96 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// InGroup adds the registration to the named group, so independent packages
+// can contribute items, such as HTTP routes or validators, that the
+// application resolves together with [ResolveGroup].
+//
+// Group members are ordinary registrations with their own lifetimes. An
+// unnamed member is registered under a generated name of the form
+// "group[n]", so members of the same type do not replace each other or the
+// type's unnamed registration. A registration can belong to several groups by
+// applying InGroup more than once. An empty group name is ignored.
+//
+// Example:
+//
+//	// In package users
+//	di.Register[Route](c, users.NewRoute, di.InGroup("routes"))
+//
+//	// In package orders
+//	di.Register[Route](c, orders.NewRoute, di.InGroup("routes"))
+//
+//	// At startup
+//	routes, err := di.ResolveGroup[Route](c, "routes")
+func InGroup(group string) RegistrationOption {
+	return func(r *registration) {
+		if group != "" && !slices.Contains(r.groups, group) {
+			r.groups = append(r.groups, group)
+		}
+	}
+}
+
+// ResolveGroup resolves the members of group whose instances are assignable
+// to T, in the order of [Container.Registrations].
+//
+// Members honor their lifetimes, and members whose conditions do not hold
+// (see [WhenRegistered]) are skipped. Resolution stops at the first error. A
+// group with no members of T resolves to an empty slice and no error, so an
+// application works the same whether or not any package contributed to it.
+//
+// Example:
+//
+//	validators, err := di.ResolveGroup[Validator](c, "validators")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, v := range validators {
+//	    v.Validate(order)
+//	}
+func ResolveGroup[T any](c *Container, group string) ([]T, error) {
+	var zero []T
+	members, err := c.resolveGroup(context.Background(), group, reflect.TypeOf(zero), nil, make([]reflect.Type, 0))
+	if err != nil {
+		return nil, err
+	}
+	return members.Interface().([]T), nil
+}
+
+// resolveGroup resolves the members of group whose instances are assignable
+// to the element type of sliceType into a new slice of that type. Members are
+// resolved as part of chain, so a member that depends on the consumer of its
+// group is reported as a circular dependency.
+func (c *Container) resolveGroup(ctx context.Context, group string, sliceType reflect.Type, scope *Scope, chain []reflect.Type) (reflect.Value, error) {
+	elemType := sliceType.Elem()
+	results := reflect.MakeSlice(sliceType, 0, 0)
+	for _, reg := range c.orderedRegistrations() {
+		if !slices.Contains(reg.groups, group) || !reg.assignableTo(elemType) || !reg.enabled(c) {
+			continue
+		}
+
+		instance, err := c.resolve(ctx, reg.targetType, reg.name, scope, chain)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if instance != nil && reflect.TypeOf(instance).AssignableTo(elemType) {
+			results = reflect.Append(results, reflect.ValueOf(instance))
+		}
+	}
+	return results, nil
+}
+
+// groupMemberName returns a free generated name for an unnamed member of the
+// registration's first group. The caller must hold c.mu.
+func (c *Container) groupMemberName(reg *registration) string {
+	for n := 0; ; n++ {
+		name := fmt.Sprintf("%s[%d]", reg.groups[0], n)
+		if _, exists := c.registrations[registrationKey{typ: reg.targetType, name: name}]; !exists {
+			return name
+		}
+	}
+}
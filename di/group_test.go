@@ -0,0 +1,47 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Group Tests
+// =============================================================================
+
+func TestResolveGroup(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.InGroup("greeters"))
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.InGroup("greeters"), di.AsSingleton())
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("formal"), di.InGroup("greeters"))
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+
+	greeters, err := di.ResolveGroup[Greeter](c, "greeters")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(greeters) != 3 {
+		t.Fatalf("expected 3 group members, got %d", len(greeters))
+	}
+	if got := greeters[0].Greet("Ada"); got != "Hello, Ada" {
+		t.Errorf("expected members in registration order, got %q first", got)
+	}
+
+	if _, err := di.ResolveNamed[Greeter](c, "greeters[1]"); err != nil {
+		t.Errorf("expected unnamed members to get generated names: %v", err)
+	}
+	if _, err := di.ResolveNamed[Greeter](c, "formal"); err != nil {
+		t.Errorf("expected named members to keep their name: %v", err)
+	}
+	if infos := c.Registrations(); len(infos[0].Groups) != 1 || infos[0].Groups[0] != "greeters" {
+		t.Errorf("expected the group to be reported, got %+v", infos[0])
+	}
+
+	empty, err := di.ResolveGroup[Greeter](c, "missing")
+	if err != nil || len(empty) != 0 {
+		t.Errorf("expected an empty group, got %v, %v", empty, err)
+	}
+}
@@ -0,0 +1,133 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// HealthChecker is implemented by components that can report their own
+// health, such as database pools and clients of remote services.
+type HealthChecker interface {
+	// CheckHealth returns nil if the component is healthy, or an error
+	// describing the problem. It should return promptly once ctx is done.
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthResult is the outcome of one component's health check.
+type HealthResult struct {
+	// Type is the registered type of the component.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Err is the error returned by the check, or nil if it passed.
+	Err error
+	// Duration is how long the check took.
+	Duration time.Duration
+}
+
+// HealthReport aggregates the health checks run by [Container.CheckHealth].
+type HealthReport struct {
+	// Results holds one result per checked component, in registration order.
+	Results []HealthResult
+}
+
+// Healthy reports whether every check passed.
+func (r HealthReport) Healthy() bool {
+	for _, result := range r.Results {
+		if result.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckHealth runs the health checks of every cached singleton that
+// implements [HealthChecker], including values registered with
+// [RegisterInstance], concurrently, and reports the results.
+//
+// Only instances that already exist are checked, so a health probe never
+// constructs a component. Singletons that have not been resolved yet are
+// skipped; mark them [Eager] so [Container.Start] constructs them.
+//
+// Example:
+//
+//	if report := container.CheckHealth(ctx); !report.Healthy() {
+//	    for _, result := range report.Results {
+//	        log.Printf("%s: %v", result.Type, result.Err)
+//	    }
+//	}
+func (c *Container) CheckHealth(ctx context.Context) HealthReport {
+	regs := c.orderedRegistrations()
+
+	var checks []*registration
+	var checkers []HealthChecker
+	c.mu.RLock()
+	for _, reg := range regs {
+		instance, ok := c.singletons[registrationKey{typ: reg.targetType, name: reg.name}]
+		if !ok {
+			continue
+		}
+		if checker, ok := instance.(HealthChecker); ok {
+			checks = append(checks, reg)
+			checkers = append(checkers, checker)
+		}
+	}
+	c.mu.RUnlock()
+
+	report := HealthReport{Results: make([]HealthResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, reg := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := runUntilDone(ctx, func() error { return checkers[i].CheckHealth(ctx) })
+			report.Results[i] = HealthResult{
+				Type:     reg.targetType,
+				Name:     reg.name,
+				Err:      err,
+				Duration: time.Since(start),
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// ErrNotReady is returned by [Container.CheckReady] when the container is not
+// ready to serve traffic.
+var ErrNotReady = errors.New("di: not ready")
+
+// CheckReady reports whether the container is ready to serve traffic: it
+// returns nil once [Container.Start] has completed, as long as every hosted
+// service it started is running, and an error wrapping [ErrNotReady] that
+// names the reason otherwise. The container stops being ready as soon as
+// [Container.Stop] begins.
+//
+// Example:
+//
+//	if err := container.CheckReady(); err != nil {
+//	    http.Error(w, err.Error(), http.StatusServiceUnavailable)
+//	    return
+//	}
+func (c *Container) CheckReady() error {
+	if !c.ready.Load() {
+		return fmt.Errorf("%w: container is not started", ErrNotReady)
+	}
+
+	c.mu.RLock()
+	supervisors := c.supervisors
+	c.mu.RUnlock()
+
+	for _, s := range supervisors {
+		stats := s.stats()
+		if stats.State != ServiceRunning {
+			return fmt.Errorf("%w: service %s is %s", ErrNotReady, describeRegistration(stats.Type, stats.Name), stats.State)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Health and Readiness Tests
+// =============================================================================
+
+type checkedPool struct {
+	err   error
+	delay time.Duration
+}
+
+func (p *checkedPool) CheckHealth(ctx context.Context) error {
+	time.Sleep(p.delay)
+	return p.err
+}
+
+func TestCheckHealth(t *testing.T) {
+	c := di.New()
+	down := errors.New("connection refused")
+	di.RegisterInstance[*checkedPool](c, &checkedPool{}, di.WithName("primary"))
+	di.RegisterInstance[*checkedPool](c, &checkedPool{err: down}, di.WithName("replica"))
+	di.Register[*TestLogger](c, func() *TestLogger { return &TestLogger{} }, di.AsSingleton())
+
+	report := c.CheckHealth(context.Background())
+	if report.Healthy() || len(report.Results) != 2 {
+		t.Fatalf("expected one failing check of two, got %+v", report)
+	}
+	if report.Results[0].Name != "primary" || report.Results[0].Err != nil {
+		t.Errorf("unexpected primary result %+v", report.Results[0])
+	}
+	if !errors.Is(report.Results[1].Err, down) {
+		t.Errorf("expected replica failure, got %+v", report.Results[1])
+	}
+}
+
+func TestCheckHealthSkipsUnconstructedSingletons(t *testing.T) {
+	c := di.New()
+	constructed := false
+	di.Register[*checkedPool](c, func() *checkedPool {
+		constructed = true
+		return &checkedPool{}
+	}, di.AsSingleton())
+
+	if report := c.CheckHealth(context.Background()); !report.Healthy() || len(report.Results) != 0 || constructed {
+		t.Errorf("expected no checks and no construction, got %+v", report)
+	}
+}
+
+func TestCheckHealthHonorsContext(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[*checkedPool](c, &checkedPool{delay: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	report := c.CheckHealth(ctx)
+	if report.Healthy() || !errors.Is(report.Results[0].Err, context.DeadlineExceeded) {
+		t.Errorf("expected the slow check to be abandoned, got %+v", report)
+	}
+}
+
+func TestCheckReady(t *testing.T) {
+	c := di.New()
+	var events []string
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "a", events: &events})
+
+	if err := c.CheckReady(); !errors.Is(err, di.ErrNotReady) {
+		t.Errorf("expected not ready before Start, got %v", err)
+	}
+	c.Start(context.Background())
+	if err := c.CheckReady(); err != nil {
+		t.Errorf("expected ready after Start, got %v", err)
+	}
+	c.Stop(context.Background())
+	if err := c.CheckReady(); !errors.Is(err, di.ErrNotReady) {
+		t.Errorf("expected not ready after Stop, got %v", err)
+	}
+}
+
+func TestCheckReadyFailedService(t *testing.T) {
+	c := di.New()
+	worker := newCrashingWorker()
+	di.RegisterInstance[di.HostedService](c, worker)
+
+	c.Start(context.Background())
+	defer c.Stop(context.Background())
+	worker.waitStarted(t)
+	worker.exit(errors.New("boom"))
+	waitServiceState(t, c, di.ServiceFailed)
+
+	if err := c.CheckReady(); !errors.Is(err, di.ErrNotReady) {
+		t.Errorf("expected not ready with a failed service, got %v", err)
+	}
+}
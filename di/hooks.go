@@ -0,0 +1,82 @@
+package di
+
+import "reflect"
+
+// Hooks are callbacks the container invokes to report what it is doing.
+//
+// All fields are optional. Hooks are called synchronously and without holding
+// any container locks, so they may safely call back into the container, but
+// slow hooks slow down the operations that trigger them.
+//
+// Use [WithHooks] to install hooks when creating a container.
+type Hooks struct {
+	// OnWarning is called when the container detects a likely wiring mistake,
+	// such as the same factory registered for several types (see [Warning]).
+	OnWarning func(Warning)
+
+	// OnDisposeError is called when the container fails to dispose an instance
+	// it released on its own, such as a singleton evicted for being idle.
+	OnDisposeError func(typ reflect.Type, name string, err error)
+
+	// OnShadowResult is called when a shadow resolution started by a
+	// registration with [WithShadow] completes. It is called from the
+	// goroutine that performed the shadow resolution.
+	OnShadowResult func(ShadowResult)
+
+	// InterceptError transforms errors returned by resolutions before they
+	// reach callers, for example to redact connection strings embedded in
+	// factory errors. It is called once per failed top-level resolution, not
+	// for each nested dependency, and the top-level [ResolveEvent] reports the
+	// transformed error. Returning nil keeps the original error.
+	InterceptError func(err error) error
+
+	// OnServiceExit is called when the background work of a started
+	// [SupervisedService] ends, before any restart. It is called from the
+	// goroutine supervising the service.
+	OnServiceExit func(ServiceExit)
+}
+
+// WithHooks installs hooks on the container.
+//
+// Example:
+//
+//	c := di.New(di.WithHooks(di.Hooks{
+//	    OnWarning: func(w di.Warning) {
+//	        log.Printf("di warning: %s", w)
+//	    },
+//	}))
+func WithHooks(hooks Hooks) ContainerOption {
+	return func(c *Container) {
+		c.hooks = hooks
+	}
+}
+
+// interceptError passes a resolution error through the InterceptError hook.
+func (c *Container) interceptError(err error) error {
+	if c.hooks.InterceptError == nil {
+		return err
+	}
+	if intercepted := c.hooks.InterceptError(err); intercepted != nil {
+		return intercepted
+	}
+	return err
+}
+
+// emitWarnings reports warnings through the OnWarning hook.
+// The caller must not hold c.mu.
+func (c *Container) emitWarnings(warnings []Warning) {
+	if c.hooks.OnWarning == nil {
+		return
+	}
+	for _, w := range warnings {
+		c.hooks.OnWarning(w)
+	}
+}
+
+// reportDisposeError reports a disposal failure through the OnDisposeError hook.
+// The caller must not hold c.mu.
+func (c *Container) reportDisposeError(typ reflect.Type, name string, err error) {
+	if c.hooks.OnDisposeError != nil {
+		c.hooks.OnDisposeError(typ, name, err)
+	}
+}
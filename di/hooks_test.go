@@ -0,0 +1,56 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Error Interceptor Tests
+// =============================================================================
+
+// redactedError hides the message of the error it wraps.
+type redactedError struct{ err error }
+
+func (e redactedError) Error() string { return "di: resolution failed (details redacted)" }
+func (e redactedError) Unwrap() error { return e.err }
+
+func TestInterceptErrorRedacts(t *testing.T) {
+	calls := 0
+	c := di.New(di.WithHooks(di.Hooks{
+		InterceptError: func(err error) error {
+			calls++
+			return redactedError{err: err}
+		},
+	}))
+	secret := errors.New("dial postgres://admin:hunter2@db: refused")
+	di.Register[Logger](c, func() (Logger, error) { return nil, secret })
+	di.Register[Service](c, func(logger Logger) Service { return &DefaultService{logger: logger} })
+
+	_, err := di.Resolve[Service](c)
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Fatalf("expected redacted error, got %v", err)
+	}
+	if !errors.Is(err, secret) {
+		t.Error("expected original error to remain in the chain")
+	}
+	if calls != 1 {
+		t.Errorf("expected one call per top-level resolution, got %d", calls)
+	}
+}
+
+func TestInterceptErrorNilKeepsOriginal(t *testing.T) {
+	c := di.New(di.WithHooks(di.Hooks{
+		InterceptError: func(err error) error { return nil },
+	}))
+
+	var notRegistered di.ErrNotRegistered
+	if _, err := di.Resolve[Logger](c); !errors.As(err, &notRegistered) {
+		t.Errorf("expected original error, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package di
+
+import "reflect"
+
+// RegistrationInfo describes a registration for inspection and tooling.
+//
+// RegistrationInfo is a read-only snapshot; modifying it has no effect on the
+// container. Use [Container.Registrations] to list all registrations.
+type RegistrationInfo struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Lifetime is the registration's lifetime.
+	Lifetime Lifetime
+	// Tags are the labels attached with [WithTags].
+	Tags []string
+	// Groups are the groups the registration contributes to with [InGroup].
+	Groups []string
+	// ImplType is the implementation type for [RegisterType] registrations,
+	// or nil otherwise.
+	ImplType reflect.Type
+	// Dependencies are the types the factory depends on, in parameter order.
+	// Instance registrations have no dependencies.
+	Dependencies []reflect.Type
+	// Instance reports whether the registration was made with [RegisterInstance].
+	Instance bool
+	// Phase is the startup phase set with [Phase], or "" for none.
+	Phase string
+	// Eager reports whether the registration was marked with [Eager].
+	Eager bool
+	// Profiles are the profiles set with [InProfiles], or nil if the
+	// registration applies to every profile.
+	Profiles []string
+}
+
+// Registrations returns information about every registration in the container,
+// in the order the registrations were made.
+//
+// The order is deterministic, so tooling output and golden tests built on it
+// are stable. Replacing a registration keeps its original position.
+//
+// The dependencies of each registration form the container's dependency graph,
+// which makes this the starting point for tooling such as dashboards and
+// wiring reports.
+//
+// Example:
+//
+//	for _, info := range container.Registrations() {
+//	    fmt.Printf("%s (%s) depends on %v\n", info.Type, info.Lifetime, info.Dependencies)
+//	}
+func (c *Container) Registrations() []RegistrationInfo {
+	regs := c.orderedRegistrations()
+
+	infos := make([]RegistrationInfo, len(regs))
+	for i, reg := range regs {
+		infos[i] = reg.info()
+	}
+	return infos
+}
+
+// orderedRegistrations returns the container's registrations in insertion order.
+func (c *Container) orderedRegistrations() []*registration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	regs := make([]*registration, len(c.order))
+	for i, key := range c.order {
+		regs[i] = c.registrations[key]
+	}
+	return regs
+}
+
+// info builds the public description of a registration.
+func (r *registration) info() RegistrationInfo {
+	return RegistrationInfo{
+		Type:         r.targetType,
+		Name:         r.name,
+		Lifetime:     r.lifetime,
+		Tags:         append([]string(nil), r.tags...),
+		Groups:       append([]string(nil), r.groups...),
+		ImplType:     r.implType,
+		Instance:     r.factory == nil,
+		Dependencies: r.dependencyTypes(),
+		Phase:        r.phase,
+		Eager:        r.eager,
+		Profiles:     append([]string(nil), r.profiles...),
+	}
+}
+
+// dependencyTypes returns the types the registration's factory resolves from
+// the container, in parameter order.
+func (r *registration) dependencyTypes() []reflect.Type {
+	if r.factory == nil {
+		return nil
+	}
+	return paramDependencies(planParams(reflect.TypeOf(r.factory)))
+}
+
+// paramDependencies returns the types the given parameters resolve from the
+// container, in parameter order.
+func paramDependencies(params []plannedParam) []reflect.Type {
+	var deps []reflect.Type
+	for _, param := range params {
+		switch param.kind {
+		case paramResolved:
+			deps = append(deps, param.typ)
+		case paramObject:
+			deps = append(deps, paramObjectDependencies(param.typ)...)
+		}
+	}
+	return deps
+}
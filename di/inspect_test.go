@@ -0,0 +1,85 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Registration Inspection Tests
+// =============================================================================
+
+func TestRegistrations(t *testing.T) {
+	c := di.New()
+
+	di.RegisterInstance[Logger](c, &TestLogger{})
+	di.RegisterType[Greeter, SimpleGreeter](c, di.WithTags("greeting"))
+	di.Register[Service](c, func(ctx context.Context, log Logger, g Greeter) Service {
+		return &DefaultService{logger: log, greeter: g}
+	}, di.AsSingleton(), di.WithName("main"), di.WithTags("app", "core"))
+
+	infos := c.Registrations()
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 registrations, got %d", len(infos))
+	}
+
+	logger, greeter, service := infos[0], infos[1], infos[2]
+
+	if greeter.ImplType != reflect.TypeOf(SimpleGreeter{}) {
+		t.Errorf("expected SimpleGreeter implementation, got %v", greeter.ImplType)
+	}
+	if len(greeter.Tags) != 1 || greeter.Tags[0] != "greeting" {
+		t.Errorf("unexpected greeter tags: %v", greeter.Tags)
+	}
+
+	if !logger.Instance || logger.Lifetime != di.Singleton {
+		t.Errorf("expected singleton instance registration, got %+v", logger)
+	}
+
+	if service.Name != "main" || service.Lifetime != di.Singleton {
+		t.Errorf("unexpected service registration: %+v", service)
+	}
+	if len(service.Tags) != 2 {
+		t.Errorf("expected 2 tags, got %v", service.Tags)
+	}
+	if len(service.Dependencies) != 2 ||
+		service.Dependencies[0] != reflect.TypeOf((*Logger)(nil)).Elem() ||
+		service.Dependencies[1] != reflect.TypeOf((*Greeter)(nil)).Elem() {
+		t.Errorf("unexpected dependencies (context should be excluded): %v", service.Dependencies)
+	}
+}
+
+func TestRegistrationsInsertionOrder(t *testing.T) {
+	c := di.New()
+
+	names := []string{"zeta", "alpha", "mu", "beta", "omega", "gamma"}
+	for _, name := range names {
+		di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName(name))
+	}
+	// Replacing a registration keeps its position
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("mu"))
+
+	for run := 0; run < 10; run++ {
+		infos := c.Registrations()
+		if len(infos) != len(names) {
+			t.Fatalf("expected %d registrations, got %d", len(names), len(infos))
+		}
+		for i, info := range infos {
+			if info.Name != names[i] {
+				t.Fatalf("expected insertion order %v, got %q at %d", names, info.Name, i)
+			}
+		}
+	}
+
+	stats := c.Stats()
+	for i, s := range stats.Registrations {
+		if s.Name != names[i] {
+			t.Fatalf("expected stats in insertion order, got %q at %d", s.Name, i)
+		}
+	}
+}
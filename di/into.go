@@ -0,0 +1,179 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ResolveInto resolves every exported field of the struct T from the container.
+//
+// This gives main() a single typed bundle of application roots instead of a
+// series of individual [Resolve] calls. Each exported field is resolved by its
+// type; unexported fields are left untouched. Struct tags control resolution:
+//   - name:"..." resolves the named registration, as with [ResolveNamed]
+//   - optional:"true" leaves the field at its zero value when its type (and
+//     name) is not registered, instead of failing
+//   - group:"..." fills a slice field with the members of a group, as
+//     [ResolveGroup] does
+//
+// Optional only covers the field's own registration: if the field is registered
+// but one of its dependencies is missing, resolution still fails.
+//
+// Returns [ErrResolutionFailed] if T is not a struct, if a tag is malformed, or
+// if any field fails to resolve. The cause identifies the field and wraps the
+// underlying error, so [errors.As] still finds [ErrNotRegistered] and friends.
+//
+// Example:
+//
+//	type App struct {
+//	    Server  *http.Server
+//	    Workers *WorkerPool
+//	    Cache   Cache   `name:"redis"`
+//	    Tracer  Tracer  `optional:"true"`
+//	}
+//
+//	app, err := di.ResolveInto[App](container)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	go app.Workers.Run()
+//	log.Fatal(app.Server.ListenAndServe())
+func ResolveInto[T any](c *Container) (T, error) {
+	var result T
+	target := reflect.ValueOf(&result).Elem()
+
+	if err := c.resolveInto(context.Background(), target, nil, make([]reflect.Type, 0)); err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
+
+// In marks a parameter object: a factory parameter of a struct type that
+// embeds In has each of its exported fields resolved from the container, so
+// constructors keep a stable signature as their dependency lists grow.
+//
+// Fields are resolved by type, within the factory's scope, and honor the
+// struct tags of [ResolveInto]:
+//   - name:"..." resolves the named registration
+//   - optional:"true" leaves the field at its zero value when its type (and
+//     name) is not registered
+//   - group:"..." fills a slice field with the members of a group, as
+//     [ResolveGroup] does
+//
+// Only fields without tags are reported as dependencies by
+// [Container.Registrations].
+//
+// Example:
+//
+//	type ServerParams struct {
+//	    di.In
+//
+//	    Logger   Logger
+//	    Cache    Cache     `name:"redis"`
+//	    Tracer   Tracer    `optional:"true"`
+//	    Handlers []Handler `group:"routes"`
+//	}
+//
+//	di.Register[*Server](c, func(p ServerParams) *Server {
+//	    return NewServer(p.Logger, p.Cache, p.Handlers)
+//	})
+type In struct{}
+
+// inType is the reflect.Type of In.
+var inType = reflect.TypeOf(In{})
+
+// isParamObject reports whether typ is a struct that embeds In.
+func isParamObject(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.Anonymous && field.Type == inType {
+			return true
+		}
+	}
+	return false
+}
+
+// paramObjectDependencies returns the types of the untagged fields of a
+// parameter object, which are resolved like plain factory parameters.
+func paramObjectDependencies(typ reflect.Type) []reflect.Type {
+	var deps []reflect.Type
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Type == inType {
+			continue
+		}
+		_, named := field.Tag.Lookup("name")
+		optional, _ := strconv.ParseBool(field.Tag.Get("optional"))
+		_, grouped := field.Tag.Lookup("group")
+		if !named && !optional && !grouped {
+			deps = append(deps, field.Type)
+		}
+	}
+	return deps
+}
+
+// resolveInto fills the exported fields of the struct value target, resolving
+// them within scope. Embedded In markers are skipped.
+func (c *Container) resolveInto(ctx context.Context, target reflect.Value, scope *Scope, chain []reflect.Type) error {
+	structType := target.Type()
+	if structType.Kind() != reflect.Struct {
+		return ErrResolutionFailed{
+			Type:  structType,
+			Cause: fmt.Errorf("ResolveInto requires a struct type, got %s", structType.Kind()),
+		}
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() || field.Type == inType {
+			continue
+		}
+
+		name := field.Tag.Get("name")
+		optional := false
+		if tag, ok := field.Tag.Lookup("optional"); ok {
+			parsed, err := strconv.ParseBool(tag)
+			if err != nil {
+				return ErrResolutionFailed{
+					Type:  structType,
+					Cause: fmt.Errorf("field %s: invalid optional tag %q", field.Name, tag),
+				}
+			}
+			optional = parsed
+		}
+
+		if group, ok := field.Tag.Lookup("group"); ok {
+			if field.Type.Kind() != reflect.Slice {
+				return ErrResolutionFailed{
+					Type:  structType,
+					Cause: fmt.Errorf("field %s: group tag requires a slice field, got %s", field.Name, field.Type),
+				}
+			}
+			members, err := c.resolveGroup(ctx, group, field.Type, scope, chain)
+			if err != nil {
+				return ErrResolutionFailed{Type: structType, Cause: fmt.Errorf("field %s: %w", field.Name, err)}
+			}
+			target.Field(i).Set(members)
+			continue
+		}
+
+		resolved, err := c.resolve(ctx, field.Type, name, scope, chain)
+		if err != nil {
+			if notRegistered, ok := err.(ErrNotRegistered); ok && optional &&
+				notRegistered.Type == field.Type && notRegistered.Name == name {
+				continue
+			}
+			return ErrResolutionFailed{Type: structType, Cause: fmt.Errorf("field %s: %w", field.Name, err)}
+		}
+		if resolved != nil {
+			target.Field(i).Set(reflect.ValueOf(resolved))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// ResolveInto Tests
+// =============================================================================
+
+type appRoots struct {
+	Logger  Logger
+	Greeter Greeter `name:"formal"`
+	Service Service `optional:"true"`
+
+	internal Logger
+}
+
+func TestResolveInto(t *testing.T) {
+	c := di.New()
+
+	logger := &TestLogger{}
+	di.RegisterInstance[Logger](c, logger)
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("formal"))
+
+	app, err := di.ResolveInto[appRoots](c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if app.Logger != logger {
+		t.Error("expected Logger field to hold the registered instance")
+	}
+	if app.Greeter == nil || app.Greeter.Greet("Ada") != "Good day, Ada" {
+		t.Errorf("expected named formal greeter, got %v", app.Greeter)
+	}
+	if app.Service != nil {
+		t.Error("expected optional unregistered field to stay nil")
+	}
+	if app.internal != nil {
+		t.Error("expected unexported field to be skipped")
+	}
+}
+
+func TestResolveIntoRequiredMissing(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	_, err := di.ResolveInto[appRoots](c)
+
+	var notRegistered di.ErrNotRegistered
+	if !errors.As(err, &notRegistered) {
+		t.Fatalf("expected ErrNotRegistered, got %v", err)
+	}
+	if notRegistered.Name != "formal" {
+		t.Errorf("expected missing named greeter, got %v", notRegistered)
+	}
+	if !contains(err.Error(), "field Greeter") {
+		t.Errorf("expected error to name the field, got %q", err.Error())
+	}
+}
+
+func TestResolveIntoOptionalWithMissingDependency(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[Logger](c, &TestLogger{})
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("formal"))
+	di.Register[Service](c, func(g Greeter) Service {
+		return &DefaultService{greeter: g}
+	})
+
+	// Service is registered, so its missing unnamed Greeter is still an error
+	_, err := di.ResolveInto[appRoots](c)
+	if err == nil {
+		t.Fatal("expected error for optional field with a missing dependency")
+	}
+}
+
+func TestResolveIntoInvalid(t *testing.T) {
+	c := di.New()
+
+	if _, err := di.ResolveInto[Logger](c); err == nil {
+		t.Error("expected error for non-struct type")
+	}
+
+	type badTag struct {
+		Logger Logger `optional:"maybe"`
+	}
+	if _, err := di.ResolveInto[badTag](c); err == nil {
+		t.Error("expected error for malformed optional tag")
+	}
+}
+
+// =============================================================================
+// Parameter Object Tests
+// =============================================================================
+
+type serviceParams struct {
+	di.In
+
+	Logger   Logger
+	Formal   Greeter   `name:"formal"`
+	Missing  Service   `optional:"true"`
+	Greeters []Greeter `group:"greeters"`
+}
+
+func TestParameterObject(t *testing.T) {
+	c := di.New()
+	logger := &TestLogger{}
+	di.RegisterInstance[Logger](c, logger)
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("formal"), di.InGroup("greeters"))
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.InGroup("greeters"))
+
+	var got serviceParams
+	di.Register[*DefaultService](c, func(p serviceParams) *DefaultService {
+		got = p
+		return &DefaultService{logger: p.Logger, greeter: p.Formal}
+	})
+
+	if _, err := di.Resolve[*DefaultService](c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Logger != logger || got.Formal.Greet("Ada") != "Good day, Ada" || got.Missing != nil || len(got.Greeters) != 2 {
+		t.Errorf("unexpected parameter object %+v", got)
+	}
+	deps := c.Registrations()[3].Dependencies
+	if len(deps) != 1 || deps[0].String() != "di_test.Logger" {
+		t.Errorf("expected only the untagged field as a dependency, got %v", deps)
+	}
+}
+
+type greeterGroupParams struct {
+	di.In
+
+	Greeters []Greeter `group:"greeters"`
+}
+
+func TestParameterObjectGroupCycle(t *testing.T) {
+	c := di.New()
+	di.Register[*DefaultService](c, func(p greeterGroupParams) *DefaultService {
+		return &DefaultService{}
+	})
+	di.Register[Greeter](c, func(s *DefaultService) Greeter { return &SimpleGreeter{} }, di.InGroup("greeters"))
+
+	_, err := di.Resolve[*DefaultService](c)
+	var circular di.ErrCircularDependency
+	if !errors.As(err, &circular) {
+		t.Errorf("expected ErrCircularDependency, got %T: %v", err, err)
+	}
+}
+
+func TestParameterObjectGroupDuringQuiesce(t *testing.T) {
+	c := di.New()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	di.Register[Logger](c, func() Logger {
+		close(entered)
+		<-release
+		return &TestLogger{}
+	})
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.InGroup("greeters"))
+	di.Register[*DefaultService](c, func(p struct {
+		di.In
+
+		Logger   Logger
+		Greeters []Greeter `group:"greeters"`
+	}) *DefaultService {
+		return &DefaultService{logger: p.Logger, greeter: p.Greeters[0]}
+	})
+
+	inFlight := make(chan error, 1)
+	go func() {
+		_, err := di.Resolve[*DefaultService](c)
+		inFlight <- err
+	}()
+	<-entered
+
+	quiesced := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		resume, err := c.Quiesce(ctx)
+		if err == nil {
+			resume()
+		}
+		quiesced <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if err := <-inFlight; err != nil {
+		t.Errorf("expected the in-flight resolution to finish, got %v", err)
+	}
+	if err := <-quiesced; err != nil {
+		t.Errorf("expected Quiesce to drain the in-flight resolution, got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// errorType is the reflect.Type of error.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Invoke calls fn with its parameters resolved from the container, which
+// wires startup code in main without a chain of [MustResolve] calls.
+//
+// It is [InvokeInScope] with context.Background() and no scope: scoped
+// registrations resolve in the default scope, if one is set (see
+// [Container.SetDefaultScope]). A returned error is passed through unchanged.
+//
+// Returns [ErrInvalidFactory] if fn is not a function that returns nothing or
+// an error, and the resolution error if a parameter cannot be resolved.
+//
+// Example:
+//
+//	err := di.Invoke(container, func(srv *http.Server, log Logger) error {
+//	    log.Log("listening on " + srv.Addr)
+//	    return srv.ListenAndServe()
+//	})
+func Invoke(c *Container, fn any) error {
+	return InvokeInScope(context.Background(), c, nil, fn)
+}
+
+// InvokeInScope calls fn with its parameters resolved from the container within
+// scope.
+//
+// Parameters are resolved like factory parameters: context.Context receives
+// ctx, *Scope receives scope, [Metadata] receives the zero Metadata, and every
+// other parameter is resolved from the container. fn may return nothing or an
+// error; a returned error is passed through unchanged.
+//
+// This lets code that is not itself a registration, such as command handlers
+// and startup routines, declare its dependencies as parameters.
+//
+// Returns [ErrInvalidFactory] if fn is not a function with a valid signature,
+// and the resolution error if a parameter cannot be resolved.
+//
+// Example:
+//
+//	scope := container.CreateScope("migrate")
+//	defer scope.Dispose()
+//
+//	err := di.InvokeInScope(ctx, container, scope, func(ctx context.Context, db *sql.DB) error {
+//	    return migrate(ctx, db)
+//	})
+func InvokeInScope(ctx context.Context, c *Container, scope *Scope, fn any) error {
+	fnValue, err := invocable(fn)
+	if err != nil {
+		return err
+	}
+
+	fnType := fnValue.Type()
+	args := make([]reflect.Value, fnType.NumIn())
+	if err := c.resolveParams(ctx, fnType, args, scope, make([]reflect.Type, 0), Metadata{}); err != nil {
+		return err
+	}
+
+	return callInvocable(fnValue, args)
+}
+
+// Wrap resolves fn's parameters once and returns a function that calls fn
+// with them, so callbacks handed to other libraries, such as HTTP servers and
+// schedulers, can be wired from the container once and reused.
+//
+// fn has the same form as for [InvokeInScope]: it may return nothing or an
+// error, which the returned function passes through. Parameters are resolved
+// immediately, without a scope, so a missing dependency is reported by Wrap
+// rather than on the first call. context.Context parameters receive
+// context.Background(). Because the parameters are resolved only once, every
+// call sees the same instances, even of transient registrations.
+//
+// Returns [ErrInvalidFactory] if fn is not a function with a valid signature,
+// and the resolution error if a parameter cannot be resolved.
+//
+// Example:
+//
+//	job, err := di.Wrap(container, func(repo *ReportRepo, mailer Mailer) error {
+//	    return sendDailyReport(repo, mailer)
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	scheduler.Every(24*time.Hour, job)
+func Wrap(c *Container, fn any) (func() error, error) {
+	fnValue, err := invocable(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	fnType := fnValue.Type()
+	args := make([]reflect.Value, fnType.NumIn())
+	if err := c.resolveParams(context.Background(), fnType, args, nil, make([]reflect.Type, 0), Metadata{}); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return callInvocable(fnValue, args)
+	}, nil
+}
+
+// Bind partially applies fn: it resolves fn's parameters from the container
+// and returns a function that takes only the parameters listed in manual, to
+// bridge container wiring with call-site data such as request payloads.
+//
+// Each entry of manual is a reflect.Type or a value whose type is used, and
+// claims the first parameter of fn of exactly that type that is not claimed
+// yet. The returned function takes the claimed parameters in their original
+// order and returns fn's results unchanged; type-assert it to its concrete
+// function type. The other parameters are resolved like those of [Wrap]:
+// once, immediately and without a scope, so wiring problems are reported by
+// Bind rather than by a call, and every call sees the same instances.
+//
+// Returns [ErrInvalidFactory] if fn is not a non-variadic function or an entry
+// of manual matches no parameter, and the resolution error if a parameter
+// cannot be resolved.
+//
+// Example:
+//
+//	bound, err := di.Bind(container, func(repo *OrderRepo, req CreateOrder) (*Order, error) {
+//	    return repo.Create(req)
+//	}, CreateOrder{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	create := bound.(func(CreateOrder) (*Order, error))
+//	order, err := create(payload)
+func Bind(c *Container, fn any, manual ...any) (any, error) {
+	if !reflectiveFactories {
+		return nil, ErrInvalidFactory{Type: reflect.TypeOf(fn), Message: errReflectionDisabled}
+	}
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func {
+		return nil, ErrInvalidFactory{Type: reflect.TypeOf(fn), Message: "bound value must be a function"}
+	}
+	fnType := fnValue.Type()
+	if fnType.IsVariadic() {
+		return nil, ErrInvalidFactory{Type: fnType, Message: "bound function cannot be variadic"}
+	}
+
+	// Claim the manual parameters; resolveParams skips arguments that are set
+	args := make([]reflect.Value, fnType.NumIn())
+	claimed := make([]bool, fnType.NumIn())
+	for _, m := range manual {
+		typ, ok := m.(reflect.Type)
+		if !ok {
+			typ = reflect.TypeOf(m)
+		}
+		i := 0
+		for i < len(args) && (claimed[i] || fnType.In(i) != typ) {
+			i++
+		}
+		if i == len(args) {
+			return nil, ErrInvalidFactory{Type: fnType, Message: fmt.Sprintf("bound function has no unclaimed parameter of type %v", typ)}
+		}
+		claimed[i] = true
+		args[i] = reflect.Zero(typ)
+	}
+
+	if err := c.resolveParams(context.Background(), fnType, args, nil, make([]reflect.Type, 0), Metadata{}); err != nil {
+		return nil, err
+	}
+
+	var in []reflect.Type
+	for i, isManual := range claimed {
+		if isManual {
+			in = append(in, fnType.In(i))
+		}
+	}
+	out := make([]reflect.Type, fnType.NumOut())
+	for i := range out {
+		out[i] = fnType.Out(i)
+	}
+
+	bound := makeFunc(reflect.FuncOf(in, out, false), func(values []reflect.Value) []reflect.Value {
+		callArgs := append([]reflect.Value(nil), args...)
+		next := 0
+		for i, isManual := range claimed {
+			if isManual {
+				callArgs[i] = values[next]
+				next++
+			}
+		}
+		return callFunc(fnValue, callArgs)
+	})
+	return bound.Interface(), nil
+}
+
+// invocable checks that fn is a function that returns nothing or an error.
+func invocable(fn any) (reflect.Value, error) {
+	if !reflectiveFactories {
+		return reflect.Value{}, ErrInvalidFactory{Type: reflect.TypeOf(fn), Message: errReflectionDisabled}
+	}
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func {
+		return reflect.Value{}, ErrInvalidFactory{Type: reflect.TypeOf(fn), Message: "invoked value must be a function"}
+	}
+
+	fnType := fnValue.Type()
+	switch {
+	case fnType.NumOut() > 1:
+		return reflect.Value{}, ErrInvalidFactory{Type: fnType, Message: "invoked function cannot return more than 1 value"}
+	case fnType.NumOut() == 1 && fnType.Out(0) != errorType:
+		return reflect.Value{}, ErrInvalidFactory{Type: fnType, Message: "invoked function may only return error"}
+	}
+	return fnValue, nil
+}
+
+// callInvocable calls a function checked by invocable and returns its error.
+func callInvocable(fnValue reflect.Value, args []reflect.Value) error {
+	results := callFunc(fnValue, args)
+	if len(results) == 1 && !results[0].IsNil() {
+		return results[0].Interface().(error)
+	}
+	return nil
+}
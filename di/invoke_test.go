@@ -0,0 +1,159 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Invoke Tests
+// =============================================================================
+
+func TestInvokeInScope(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[Logger](c, &TestLogger{})
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsScoped())
+
+	scope := c.CreateScope("invoke")
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	var called bool
+	err := di.InvokeInScope(ctx, c, scope, func(ctx context.Context, s *di.Scope, logger Logger, g Greeter) {
+		called = true
+		if ctx.Value(ctxKey{}) != "value" {
+			t.Error("expected the invocation context")
+		}
+		if s != scope {
+			t.Error("expected the invocation scope")
+		}
+		if scoped, _ := di.ResolveInScope[Greeter](c, scope); scoped != g {
+			t.Error("expected the scoped instance")
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected function to be called")
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	c := di.New()
+	logger := &TestLogger{}
+	di.RegisterInstance[Logger](c, logger)
+	boom := errors.New("boom")
+
+	var got Logger
+	if err := di.Invoke(c, func(l Logger) { got = l }); err != nil || got != logger {
+		t.Errorf("expected the logger to be injected, got %v, %v", got, err)
+	}
+	if err := di.Invoke(c, func(Logger) error { return boom }); !errors.Is(err, boom) {
+		t.Errorf("expected the function's error, got %v", err)
+	}
+	if err := di.Invoke(c, func(Greeter) {}); !errors.As(err, new(di.ErrNotRegistered)) {
+		t.Errorf("expected a missing dependency to fail, got %v", err)
+	}
+}
+
+func TestInvokeInScopeErrors(t *testing.T) {
+	c := di.New()
+	scope := c.CreateScope("invoke")
+	boom := errors.New("boom")
+
+	if err := di.InvokeInScope(context.Background(), c, scope, func() error { return boom }); !errors.Is(err, boom) {
+		t.Errorf("expected returned error, got %v", err)
+	}
+
+	var notRegistered di.ErrNotRegistered
+	if err := di.InvokeInScope(context.Background(), c, scope, func(Logger) {}); !errors.As(err, &notRegistered) {
+		t.Errorf("expected ErrNotRegistered, got %v", err)
+	}
+
+	var invalid di.ErrInvalidFactory
+	for _, fn := range []any{"not a function", func() int { return 0 }, func() (int, error) { return 0, nil }} {
+		if err := di.InvokeInScope(context.Background(), c, scope, fn); !errors.As(err, &invalid) {
+			t.Errorf("expected ErrInvalidFactory for %T, got %v", fn, err)
+		}
+	}
+}
+
+func TestWrapResolvesOnce(t *testing.T) {
+	c := di.New()
+	constructions := 0
+	di.Register[Logger](c, func() Logger {
+		constructions++
+		return &TestLogger{}
+	})
+
+	var seen []Logger
+	job, err := di.Wrap(c, func(log Logger) error {
+		seen = append(seen, log)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for range 3 {
+		if err := job(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if constructions != 1 || len(seen) != 3 || seen[0] != seen[2] {
+		t.Errorf("expected one resolution reused by every call, got %d constructions", constructions)
+	}
+}
+
+func TestWrapErrors(t *testing.T) {
+	c := di.New()
+	boom := errors.New("boom")
+
+	if _, err := di.Wrap(c, func(Greeter) {}); !errors.As(err, new(di.ErrNotRegistered)) {
+		t.Errorf("expected a missing dependency to fail eagerly, got %v", err)
+	}
+	if _, err := di.Wrap(c, "not a function"); !errors.As(err, new(di.ErrInvalidFactory)) {
+		t.Errorf("expected ErrInvalidFactory, got %v", err)
+	}
+
+	job, err := di.Wrap(c, func() error { return boom })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := job(); !errors.Is(err, boom) {
+		t.Errorf("expected the function's error, got %v", err)
+	}
+}
+
+func TestBind(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+
+	bound, err := di.Bind(c, func(prefix string, g Greeter, name string) string {
+		return prefix + g.Greet(name)
+	}, "", reflect.TypeOf(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	greet, ok := bound.(func(string, string) string)
+	if !ok {
+		t.Fatalf("expected func(string, string) string, got %T", bound)
+	}
+	if got := greet("> ", "Ada"); got != "> Hello, Ada" {
+		t.Errorf("expected manual parameters in their original order, got %q", got)
+	}
+
+	if _, err := di.Bind(c, func(Logger, string) {}, ""); !errors.As(err, new(di.ErrNotRegistered)) {
+		t.Errorf("expected a missing dependency to fail eagerly, got %v", err)
+	}
+	if _, err := di.Bind(c, func(Greeter) {}, 0); !errors.As(err, new(di.ErrInvalidFactory)) {
+		t.Errorf("expected ErrInvalidFactory for an unmatched manual type, got %v", err)
+	}
+}
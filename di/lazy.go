@@ -0,0 +1,98 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// Lazy is a factory parameter that defers resolving a dependency until it is
+// first needed.
+//
+// When a factory declares a parameter of type Lazy[T], it receives a handle
+// instead of a T; the container resolves T on the first call to
+// [Lazy.Get], within the scope the factory was resolved in, and every later
+// call returns the same result. This avoids constructing expensive
+// dependencies that a code path may never use, and breaks construction-time
+// cycles: A can take a Lazy[B] while B takes an A, as long as A does not call
+// Get from its factory.
+//
+// Lazy parameters are not reported as dependencies by
+// [Container.Registrations] or checked by validation, since they are not
+// resolved during construction. Copies of a Lazy share its result.
+//
+// Example:
+//
+//	di.Register[*ReportService](c, func(pdf di.Lazy[*PDFRenderer]) *ReportService {
+//	    return &ReportService{pdf: pdf}
+//	})
+//
+//	func (s *ReportService) Export(r Report) ([]byte, error) {
+//	    renderer, err := s.pdf.Get()
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return renderer.Render(r)
+//	}
+type Lazy[T any] struct {
+	state *lazyState[T]
+}
+
+// lazyState is the resolution shared by the copies of a Lazy.
+type lazyState[T any] struct {
+	once  sync.Once
+	c     *Container
+	scope *Scope
+	value T
+	err   error
+}
+
+// Get resolves the dependency on the first call and returns the same instance
+// or error on every call. Get on the zero Lazy, which was not injected by a
+// container, returns [ErrResolutionFailed].
+func (l Lazy[T]) Get() (T, error) {
+	if l.state == nil {
+		var zero T
+		return zero, ErrResolutionFailed{Type: l.lazyType(), Cause: errLazyNotInjected}
+	}
+	s := l.state
+	s.once.Do(func() {
+		instance, err := s.c.resolve(context.Background(), l.lazyType(), "", s.scope, make([]reflect.Type, 0))
+		if err != nil {
+			s.err = err
+			return
+		}
+		s.value, _ = instance.(T)
+	})
+	return s.value, s.err
+}
+
+// errLazyNotInjected is the cause reported by Get on the zero Lazy.
+var errLazyNotInjected = errors.New("di: Lazy was not injected by a container")
+
+// lazyParam is implemented by every instantiation of Lazy.
+type lazyParam interface {
+	lazyType() reflect.Type
+	bind(c *Container, scope *Scope) any
+}
+
+func (Lazy[T]) lazyType() reflect.Type {
+	var zero T
+	return reflect.TypeOf(&zero).Elem()
+}
+
+func (Lazy[T]) bind(c *Container, scope *Scope) any {
+	return Lazy[T]{state: &lazyState[T]{c: c, scope: scope}}
+}
+
+// lazyParamType is the reflect.Type of lazyParam.
+var lazyParamType = reflect.TypeOf((*lazyParam)(nil)).Elem()
+
+// asLazy returns the Lazy behind a parameter type, if it is one.
+func asLazy(paramType reflect.Type) (lazyParam, bool) {
+	if paramType.Kind() != reflect.Struct || !paramType.Implements(lazyParamType) {
+		return nil, false
+	}
+	return reflect.Zero(paramType).Interface().(lazyParam), true
+}
@@ -0,0 +1,76 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Lazy Dependency Tests
+// =============================================================================
+
+type lazyParent struct {
+	child di.Lazy[*lazyChild]
+}
+
+type lazyChild struct {
+	parent *lazyParent
+}
+
+func TestLazyDefersResolution(t *testing.T) {
+	c := di.New()
+	constructions := 0
+	di.Register[Logger](c, func() Logger {
+		constructions++
+		return &TestLogger{}
+	})
+	var handle di.Lazy[Logger]
+	di.Register[Service](c, func(logger di.Lazy[Logger]) Service {
+		handle = logger
+		return &DefaultService{}
+	})
+
+	di.MustResolve[Service](c)
+	if constructions != 0 {
+		t.Fatalf("expected no construction before Get, got %d", constructions)
+	}
+	first, err := handle.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := handle.Get()
+	if first != second || constructions != 1 {
+		t.Errorf("expected Get to resolve once, got %d constructions", constructions)
+	}
+
+	var failed di.ErrResolutionFailed
+	if _, err := (di.Lazy[Logger]{}).Get(); !errors.As(err, &failed) {
+		t.Errorf("expected the zero Lazy to fail, got %v", err)
+	}
+}
+
+func TestLazyBreaksCycles(t *testing.T) {
+	c := di.New()
+	di.Register[*lazyParent](c, func(child di.Lazy[*lazyChild]) *lazyParent {
+		return &lazyParent{child: child}
+	}, di.AsSingleton())
+	di.Register[*lazyChild](c, func(parent *lazyParent) *lazyChild {
+		return &lazyChild{parent: parent}
+	}, di.AsSingleton())
+
+	parent, err := di.Resolve[*lazyParent](c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	child, err := parent.child.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if child.parent != parent {
+		t.Error("expected the cycle to be wired through the lazy handle")
+	}
+}
@@ -0,0 +1,154 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// HostedService is implemented by components that run in the background for
+// the lifetime of the application, such as job schedulers, queue consumers,
+// and servers.
+//
+// Register hosted services like any other dependency, usually as singletons;
+// [Container.Start] finds and starts them and [Container.Stop] stops them.
+type HostedService interface {
+	// Start begins the service's background work. It should return promptly,
+	// running long-lived work in its own goroutines.
+	Start(ctx context.Context) error
+	// Stop ends the service's background work, waiting for in-flight work
+	// until ctx is done.
+	Stop(ctx context.Context) error
+}
+
+// hostedServiceType is the reflect.Type of HostedService.
+var hostedServiceType = reflect.TypeOf((*HostedService)(nil)).Elem()
+
+// Start resolves every registration that implements [HostedService] and starts
+// the services in the order of [Container.Registrations]. Lifecycle extensions
+// (see [LifecycleExtension]) are started first.
+//
+// Startup runs phase by phase (see [Phase]): the [Eager] singletons and hosted
+// services of a phase are all constructed and started before the next phase
+// begins. Without phases, everything runs in a single phase.
+//
+// Services that implement [SupervisedService] are watched after they start
+// and restarted according to their [RestartPolicy] (see [WithRestartPolicy]).
+//
+// If a service fails to resolve or start, the services and extensions already
+// started are stopped in reverse order and the error is returned. Calling Start
+// again while the services are running has no effect.
+//
+// Example:
+//
+//	di.Register[*Scheduler](c, NewScheduler, di.AsSingleton())
+//
+//	if err := c.Start(ctx); err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer c.Stop(context.Background())
+func (c *Container) Start(ctx context.Context) error {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+
+	if c.started != nil {
+		return nil
+	}
+	c.starting.Store(true)
+	defer c.starting.Store(false)
+
+	c.mu.Lock()
+	c.supervisors = nil
+	c.mu.Unlock()
+
+	if err := c.startExtensions(ctx); err != nil {
+		return err
+	}
+
+	started := make([]runningService, 0)
+	clock := c.newStartupClock()
+	for _, phase := range c.startupPhases() {
+		constructing := time.Now()
+		instances, err := c.constructPhase(ctx, phase)
+		if err == nil {
+			err = clock.add(time.Since(constructing))
+		}
+		if err != nil {
+			c.endSupervision(ctx)
+			_, stopErr := stopServices(ctx, started)
+			return errors.Join(phase.wrap(err), stopErr, c.stopLifecycleExtensions(ctx))
+		}
+
+		graph := newDependencyGraph(c.orderedRegistrations())
+		for i, reg := range phase.regs {
+			service, ok := instances[i].(HostedService)
+			if !ok {
+				continue
+			}
+			if err := service.Start(ctx); err != nil {
+				err = phase.wrap(fmt.Errorf("di: failed to start %T: %w", service, err))
+				c.endSupervision(ctx)
+				_, stopErr := stopServices(ctx, started)
+				return errors.Join(err, stopErr, c.stopLifecycleExtensions(ctx))
+			}
+			started = append(started, runningService{service: service, reg: reg, deps: graph.reachable(reg)})
+			c.supervise(ctx, reg, service)
+		}
+	}
+
+	c.started = started
+	c.ready.Store(true)
+	clock.finish()
+	return nil
+}
+
+// Stop stops the services started by [Container.Start] in reverse start order,
+// followed by lifecycle extensions. A service that depends on another hosted
+// service, directly or through its dependencies, is stopped first, whatever
+// the start order.
+//
+// Every service is asked to stop even if an earlier one fails; the errors are
+// joined into the returned error. A service whose Stop is still running when
+// ctx is done is abandoned and reported as failed, so a service that ignores
+// its context cannot block shutdown forever. Calling Stop when the services
+// are not running has no effect.
+func (c *Container) Stop(ctx context.Context) error {
+	_, err := c.stop(ctx)
+	return err
+}
+
+// stop stops the running services and lifecycle extensions, and returns the
+// services that failed to stop.
+func (c *Container) stop(ctx context.Context) ([]runningService, error) {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+
+	if c.started == nil {
+		return nil, nil
+	}
+
+	c.ready.Store(false)
+	started := c.started
+	c.started = nil
+	c.endSupervision(ctx)
+	failed, err := stopServices(ctx, started)
+	return failed, errors.Join(err, c.stopLifecycleExtensions(ctx))
+}
+
+// stopServices stops services in shutdown order (see shutdownOrder),
+// abandoning stops that are still running when ctx is done, and returns the
+// services that failed to stop.
+func stopServices(ctx context.Context, services []runningService) ([]runningService, error) {
+	var failed []runningService
+	var errs []error
+	for _, s := range shutdownOrder(services) {
+		service := s.service
+		if err := runUntilDone(ctx, func() error { return service.Stop(ctx) }); err != nil {
+			failed = append(failed, s)
+			errs = append(errs, fmt.Errorf("di: failed to stop %T: %w", service, err))
+		}
+	}
+	return failed, errors.Join(errs...)
+}
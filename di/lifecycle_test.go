@@ -0,0 +1,77 @@
+package di_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Hosted Service Lifecycle Tests
+// =============================================================================
+
+type recordingService struct {
+	name     string
+	events   *[]string
+	startErr error
+}
+
+func (s *recordingService) Start(ctx context.Context) error {
+	*s.events = append(*s.events, "start "+s.name)
+	return s.startErr
+}
+
+func (s *recordingService) Stop(ctx context.Context) error {
+	*s.events = append(*s.events, "stop "+s.name)
+	return nil
+}
+
+func TestStartStopHostedServices(t *testing.T) {
+	c := di.New()
+	var events []string
+
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "a", events: &events}, di.WithName("a"))
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "b", events: &events}, di.WithName("b"))
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error on second start: %v", err)
+	}
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected stop error: %v", err)
+	}
+
+	want := []string{"start a", "start b", "stop b", "stop a"}
+	if len(events) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("expected events %v, got %v", want, events)
+			break
+		}
+	}
+}
+
+func TestStartFailureStopsStartedServices(t *testing.T) {
+	c := di.New()
+	var events []string
+	boom := errors.New("boom")
+
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "a", events: &events}, di.WithName("a"))
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "b", events: &events, startErr: boom}, di.WithName("b"))
+
+	err := c.Start(context.Background())
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected start error, got %v", err)
+	}
+
+	if len(events) != 3 || events[2] != "stop a" {
+		t.Errorf("expected started service to be stopped, got %v", events)
+	}
+}
@@ -1,6 +1,10 @@
 package di
 
-import "sync"
+import (
+	"context"
+	"reflect"
+	"sync"
+)
 
 // Lifetime defines how long a resolved instance lives.
 //
@@ -8,6 +12,7 @@ import "sync"
 //   - [Transient]: No caching, new instance every resolution
 //   - [Singleton]: Cached for container lifetime
 //   - [Scoped]: Cached per scope
+//   - [ScopeFamily]: Cached per family of related scopes
 //
 // Use the [WithLifetime] option or convenience functions [AsSingleton],
 // [AsTransient], [AsScoped] when registering dependencies.
@@ -48,6 +53,20 @@ const (
 	//	scope := container.CreateScope("request-1")
 	//	ctx, _ := di.ResolveInScope[*RequestContext](c, scope)
 	Scoped
+
+	// ScopeFamily creates one instance per family of related scopes, such as
+	// all the request scopes of one tenant. It sits between Singleton and
+	// Scoped: scopes of the same family share the instance, other families
+	// get their own. See [AsScopeFamily].
+	//
+	// Example:
+	//
+	//	di.Register[*TenantCache](c, newTenantCache, di.AsScopeFamily("/"))
+	//
+	//	a := container.CreateScope("tenant-42/request-1")
+	//	b := container.CreateScope("tenant-42/request-2")
+	//	// a and b resolve the same *TenantCache
+	ScopeFamily
 )
 
 // String returns the string representation of the lifetime.
@@ -59,6 +78,8 @@ func (l Lifetime) String() string {
 		return "Singleton"
 	case Scoped:
 		return "Scoped"
+	case ScopeFamily:
+		return "ScopeFamily"
 	default:
 		return "Unknown"
 	}
@@ -85,18 +106,36 @@ func (l Lifetime) String() string {
 //	    ctx2, _ := di.ResolveInScope[*RequestContext](c, scope)
 //	    // ctx1 == ctx2
 //	}
+//
+// Factories can declare a *Scope parameter to receive the scope they are
+// resolved in, for example to read values attached with [Scope.SetValue]. The
+// parameter is nil when resolving outside of any scope.
 type Scope struct {
 	mu        sync.RWMutex
 	name      string
-	instances map[any]any
+	instances map[registrationKey]any
+	order     []registrationKey // Instance keys in creation order
+	values    map[any]any
+	inherited map[registrationKey]bool // Instances shared with the scope this one was forked from
 	parent    *Container
+	dispose   func() error            // Disposal wrapped by scope middleware, until first used
+	budget    *scopeBudget            // Resource limits, shared with forks (see SetBudget)
+	ctx       context.Context         // Context for resolutions without one (see SetContext)
+	overrides map[registrationKey]any // Instances shadowing registrations (see Override)
+	arena     *[]arenaEntry           // Transients to release with the scope (see WithScopeArena)
 }
 
+// scopeType is the reflect.Type of *Scope. Factory parameters of this type
+// receive the resolving scope rather than being resolved from the container.
+var scopeType = reflect.TypeOf((*Scope)(nil))
+
 // newScope creates a new scope attached to the given container.
 func newScope(name string, parent *Container) *Scope {
 	return &Scope{
 		name:      name,
-		instances: make(map[any]any),
+		instances: make(map[registrationKey]any),
+		values:    make(map[any]any),
+		inherited: make(map[registrationKey]bool),
 		parent:    parent,
 	}
 }
@@ -108,8 +147,217 @@ func (s *Scope) Name() string {
 	return s.name
 }
 
+// SetValue attaches a value to the scope under key.
+//
+// Scope values carry per-scope data such as a request ID or tenant that
+// selectors and other scope-aware features can read. As with context values,
+// keys should be of an unexported type to avoid collisions between packages.
+//
+// Example:
+//
+//	type tenantKey struct{}
+//
+//	scope := container.CreateScope("request-123")
+//	scope.SetValue(tenantKey{}, "acme")
+func (s *Scope) SetValue(key, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// Value returns the value attached to the scope under key, or nil if there is none.
+func (s *Scope) Value(key any) any {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values[key]
+}
+
+// SetContext sets the context that resolutions in the scope run with when the
+// caller does not pass one, such as [ResolveInScope].
+//
+// Factories that declare a context.Context parameter receive it, so
+// constructors of scoped services can honor the deadline and read the values
+// of the request or job the scope belongs to. [ScopeForContext] and the HTTP
+// middleware in dihttp set it for the scopes they create. Forks inherit it.
+//
+// The context is also passed to the factories of singletons and transients
+// first constructed from the scope. Factories should only use it during
+// construction rather than retain it, since the scope's context usually ends
+// long before a singleton does.
+//
+// Example:
+//
+//	scope := container.CreateScope("request-123")
+//	scope.SetContext(r.Context())
+//
+//	// func newOrderRepo(ctx context.Context, db *sql.DB) *OrderRepo
+//	repo, _ := di.ResolveInScope[*OrderRepo](container, scope)
+func (s *Scope) SetContext(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ctx = ctx
+}
+
+// Context returns the context set with [Scope.SetContext], or
+// context.Background() if there is none. A nil scope returns
+// context.Background() too.
+func (s *Scope) Context() context.Context {
+	if s == nil {
+		return context.Background()
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// Fork creates a new scope that starts out sharing this scope's cached instances.
+//
+// The forked scope holds references to every scoped instance already created in
+// this scope, so those instances are shared between the two, and it starts with
+// a copy of this scope's values. Instances created after the fork are cached only
+// in the scope that created them, which gives each branch of fan-out work its own
+// unit of work while still sharing request-level state.
+//
+// Like [Container.CreateScope], forking goes through the container's scope
+// middleware (see [Container.UseScope]), and forking with the name of an
+// existing scope replaces that scope in the container. Instances and values
+// the middleware attaches to the fork take precedence over the ones copied
+// from this scope.
+//
+// Example:
+//
+//	scope := container.CreateScope("request-123")
+//	reqCtx, _ := di.ResolveInScope[*RequestContext](container, scope)
+//
+//	for i := range shards {
+//	    branch := scope.Fork(fmt.Sprintf("request-123/shard-%d", i))
+//	    go func() {
+//	        // Same *RequestContext, but a fresh *UnitOfWork per branch
+//	        uow, _ := di.ResolveInScope[*UnitOfWork](container, branch)
+//	        // ...
+//	    }()
+//	}
+func (s *Scope) Fork(name string) *Scope {
+	fork, dispose := s.parent.scopeFactory()(name)
+	fork.setDispose(dispose)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fork.mu.Lock()
+	defer fork.mu.Unlock()
+
+	// Middleware may have created instances and set values on the fork
+	// already; those take precedence over the parent's
+	for _, key := range s.order {
+		if _, exists := fork.instances[key]; exists {
+			continue
+		}
+		fork.instances[key] = s.instances[key]
+		fork.inherited[key] = true
+		fork.order = append(fork.order, key)
+	}
+	for key, value := range s.values {
+		if _, exists := fork.values[key]; !exists {
+			fork.values[key] = value
+		}
+	}
+	if fork.budget == nil {
+		fork.budget = s.budget
+	}
+	if fork.ctx == nil {
+		fork.ctx = s.ctx
+	}
+	if len(s.overrides) > 0 {
+		fork.overrides = make(map[registrationKey]any, len(s.overrides))
+		for key, instance := range s.overrides {
+			fork.overrides[key] = instance
+		}
+	}
+	return fork
+}
+
+// Dispose releases the scope and the instances it created.
+//
+// Scoped instances that implement [Disposable] or io.Closer are disposed in
+// reverse creation order, so an instance is disposed before the scoped
+// instances it was created from. The scope's cache is emptied, and the scope
+// is removed from its container (and unset as the default scope if it is
+// one), so scopes created per request do not accumulate. Instances a forked
+// scope shares with the scope it was forked from are left open for their
+// owner to dispose. If the scope is the last live scope of a family (see
+// [AsScopeFamily]), the family's instances are disposed too. With
+// [WithScopeArena], the transients created in the scope are disposed first.
+//
+// The scope can still be used after Dispose, but it starts over with an empty
+// cache and is no longer tracked by the container. Disposal failures are
+// reported as an [ErrDisposeFailed] that names each failed instance. The first
+// Dispose also runs the disposal added by scope middleware (see
+// [Container.UseScope]).
+//
+// Example:
+//
+//	scope := container.CreateScope("job-42")
+//	defer scope.Dispose()
+func (s *Scope) Dispose() error {
+	s.mu.Lock()
+	dispose := s.dispose
+	s.dispose = nil
+	s.mu.Unlock()
+
+	if dispose != nil {
+		return dispose()
+	}
+	return s.release()
+}
+
+// setDispose sets the disposal function wrapped by scope middleware.
+func (s *Scope) setDispose(dispose func() error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dispose = dispose
+}
+
+// release removes the scope from its container and disposes the instances it
+// created.
+func (s *Scope) release() error {
+	s.parent.mu.Lock()
+	if s.parent.scopes[s.name] == s {
+		delete(s.parent.scopes, s.name)
+	}
+	if s.parent.defaultScope == s {
+		s.parent.defaultScope = nil
+	}
+	s.parent.mu.Unlock()
+
+	s.mu.Lock()
+	instances, inherited, order := s.instances, s.inherited, s.order
+	s.instances = make(map[registrationKey]any)
+	s.inherited = make(map[registrationKey]bool)
+	s.order = nil
+	s.mu.Unlock()
+
+	// Dispose newest first, so instances go before their dependencies
+	failures := s.releaseArena()
+	for i := len(order) - 1; i >= 0; i-- {
+		key := order[i]
+		if inherited[key] {
+			continue
+		}
+		if err := disposeInstance(instances[key]); err != nil {
+			failures = append(failures, DisposeFailure{Type: key.typ, Name: key.name, Err: err})
+		}
+	}
+
+	// Family instances outlive the scope's own, which may depend on them
+	failures = append(failures, s.parent.leaveFamilies(s)...)
+	return disposeFailed(failures)
+}
+
 // get retrieves an instance from the scope cache.
-func (s *Scope) get(key any) (any, bool) {
+func (s *Scope) get(key registrationKey) (any, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	instance, ok := s.instances[key]
@@ -117,8 +365,58 @@ func (s *Scope) get(key any) (any, bool) {
 }
 
 // set stores an instance in the scope cache.
-func (s *Scope) set(key any, instance any) {
+func (s *Scope) set(key registrationKey, instance any) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if _, exists := s.instances[key]; !exists {
+		s.order = append(s.order, key)
+	}
 	s.instances[key] = instance
 }
+
+// InstanceKey identifies the registration an instance was created for.
+type InstanceKey struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+}
+
+// String renders the key as the type followed by the quoted name, if any.
+func (k InstanceKey) String() string {
+	return describeRegistration(k.Type, k.Name)
+}
+
+// ScopedInstanceInfo describes an instance cached in a scope.
+type ScopedInstanceInfo struct {
+	// Key identifies the registration the instance was created for.
+	Key InstanceKey
+	// Instance is the cached instance.
+	Instance any
+	// Inherited reports whether the instance is shared with the scope this
+	// one was forked from (see [Scope.Fork]), which owns it.
+	Inherited bool
+}
+
+// Instances lists the instances cached in the scope, in the order they were
+// created, for tooling such as per-request memory attribution and debugging.
+//
+// Example:
+//
+//	for _, info := range scope.Instances() {
+//	    log.Printf("%s: %T", info.Key, info.Instance)
+//	}
+func (s *Scope) Instances() []ScopedInstanceInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	infos := make([]ScopedInstanceInfo, len(s.order))
+	for i, key := range s.order {
+		infos[i] = ScopedInstanceInfo{
+			Key:       InstanceKey{Type: key.typ, Name: key.name},
+			Instance:  s.instances[key],
+			Inherited: s.inherited[key],
+		}
+	}
+	return infos
+}
@@ -0,0 +1,56 @@
+package di
+
+import "log/slog"
+
+// LogField maps a scope value to a log attribute for [RegisterScopedLogger].
+type LogField struct {
+	// Key is the scope value key, as passed to [Scope.SetValue].
+	Key any
+	// Attr is the name of the log attribute.
+	Attr string
+}
+
+// RegisterScopedLogger registers a scoped *slog.Logger enriched with values
+// from the resolving scope.
+//
+// Each scope gets a logger derived from base with one attribute per field whose
+// key has a value in the scope, so every scoped service that depends on
+// *slog.Logger logs with correlation fields such as the request ID or tenant.
+// Fields without a value in the scope are omitted, and resolving outside of a
+// scope returns base itself. A nil base uses slog.Default().
+//
+// The dihttp middleware registers a scoped logger with the request ID
+// automatically when given a base logger.
+//
+// Example:
+//
+//	type tenantKey struct{}
+//
+//	di.RegisterScopedLogger(c, slog.Default(), di.LogField{Key: tenantKey{}, Attr: "tenant"})
+//
+//	scope := c.CreateScope("job-42")
+//	scope.SetValue(tenantKey{}, "acme")
+//	logger, _ := di.ResolveInScope[*slog.Logger](c, scope)
+//	logger.Info("started") // ... msg=started tenant=acme
+func RegisterScopedLogger(c *Container, base *slog.Logger, fields ...LogField) error {
+	return Register1[*slog.Logger](c, func(scope *Scope) (*slog.Logger, error) {
+		logger := base
+		if logger == nil {
+			logger = slog.Default()
+		}
+		if scope == nil {
+			return logger, nil
+		}
+
+		var attrs []any
+		for _, field := range fields {
+			if value := scope.Value(field.Key); value != nil {
+				attrs = append(attrs, slog.Any(field.Attr, value))
+			}
+		}
+		if len(attrs) == 0 {
+			return logger, nil
+		}
+		return logger.With(attrs...), nil
+	}, AsScoped(), WithTags("logging"))
+}
@@ -0,0 +1,87 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Scoped Logger Tests
+// =============================================================================
+
+type tenantLogKey struct{}
+
+type auditService struct {
+	log *slog.Logger
+}
+
+func TestScopedLogger(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewTextHandler(&buf, nil))
+
+	c := di.New()
+	di.RegisterScopedLogger(c, base, di.LogField{Key: tenantLogKey{}, Attr: "tenant"})
+	di.Register[*auditService](c, func(log *slog.Logger) *auditService {
+		return &auditService{log: log}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request-1")
+	scope.SetValue(tenantLogKey{}, "acme")
+
+	svc, err := di.ResolveInScope[*auditService](c, scope)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svc.log.Info("audited")
+
+	if !contains(buf.String(), "tenant=acme") {
+		t.Errorf("expected tenant attribute, got %q", buf.String())
+	}
+
+	logger, _ := di.ResolveInScope[*slog.Logger](c, scope)
+	if logger != svc.log {
+		t.Error("expected one logger per scope")
+	}
+}
+
+func TestScopedLoggerWithoutValues(t *testing.T) {
+	base := slog.Default()
+
+	c := di.New()
+	di.RegisterScopedLogger(c, base, di.LogField{Key: tenantLogKey{}, Attr: "tenant"})
+
+	if logger := di.MustResolve[*slog.Logger](c); logger != base {
+		t.Error("expected base logger outside of a scope")
+	}
+
+	scope := c.CreateScope("request-2")
+	if logger, _ := di.ResolveInScope[*slog.Logger](c, scope); logger != base {
+		t.Error("expected base logger when the scope has no values")
+	}
+}
+
+func TestFactoryReceivesScope(t *testing.T) {
+	c := di.New()
+	di.Register[string](c, func(scope *di.Scope) string {
+		if scope == nil {
+			return "none"
+		}
+		return scope.Name()
+	}, di.AsScoped())
+
+	if name, _ := di.ResolveInScope[string](c, c.CreateScope("outer")); name != "outer" {
+		t.Errorf("expected factory to receive the scope, got %q", name)
+	}
+	if name := di.MustResolve[string](c); name != "none" {
+		t.Errorf("expected nil scope outside of a scope, got %q", name)
+	}
+
+	if deps := c.Registrations()[0].Dependencies; len(deps) != 0 {
+		t.Errorf("expected *Scope not to be reported as a dependency, got %v", deps)
+	}
+}
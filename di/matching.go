@@ -0,0 +1,58 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+)
+
+// ResolveMatching resolves every named registration of T whose name matches
+// pattern, returning the instances keyed by name.
+//
+// The pattern uses the syntax of [path.Match], where '*' matches any sequence
+// of characters other than '/'. This suits names that encode a hierarchy, such
+// as regions or shards: "cache.*" matches "cache.eu" and "cache.us", and
+// "shard-?" matches "shard-1". Unnamed registrations never match, and names
+// served by [RegisterTemplate] only match once they have been requested.
+//
+// Instances are resolved in the order of [Container.Registrations] and honor
+// their lifetimes. Resolution stops at the first error. A pattern that matches
+// nothing returns an empty map.
+//
+// Returns [ErrResolutionFailed] if pattern is malformed.
+//
+// Example:
+//
+//	caches, err := di.ResolveMatching[Cache](container, "cache.*")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for region, cache := range caches {
+//	    warm(region, cache)
+//	}
+func ResolveMatching[T any](c *Container, pattern string) (map[string]T, error) {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, ErrResolutionFailed{Type: targetType, Cause: fmt.Errorf("pattern %q: %w", pattern, err)}
+	}
+
+	results := make(map[string]T)
+	for _, reg := range c.orderedRegistrations() {
+		if reg.targetType != targetType || reg.name == "" {
+			continue
+		}
+		if ok, _ := path.Match(pattern, reg.name); !ok {
+			continue
+		}
+
+		instance, err := c.resolve(context.Background(), targetType, reg.name, nil, make([]reflect.Type, 0))
+		if err != nil {
+			return nil, err
+		}
+		results[reg.name] = instance.(T)
+	}
+	return results, nil
+}
@@ -0,0 +1,50 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Pattern Resolution Tests
+// =============================================================================
+
+func TestResolveMatching(t *testing.T) {
+	c := di.New()
+	for _, name := range []string{"cache.eu", "cache.us", "store.eu"} {
+		di.RegisterInstance[Logger](c, &TestLogger{}, di.WithName(name))
+	}
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	loggers, err := di.ResolveMatching[Logger](c, "cache.*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loggers) != 2 || loggers["cache.eu"] == nil || loggers["cache.us"] == nil {
+		t.Errorf("expected the cache loggers, got %v", loggers)
+	}
+
+	none, err := di.ResolveMatching[Logger](c, "queue.*")
+	if err != nil || len(none) != 0 {
+		t.Errorf("expected empty result, got %v, %v", none, err)
+	}
+}
+
+func TestResolveMatchingErrors(t *testing.T) {
+	c := di.New()
+	boom := errors.New("boom")
+	di.Register[Logger](c, func() (Logger, error) { return nil, boom }, di.WithName("cache.eu"))
+
+	if _, err := di.ResolveMatching[Logger](c, "cache.*"); !errors.Is(err, boom) {
+		t.Errorf("expected factory error, got %v", err)
+	}
+
+	var failed di.ErrResolutionFailed
+	if _, err := di.ResolveMatching[Logger](c, "cache.["); !errors.As(err, &failed) {
+		t.Errorf("expected ErrResolutionFailed for bad pattern, got %v", err)
+	}
+}
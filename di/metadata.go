@@ -0,0 +1,41 @@
+package di
+
+import "reflect"
+
+// Metadata describes the registration whose instance is being constructed.
+//
+// A factory that declares a Metadata parameter receives it instead of a
+// resolved dependency. This lets one generic factory configure instances
+// differently per named registration without duplicating code. Decorators
+// (see [DecorateWhen]) receive the metadata of the registration they decorate.
+//
+// Example:
+//
+//	newClient := func(meta di.Metadata, cfg *Config) *http.Client {
+//	    return &http.Client{Timeout: cfg.Timeouts[meta.Name]}
+//	}
+//	di.Register[*http.Client](c, newClient, di.WithName("payments"), di.AsSingleton())
+//	di.Register[*http.Client](c, newClient, di.WithName("search"), di.AsSingleton())
+type Metadata struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Lifetime is the registration's lifetime.
+	Lifetime Lifetime
+	// Tags are the labels attached with [WithTags].
+	Tags []string
+}
+
+// metadataType is the reflect.Type of Metadata.
+var metadataType = reflect.TypeOf(Metadata{})
+
+// metadata describes the registration for its factory.
+func (r *registration) metadata() Metadata {
+	return Metadata{
+		Type:     r.targetType,
+		Name:     r.name,
+		Lifetime: r.lifetime,
+		Tags:     append([]string(nil), r.tags...),
+	}
+}
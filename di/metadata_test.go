@@ -0,0 +1,43 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Metadata Injection Tests
+// =============================================================================
+
+type namedGreeter struct{ meta di.Metadata }
+
+func (g *namedGreeter) Greet(name string) string { return g.meta.Name + ": " + name }
+
+func TestFactoryReceivesMetadata(t *testing.T) {
+	c := di.New()
+	newGreeter := func(meta di.Metadata) Greeter { return &namedGreeter{meta: meta} }
+	di.Register[Greeter](c, newGreeter, di.WithName("english"), di.AsSingleton(), di.WithTags("i18n"))
+	di.Register[Greeter](c, newGreeter, di.WithName("french"))
+
+	english := di.MustResolveNamed[Greeter](c, "english").(*namedGreeter)
+	if english.meta.Name != "english" || english.meta.Lifetime != di.Singleton ||
+		len(english.meta.Tags) != 1 || english.meta.Tags[0] != "i18n" {
+		t.Errorf("unexpected metadata: %+v", english.meta)
+	}
+	if got := di.MustResolveNamed[Greeter](c, "french").Greet("Ada"); got != "french: Ada" {
+		t.Errorf("expected french greeter, got %q", got)
+	}
+}
+
+func TestMetadataIsNotADependency(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func(meta di.Metadata, logger Logger) Greeter { return &namedGreeter{meta: meta} })
+
+	deps := c.Registrations()[0].Dependencies
+	if len(deps) != 1 {
+		t.Errorf("expected only Logger as a dependency, got %v", deps)
+	}
+}
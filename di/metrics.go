@@ -0,0 +1,234 @@
+package di
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Handler returns an http.Handler that exposes the container's statistics.
+//
+// By default the handler responds with the same JSON document produced by
+// marshaling [Stats], which is suitable for expvar-style scraping. Requests with
+// ?format=prometheus, or whose Accept header asks for text/plain or OpenMetrics,
+// receive the Prometheus text exposition format instead.
+//
+// The handler has no dependencies outside the standard library.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/debug/di", di.Handler(container))
+//
+//	// Prometheus scrape config:
+//	//   metrics_path: /debug/di
+//	//   params: { format: [prometheus] }
+func Handler(c *Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stats := c.Stats()
+
+		if wantsPrometheus(r) {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+			_ = writePrometheus(w, stats)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(w).Encode(stats)
+	})
+}
+
+// MarshalJSON encodes the statistics as a JSON object with snake_case keys and
+// durations in seconds.
+//
+// This makes a container easy to publish through the expvar package:
+//
+//	expvar.Publish("di", expvar.Func(func() any { return container.Stats() }))
+func (s Stats) MarshalJSON() ([]byte, error) {
+	type registrationJSON struct {
+		Type                       string  `json:"type"`
+		Name                       string  `json:"name"`
+		Lifetime                   string  `json:"lifetime"`
+		Resolutions                uint64  `json:"resolutions"`
+		CacheHits                  uint64  `json:"cache_hits"`
+		Constructions              uint64  `json:"constructions"`
+		Errors                     uint64  `json:"errors"`
+		FactoryDurationSeconds     float64 `json:"factory_duration_seconds"`
+		MaxFactoryDurationSeconds  float64 `json:"max_factory_duration_seconds"`
+		HitRatio                   float64 `json:"hit_ratio"`
+		ConstructionsLastMinute    uint64  `json:"constructions_last_minute"`
+		ConstructionsLastHour      uint64  `json:"constructions_last_hour"`
+		ConstructionErrors         uint64  `json:"construction_errors"`
+		ConstructionErrorsLastHour uint64  `json:"construction_errors_last_hour"`
+	}
+
+	regs := make([]registrationJSON, len(s.Registrations))
+	for i, r := range s.Registrations {
+		regs[i] = registrationJSON{
+			Type:                       r.Type.String(),
+			Name:                       r.Name,
+			Lifetime:                   r.Lifetime.String(),
+			Resolutions:                r.Resolutions,
+			CacheHits:                  r.CacheHits,
+			Constructions:              r.Constructions,
+			Errors:                     r.Errors,
+			FactoryDurationSeconds:     r.TotalFactoryDuration.Seconds(),
+			MaxFactoryDurationSeconds:  r.MaxFactoryDuration.Seconds(),
+			HitRatio:                   r.HitRatio(),
+			ConstructionsLastMinute:    r.ConstructionsLastMinute,
+			ConstructionsLastHour:      r.ConstructionsLastHour,
+			ConstructionErrors:         r.ConstructionErrors,
+			ConstructionErrorsLastHour: r.ConstructionErrorsLastHour,
+		}
+	}
+
+	type lifetimeJSON struct {
+		Lifetime                string  `json:"lifetime"`
+		Registrations           int     `json:"registrations"`
+		Resolutions             uint64  `json:"resolutions"`
+		CacheHits               uint64  `json:"cache_hits"`
+		Constructions           uint64  `json:"constructions"`
+		Errors                  uint64  `json:"errors"`
+		HitRatio                float64 `json:"hit_ratio"`
+		ConstructionsLastMinute uint64  `json:"constructions_last_minute"`
+		ConstructionsLastHour   uint64  `json:"constructions_last_hour"`
+	}
+
+	lifetimes := make([]lifetimeJSON, len(s.Lifetimes))
+	for i, l := range s.Lifetimes {
+		lifetimes[i] = lifetimeJSON{
+			Lifetime:                l.Lifetime.String(),
+			Registrations:           l.Registrations,
+			Resolutions:             l.Resolutions,
+			CacheHits:               l.CacheHits,
+			Constructions:           l.Constructions,
+			Errors:                  l.Errors,
+			HitRatio:                l.HitRatio(),
+			ConstructionsLastMinute: l.ConstructionsLastMinute,
+			ConstructionsLastHour:   l.ConstructionsLastHour,
+		}
+	}
+
+	type serviceJSON struct {
+		Type     string `json:"type"`
+		Name     string `json:"name"`
+		State    string `json:"state"`
+		Restarts uint64 `json:"restarts"`
+	}
+
+	services := make([]serviceJSON, len(s.Services))
+	for i, svc := range s.Services {
+		services[i] = serviceJSON{
+			Type:     svc.Type.String(),
+			Name:     svc.Name,
+			State:    string(svc.State),
+			Restarts: svc.Restarts,
+		}
+	}
+
+	return json.Marshal(struct {
+		Resolutions            uint64             `json:"resolutions"`
+		CacheHits              uint64             `json:"cache_hits"`
+		Constructions          uint64             `json:"constructions"`
+		Errors                 uint64             `json:"errors"`
+		ConstructionErrors     uint64             `json:"construction_errors"`
+		FactoryDurationSeconds float64            `json:"factory_duration_seconds"`
+		HitRatio               float64            `json:"hit_ratio"`
+		Lifetimes              []lifetimeJSON     `json:"lifetimes"`
+		Registrations          []registrationJSON `json:"registrations"`
+		Services               []serviceJSON      `json:"services"`
+	}{
+		Resolutions:            s.Resolutions,
+		CacheHits:              s.CacheHits,
+		Constructions:          s.Constructions,
+		Errors:                 s.Errors,
+		ConstructionErrors:     s.ConstructionErrors,
+		FactoryDurationSeconds: s.FactoryDuration.Seconds(),
+		HitRatio:               s.HitRatio(),
+		Lifetimes:              lifetimes,
+		Registrations:          regs,
+		Services:               services,
+	})
+}
+
+// wantsPrometheus reports whether the request asks for the Prometheus text format.
+func wantsPrometheus(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format == "prometheus"
+	}
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "text/plain") || strings.Contains(accept, "openmetrics")
+}
+
+// writePrometheus writes the statistics in the Prometheus text exposition format.
+func writePrometheus(w io.Writer, stats Stats) error {
+	var b strings.Builder
+
+	counter := func(name, help string, value func(RegistrationStats) string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+		for _, r := range stats.Registrations {
+			fmt.Fprintf(&b, "%s{%s} %s\n", name, prometheusLabels(r), value(r))
+		}
+	}
+
+	counter("di_resolutions_total", "Total number of resolutions per registration.",
+		func(r RegistrationStats) string { return fmt.Sprint(r.Resolutions) })
+	counter("di_cache_hits_total", "Resolutions served without invoking the factory.",
+		func(r RegistrationStats) string { return fmt.Sprint(r.CacheHits) })
+	counter("di_constructions_total", "Successful factory invocations.",
+		func(r RegistrationStats) string { return fmt.Sprint(r.Constructions) })
+	counter("di_resolution_errors_total", "Failed resolutions per registration.",
+		func(r RegistrationStats) string { return fmt.Sprint(r.Errors) })
+	counter("di_construction_errors_total", "Failures of the registration's own factory or validators.",
+		func(r RegistrationStats) string { return fmt.Sprint(r.ConstructionErrors) })
+
+	var registeredErrors uint64
+	for _, r := range stats.Registrations {
+		registeredErrors += r.Errors
+	}
+	fmt.Fprintf(&b, "# HELP di_unregistered_resolutions_total Attempts to resolve unregistered types.\n")
+	fmt.Fprintf(&b, "# TYPE di_unregistered_resolutions_total counter\n")
+	fmt.Fprintf(&b, "di_unregistered_resolutions_total %d\n", stats.Errors-registeredErrors)
+
+	fmt.Fprintf(&b, "# HELP di_factory_duration_seconds Time spent constructing instances.\n")
+	fmt.Fprintf(&b, "# TYPE di_factory_duration_seconds summary\n")
+	for _, r := range stats.Registrations {
+		labels := prometheusLabels(r)
+		fmt.Fprintf(&b, "di_factory_duration_seconds_sum{%s} %g\n", labels, r.TotalFactoryDuration.Seconds())
+		fmt.Fprintf(&b, "di_factory_duration_seconds_count{%s} %d\n", labels, r.Constructions)
+	}
+
+	fmt.Fprintf(&b, "# HELP di_factory_duration_seconds_max Slowest single construction.\n")
+	fmt.Fprintf(&b, "# TYPE di_factory_duration_seconds_max gauge\n")
+	for _, r := range stats.Registrations {
+		fmt.Fprintf(&b, "di_factory_duration_seconds_max{%s} %g\n", prometheusLabels(r), r.MaxFactoryDuration.Seconds())
+	}
+
+	fmt.Fprintf(&b, "# HELP di_constructions_last_hour Factory invocations in the last hour.\n")
+	fmt.Fprintf(&b, "# TYPE di_constructions_last_hour gauge\n")
+	for _, r := range stats.Registrations {
+		fmt.Fprintf(&b, "di_constructions_last_hour{%s} %d\n", prometheusLabels(r), r.ConstructionsLastHour)
+	}
+
+	fmt.Fprintf(&b, "# HELP di_lifetime_cache_hit_ratio Fraction of resolutions served from a cache per lifetime.\n")
+	fmt.Fprintf(&b, "# TYPE di_lifetime_cache_hit_ratio gauge\n")
+	for _, l := range stats.Lifetimes {
+		fmt.Fprintf(&b, "di_lifetime_cache_hit_ratio{lifetime=\"%s\"} %g\n", l.Lifetime, l.HitRatio())
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// prometheusLabels renders the identifying labels for a registration.
+func prometheusLabels(r RegistrationStats) string {
+	return fmt.Sprintf(`type="%s",name="%s",lifetime="%s"`,
+		escapeLabel(r.Type.String()), escapeLabel(r.Name), r.Lifetime)
+}
+
+// escapeLabel escapes a Prometheus label value.
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,174 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Module is a unit of registrations contributed by a feature area or library.
+//
+// A module registers its services on the container it is given and returns
+// the first registration error.
+//
+// Example:
+//
+//	func BillingModule(c *di.Container) error {
+//	    if err := di.Register[*InvoiceService](c, NewInvoiceService, di.AsSingleton()); err != nil {
+//	        return err
+//	    }
+//	    return di.Register[*PaymentGateway](c, NewPaymentGateway, di.AsSingleton())
+//	}
+type Module func(c *Container) error
+
+// Apply applies modules to the container in order, stopping at the first
+// error.
+func (c *Container) Apply(modules ...Module) error {
+	for _, module := range modules {
+		if err := module(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Ensure returns c, or a new container if c is nil.
+//
+// It supports the accept-or-create convention for libraries: a library's
+// constructor accepts an optional container, so applications that use
+// dependency injection share theirs while others pass nil. Combine it with
+// [Contribute] to register the library's services.
+//
+// Example:
+//
+//	func NewClient(c *di.Container) (*Client, error) {
+//	    c = di.Ensure(c)
+//	    // ...
+//	}
+func Ensure(c *Container) *Container {
+	if c == nil {
+		return New()
+	}
+	return c
+}
+
+// Contribute applies a library's module to c, creating the container if c is
+// nil (see [Ensure]), and returns the container.
+//
+// The module is applied at most once per container under the given name,
+// conventionally the library's import path, so libraries that build on each
+// other can each contribute their dependencies without registering them
+// twice. A module that fails is not recorded and can be contributed again.
+//
+// Example:
+//
+//	// In package github.com/acme/billing:
+//	func Provide(c *di.Container) (*di.Container, error) {
+//	    return di.Contribute(c, "github.com/acme/billing", func(c *di.Container) error {
+//	        if _, err := payments.Provide(c); err != nil {
+//	            return err
+//	        }
+//	        return di.Register[*InvoiceService](c, NewInvoiceService, di.AsSingleton())
+//	    })
+//	}
+//
+//	// In the application:
+//	c, err := billing.Provide(container)
+func Contribute(c *Container, name string, module Module) (*Container, error) {
+	c = Ensure(c)
+
+	c.mu.Lock()
+	if c.contributed[name] {
+		c.mu.Unlock()
+		return c, nil
+	}
+	c.contributed[name] = true
+	c.mu.Unlock()
+
+	if err := module(c); err != nil {
+		c.mu.Lock()
+		delete(c.contributed, name)
+		c.mu.Unlock()
+		return c, fmt.Errorf("di: contributing %s: %w", name, err)
+	}
+	return c, nil
+}
+
+// lazyModule is a module applied on first demand by ApplyLazy.
+type lazyModule struct {
+	module   Module
+	triggers []reflect.Type
+	once     sync.Once
+	err      error
+}
+
+// ApplyLazy attaches module to the container without applying it. The module
+// is applied the first time one of the trigger types is resolved, directly or
+// as a dependency, and then behaves as if it had been applied with
+// [Container.Apply].
+//
+// This keeps startup fast for rarely used feature areas. Until the module is
+// applied, its registrations are invisible to [Has], [Container.Registrations],
+// and [Container.Start], so trigger on the types the rest of the application
+// resolves to reach the feature.
+//
+// If the module fails, the triggering resolution fails with
+// [ErrResolutionFailed] wrapping its error, and so do later resolutions of
+// its triggers. Returns an error if no triggers are given.
+//
+// Example:
+//
+//	c.ApplyLazy(ReportingModule, reflect.TypeOf((*ReportService)(nil)).Elem())
+func (c *Container) ApplyLazy(module Module, triggers ...reflect.Type) error {
+	if len(triggers) == 0 {
+		return fmt.Errorf("di: lazy module needs at least one trigger type")
+	}
+
+	lazy := &lazyModule{module: module, triggers: triggers}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, trigger := range triggers {
+		c.lazy[trigger] = append(c.lazy[trigger], lazy)
+	}
+	return nil
+}
+
+// loadLazy applies the lazy modules triggered by typ. The caller must not hold
+// c.mu.
+func (c *Container) loadLazy(typ reflect.Type, modules []*lazyModule) error {
+	for _, lazy := range modules {
+		lazy.once.Do(func() {
+			lazy.err = lazy.module(c)
+			if lazy.err == nil {
+				c.forgetLazy(lazy)
+			}
+		})
+		if lazy.err != nil {
+			return ErrResolutionFailed{Type: typ, Cause: fmt.Errorf("lazy module: %w", lazy.err)}
+		}
+	}
+	return nil
+}
+
+// forgetLazy removes an applied module from the triggers map.
+func (c *Container) forgetLazy(lazy *lazyModule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, trigger := range lazy.triggers {
+		modules := c.lazy[trigger]
+		for i, m := range modules {
+			if m == lazy {
+				modules = append(modules[:i:i], modules[i+1:]...)
+				break
+			}
+		}
+		if len(modules) == 0 {
+			delete(c.lazy, trigger)
+		} else {
+			c.lazy[trigger] = modules
+		}
+	}
+}
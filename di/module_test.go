@@ -0,0 +1,175 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Module Tests
+// =============================================================================
+
+func greeterModule(calls *int) di.Module {
+	return func(c *di.Container) error {
+		*calls++
+		return di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsSingleton())
+	}
+}
+
+func TestApply(t *testing.T) {
+	c := di.New()
+	calls := 0
+	if err := c.Apply(greeterModule(&calls)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !di.Has[Greeter](c) || calls != 1 {
+		t.Errorf("expected module applied once, got %d calls", calls)
+	}
+
+	failing := errors.New("boom")
+	if err := c.Apply(func(*di.Container) error { return failing }); !errors.Is(err, failing) {
+		t.Errorf("expected module error, got %v", err)
+	}
+}
+
+func TestApplyLazyDefersUntilTriggered(t *testing.T) {
+	c := di.New()
+	calls := 0
+	greeterType := reflect.TypeOf((*Greeter)(nil)).Elem()
+	if err := c.ApplyLazy(greeterModule(&calls), greeterType); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	di.Register[Logger](c, func() Logger { return &TestLogger{} })
+
+	di.MustResolve[Logger](c)
+	if calls != 0 || di.Has[Greeter](c) {
+		t.Fatal("expected module not applied before its trigger is resolved")
+	}
+
+	if got := di.MustResolve[Greeter](c).Greet("World"); got != "Hello, World" {
+		t.Errorf("unexpected greeting %q", got)
+	}
+	di.MustResolve[Greeter](c)
+	if calls != 1 {
+		t.Errorf("expected module applied once, got %d calls", calls)
+	}
+}
+
+func TestApplyLazyTriggeredAsDependency(t *testing.T) {
+	c := di.New()
+	calls := 0
+	c.ApplyLazy(greeterModule(&calls), reflect.TypeOf((*Greeter)(nil)).Elem())
+	di.Register[Service](c, func(g Greeter) Service { return &DefaultService{greeter: g} })
+
+	if _, err := di.Resolve[Service](c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected module applied once, got %d calls", calls)
+	}
+}
+
+func TestApplyLazyConcurrentTrigger(t *testing.T) {
+	c := di.New()
+	var mu sync.Mutex
+	calls := 0
+	c.ApplyLazy(func(c *di.Container) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	}, reflect.TypeOf((*Greeter)(nil)).Elem())
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := di.Resolve[Greeter](c); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("expected module applied once, got %d calls", calls)
+	}
+}
+
+func TestApplyLazyModuleError(t *testing.T) {
+	c := di.New()
+	failing := errors.New("feature disabled")
+	c.ApplyLazy(func(*di.Container) error { return failing }, reflect.TypeOf((*Greeter)(nil)).Elem())
+
+	for range 2 {
+		_, err := di.Resolve[Greeter](c)
+		var resErr di.ErrResolutionFailed
+		if !errors.As(err, &resErr) || !errors.Is(err, failing) {
+			t.Errorf("expected ErrResolutionFailed wrapping the module error, got %v", err)
+		}
+	}
+}
+
+func TestApplyLazyRequiresTriggers(t *testing.T) {
+	c := di.New()
+	calls := 0
+	if err := c.ApplyLazy(greeterModule(&calls)); err == nil {
+		t.Error("expected error for a lazy module without triggers")
+	}
+}
+
+// =============================================================================
+// Library Composition Tests
+// =============================================================================
+
+func TestEnsure(t *testing.T) {
+	if di.Ensure(nil) == nil {
+		t.Fatal("expected a new container for nil")
+	}
+	c := di.New()
+	if di.Ensure(c) != c {
+		t.Error("expected the given container to be returned")
+	}
+}
+
+func TestContributeAppliesModuleOnce(t *testing.T) {
+	calls := 0
+	provide := func(c *di.Container) (*di.Container, error) {
+		return di.Contribute(c, "example.com/greeting", greeterModule(&calls))
+	}
+
+	c, err := provide(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := provide(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the module to be applied once, got %d", calls)
+	}
+	if !di.Has[Greeter](c) {
+		t.Error("expected the module's registrations on the returned container")
+	}
+}
+
+func TestContributeRetriesFailedModule(t *testing.T) {
+	c := di.New()
+	boom := errors.New("boom")
+
+	_, err := di.Contribute(c, "example.com/broken", func(*di.Container) error { return boom })
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected the module error, got %v", err)
+	}
+
+	calls := 0
+	if _, err := di.Contribute(c, "example.com/broken", greeterModule(&calls)); err != nil || calls != 1 {
+		t.Errorf("expected a failed module to be contributed again, got %v after %d calls", err, calls)
+	}
+}
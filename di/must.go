@@ -0,0 +1,211 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions caps the nearest-match suggestions in a [ResolutionPanic].
+const maxSuggestions = 3
+
+// ResolutionPanic is the value [MustResolve] and [MustResolveNamed] panic with.
+//
+// Its message is the resolution error followed by diagnostics gathered from
+// the container at the time of the failure: the dependency chain that led to
+// the failing type, the registrations of the requested type, and registrations
+// whose type or name is close to the one that was missing. It wraps the
+// resolution error, so a recovered value can be inspected with [errors.As].
+//
+// Example:
+//
+//	defer func() {
+//	    if r := recover(); r != nil {
+//	        var p di.ResolutionPanic
+//	        if err, ok := r.(error); ok && errors.As(err, &p) {
+//	            log.Printf("wiring failed at %v", p.Chain)
+//	        }
+//	        panic(r)
+//	    }
+//	}()
+type ResolutionPanic struct {
+	// Err is the resolution error.
+	Err error
+	// Chain is the dependency path from the requested type to the type that
+	// failed.
+	Chain []reflect.Type
+	// Registrations are the registrations of the requested type.
+	Registrations []RegistrationInfo
+	// Suggestions describe registrations close to the missing one, nearest
+	// first.
+	Suggestions []string
+}
+
+func (p ResolutionPanic) Error() string {
+	var b strings.Builder
+	b.WriteString(p.Err.Error())
+
+	if len(p.Chain) > 1 {
+		names := make([]string, len(p.Chain))
+		for i, t := range p.Chain {
+			names[i] = t.String()
+		}
+		fmt.Fprintf(&b, "\n\nresolution chain:\n  %s", strings.Join(names, " -> "))
+	}
+
+	if len(p.Chain) > 0 {
+		fmt.Fprintf(&b, "\n\nregistrations of %s:", p.Chain[0])
+		if len(p.Registrations) == 0 {
+			b.WriteString("\n  (none)")
+		}
+		for _, info := range p.Registrations {
+			name := "(unnamed)"
+			if info.Name != "" {
+				name = fmt.Sprintf("%q", info.Name)
+			}
+			fmt.Fprintf(&b, "\n  %s %s", name, info.Lifetime)
+		}
+	}
+
+	if len(p.Suggestions) > 0 {
+		fmt.Fprintf(&b, "\n\ndid you mean:\n  %s", strings.Join(p.Suggestions, "\n  "))
+	}
+	return b.String()
+}
+
+// Unwrap returns the resolution error.
+func (p ResolutionPanic) Unwrap() error {
+	return p.Err
+}
+
+// resolutionPanic builds the diagnostics for a failed resolution of typ and
+// name.
+func (c *Container) resolutionPanic(err error, typ reflect.Type, name string) ResolutionPanic {
+	regs := c.orderedRegistrations()
+	p := ResolutionPanic{Err: err, Chain: failureChain(err, typ)}
+
+	for _, reg := range regs {
+		if reg.targetType == typ {
+			p.Registrations = append(p.Registrations, reg.info())
+		}
+	}
+
+	var missing ErrNotRegistered
+	if errors.As(err, &missing) {
+		p.Suggestions = suggestRegistrations(regs, missing.Type, missing.Name)
+	}
+	return p
+}
+
+// failureChain follows the nested resolution errors in err from typ down to
+// the type that failed.
+func failureChain(err error, typ reflect.Type) []reflect.Type {
+	chain := []reflect.Type{typ}
+	add := func(t reflect.Type) {
+		if t != nil && chain[len(chain)-1] != t {
+			chain = append(chain, t)
+		}
+	}
+
+	for err != nil {
+		switch e := err.(type) {
+		case ErrResolutionFailed:
+			add(e.Type)
+			err = e.Cause
+		case ErrNotRegistered:
+			add(e.Type)
+			return chain
+		case ErrCircularDependency:
+			for _, t := range e.Chain {
+				add(t)
+			}
+			return chain
+		default:
+			err = errors.Unwrap(err)
+		}
+	}
+	return chain
+}
+
+// suggestRegistrations returns descriptions of the registrations nearest to a
+// missing type and name: other names of the same type, and types whose name
+// is close or that differ only by a pointer.
+func suggestRegistrations(regs []*registration, typ reflect.Type, name string) []string {
+	type candidate struct {
+		description string
+		distance    int
+	}
+	var candidates []candidate
+	seen := make(map[string]bool)
+	consider := func(reg *registration, distance int) {
+		description := describeRegistration(reg.targetType, reg.name)
+		if !seen[description] {
+			seen[description] = true
+			candidates = append(candidates, candidate{description, distance})
+		}
+	}
+
+	for _, reg := range regs {
+		if reg.targetType == typ {
+			if reg.name == name {
+				continue
+			}
+			if d := editDistance(reg.name, name); d <= closeEnough(name) {
+				consider(reg, d)
+			}
+			continue
+		}
+		if reg.name != name {
+			continue
+		}
+		switch {
+		case reg.targetType == reflect.PointerTo(typ),
+			typ.Kind() == reflect.Pointer && reg.targetType == typ.Elem():
+			consider(reg, 0)
+		case reg.targetType.Name() != "" && reg.targetType.Name() == typ.Name():
+			consider(reg, 1)
+		default:
+			if d := editDistance(reg.targetType.String(), typ.String()); d <= closeEnough(typ.String()) {
+				consider(reg, d)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	suggestions := make([]string, 0, min(len(candidates), maxSuggestions))
+	for _, cand := range candidates[:min(len(candidates), maxSuggestions)] {
+		suggestions = append(suggestions, cand.description)
+	}
+	return suggestions
+}
+
+// closeEnough returns the largest edit distance at which s is considered a
+// likely misspelling.
+func closeEnough(s string) int {
+	return max(2, len(s)/4)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
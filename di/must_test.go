@@ -0,0 +1,88 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Resolution Panic Diagnostics Tests
+// =============================================================================
+
+func recoverResolutionPanic(t *testing.T, fn func()) di.ResolutionPanic {
+	t.Helper()
+	var p di.ResolutionPanic
+	func() {
+		defer func() {
+			err, ok := recover().(error)
+			if !ok || !errors.As(err, &p) {
+				t.Fatalf("expected a ResolutionPanic, got %v", err)
+			}
+		}()
+		fn()
+	}()
+	return p
+}
+
+func TestMustResolvePanicChain(t *testing.T) {
+	c := di.New()
+	di.Register[Service](c, func(g Greeter) Service { return &DefaultService{greeter: g} }, di.WithName("primary"))
+	di.Register[Service](c, func(g Greeter) Service { return &DefaultService{greeter: g} })
+
+	p := recoverResolutionPanic(t, func() { di.MustResolve[Service](c) })
+
+	serviceType := reflect.TypeOf((*Service)(nil)).Elem()
+	greeterType := reflect.TypeOf((*Greeter)(nil)).Elem()
+	if len(p.Chain) != 2 || p.Chain[0] != serviceType || p.Chain[1] != greeterType {
+		t.Errorf("expected chain Service -> Greeter, got %v", p.Chain)
+	}
+	if len(p.Registrations) != 2 {
+		t.Errorf("expected both Service registrations, got %+v", p.Registrations)
+	}
+	var notRegistered di.ErrNotRegistered
+	if !errors.As(p, &notRegistered) {
+		t.Error("expected the panic to wrap ErrNotRegistered")
+	}
+
+	msg := p.Error()
+	for _, want := range []string{"di_test.Service -> di_test.Greeter", `"primary" Transient`, "(unnamed) Transient"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected panic message to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestMustResolveNamedPanicSuggestsNames(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("console"))
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("syslog"))
+
+	p := recoverResolutionPanic(t, func() { di.MustResolveNamed[Greeter](c, "consle") })
+
+	if len(p.Suggestions) != 1 || !strings.Contains(p.Suggestions[0], `"console"`) {
+		t.Errorf("expected console to be suggested, got %v", p.Suggestions)
+	}
+	if !strings.Contains(p.Error(), "did you mean:") {
+		t.Errorf("expected suggestions in the panic message, got:\n%s", p.Error())
+	}
+}
+
+func TestMustResolvePanicSuggestsPointerForm(t *testing.T) {
+	c := di.New()
+	di.Register[*SimpleGreeter](c, func() *SimpleGreeter { return &SimpleGreeter{} })
+
+	p := recoverResolutionPanic(t, func() { di.MustResolve[SimpleGreeter](c) })
+
+	if len(p.Suggestions) != 1 || p.Suggestions[0] != "*di_test.SimpleGreeter" {
+		t.Errorf("expected the pointer form to be suggested, got %v", p.Suggestions)
+	}
+	if !strings.Contains(p.Error(), "registrations of di_test.SimpleGreeter:\n  (none)") {
+		t.Errorf("expected an empty registration table, got:\n%s", p.Error())
+	}
+}
@@ -0,0 +1,61 @@
+package di
+
+// NameResolver maps a requested registration name to the name that is looked
+// up, so naming conventions such as environment-suffixed names can be applied
+// in one place instead of at every call site (see [WithNameResolver]).
+type NameResolver func(req NameRequest) string
+
+// NameRequest describes a lookup for a [NameResolver]. The embedded
+// [SelectionContext] gives access to the resolution context, the type, the
+// scope, and the values of the scope and context.
+type NameRequest struct {
+	SelectionContext
+	// Name is the requested name, or "" for unnamed requests.
+	Name string
+}
+
+// WithNameResolver installs a container-wide hook that rewrites registration
+// names before every lookup, including unnamed lookups and the lookups of
+// factory parameters.
+//
+// If no registration or alias exists under the returned name, the requested
+// name is used, so a resolver can map to a more specific name where one is
+// registered and fall back to the plain name otherwise. Selectors (see
+// [RegisterSelector]) run afterwards for requests that still have no name.
+//
+// Example:
+//
+//	env := os.Getenv("APP_ENV")
+//	c := di.New(di.WithNameResolver(func(req di.NameRequest) string {
+//	    if req.Name == "" {
+//	        return env
+//	    }
+//	    return req.Name + "-" + env
+//	}))
+//
+//	di.Register[*sql.DB](c, openProdDB, di.WithName("reports-prod"))
+//	db, _ := di.ResolveNamed[*sql.DB](c, "reports") // reports-prod when APP_ENV=prod
+func WithNameResolver(resolver NameResolver) ContainerOption {
+	return func(c *Container) {
+		c.nameResolver = resolver
+	}
+}
+
+// resolveName applies the name resolver to req, keeping the requested name
+// unless the resolved one is registered.
+func (c *Container) resolveName(req NameRequest) string {
+	name := c.nameResolver(req)
+	if name == req.Name {
+		return name
+	}
+
+	key := registrationKey{typ: req.Type, name: name}
+	c.mu.RLock()
+	_, registered := c.registrations[key]
+	_, aliased := c.aliases[key]
+	c.mu.RUnlock()
+	if registered || aliased {
+		return name
+	}
+	return req.Name
+}
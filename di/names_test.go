@@ -0,0 +1,42 @@
+package di_test
+
+import (
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Name Resolver Tests
+// =============================================================================
+
+func TestNameResolver(t *testing.T) {
+	c := di.New(di.WithNameResolver(func(req di.NameRequest) string {
+		tenant, _ := req.Value(tenantKey{}).(string)
+		if req.Name == "" {
+			return tenant
+		}
+		return req.Name + "-" + tenant
+	}))
+	plain := &TestLogger{}
+	acme := &TestLogger{}
+	audit := &TestLogger{}
+	di.RegisterInstance[Logger](c, plain)
+	di.RegisterInstance[Logger](c, acme, di.WithName("acme"))
+	di.RegisterInstance[Logger](c, audit, di.WithName("audit-acme"))
+	di.RegisterInstance[Logger](c, &TestLogger{}, di.WithName("audit"))
+
+	scope := c.CreateScope("request")
+	scope.SetValue(tenantKey{}, "acme")
+	if logger, _ := di.ResolveInScope[Logger](c, scope); logger != acme {
+		t.Error("expected the unnamed request to resolve to the tenant's name")
+	}
+	if logger, _ := di.Resolve[Logger](c); logger != plain {
+		t.Error("expected an unregistered resolved name to fall back to the requested one")
+	}
+
+	c.SetDefaultScope(scope)
+	if logger, _ := di.ResolveNamed[Logger](c, "audit"); logger != audit {
+		t.Error("expected the named request to resolve to the suffixed name")
+	}
+}
@@ -0,0 +1,104 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// WithPointerNormalization lets requests for a struct type and a pointer to it
+// be satisfied by a registration of the other form, instead of failing with
+// [ErrNotRegistered].
+//
+// The rules are applied only when the requested type itself has no
+// registration under the requested name, and the counterpart is looked up
+// under the same name:
+//   - A request for T is satisfied by a registration of *T. The result is a
+//     shallow copy of the pointed-to value, so later changes through the
+//     pointer are not seen. A nil *T fails with [ErrResolutionFailed].
+//   - A request for *T is satisfied by a registration of T only if T is
+//     value-safe: built solely from booleans, numbers, strings, and arrays and
+//     structs of those. Each resolution returns a pointer to a fresh copy, so
+//     writes through it never reach the registered value.
+//   - Interfaces and pointers to pointers or interfaces are never normalized.
+//
+// [Has] and [HasNamed] report only exact registrations.
+//
+// Example:
+//
+//	c := di.New(di.WithPointerNormalization())
+//	di.RegisterInstance[*AppConfig](c, &AppConfig{Port: 8080})
+//
+//	cfg := di.MustResolve[AppConfig](c) // copy of the registered *AppConfig
+func WithPointerNormalization() ContainerOption {
+	return func(c *Container) {
+		c.normalizePointers = true
+	}
+}
+
+// pointerCounterpart returns the registered type that can stand in for an
+// unregistered request of typ and name under the normalization rules.
+func (c *Container) pointerCounterpart(typ reflect.Type, name string) (reflect.Type, bool) {
+	var counterpart reflect.Type
+	switch {
+	case typ.Kind() == reflect.Pointer:
+		if !valueSafe(typ.Elem()) {
+			return nil, false
+		}
+		counterpart = typ.Elem()
+	case typ.Kind() == reflect.Struct:
+		counterpart = reflect.PointerTo(typ)
+	default:
+		return nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, exists := c.registrations[registrationKey{typ: counterpart, name: name}]
+	return counterpart, exists
+}
+
+// resolveCounterpart resolves typ from the registration of its pointer or
+// value counterpart and converts the result.
+func (c *Container) resolveCounterpart(ctx context.Context, typ, counterpart reflect.Type, name string, scope *Scope, chain []reflect.Type) (any, error) {
+	instance, err := c.resolve(ctx, counterpart, name, scope, chain)
+	if err != nil {
+		return nil, ErrResolutionFailed{Type: typ, Cause: err}
+	}
+
+	v := reflect.ValueOf(instance)
+	if typ.Kind() == reflect.Pointer {
+		copied := reflect.New(typ.Elem())
+		copied.Elem().Set(v)
+		return copied.Interface(), nil
+	}
+
+	if v.IsNil() {
+		return nil, ErrResolutionFailed{Type: typ, Cause: fmt.Errorf("registered %s is nil", counterpart)}
+	}
+	return v.Elem().Interface(), nil
+}
+
+// valueSafe reports whether copying a value of typ copies all of its state,
+// so that no copy shares memory with another.
+func valueSafe(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return valueSafe(typ.Elem())
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			if !valueSafe(typ.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
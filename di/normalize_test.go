@@ -0,0 +1,81 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Pointer Normalization Tests
+// =============================================================================
+
+type appConfig struct {
+	Port  int
+	Debug bool
+}
+
+type loggingConfig struct {
+	Outputs []string
+}
+
+func TestPointerNormalizationValueFromPointer(t *testing.T) {
+	c := di.New(di.WithPointerNormalization())
+	registered := &appConfig{Port: 8080}
+	di.RegisterInstance[*appConfig](c, registered)
+
+	cfg, err := di.Resolve[appConfig](c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("expected a copy of the registered config, got %+v", cfg)
+	}
+	if di.Has[appConfig](c) {
+		t.Error("expected Has to report only exact registrations")
+	}
+}
+
+func TestPointerNormalizationPointerFromValue(t *testing.T) {
+	c := di.New(di.WithPointerNormalization())
+	di.RegisterInstance[appConfig](c, appConfig{Port: 8080})
+
+	first := di.MustResolve[*appConfig](c)
+	first.Port = 9090
+	if second := di.MustResolve[*appConfig](c); second == first || second.Port != 8080 {
+		t.Errorf("expected a fresh copy per resolution, got %+v", second)
+	}
+}
+
+func TestPointerNormalizationRejectsSharedState(t *testing.T) {
+	c := di.New(di.WithPointerNormalization())
+	di.RegisterInstance[loggingConfig](c, loggingConfig{Outputs: []string{"stderr"}})
+
+	var notRegistered di.ErrNotRegistered
+	if _, err := di.Resolve[*loggingConfig](c); !errors.As(err, &notRegistered) {
+		t.Errorf("expected ErrNotRegistered for a value type with shared state, got %v", err)
+	}
+}
+
+func TestPointerNormalizationNilPointer(t *testing.T) {
+	c := di.New(di.WithPointerNormalization())
+	di.Register[*appConfig](c, func() *appConfig { return nil })
+
+	var resErr di.ErrResolutionFailed
+	if _, err := di.Resolve[appConfig](c); !errors.As(err, &resErr) {
+		t.Errorf("expected ErrResolutionFailed for a nil pointer, got %v", err)
+	}
+}
+
+func TestPointerNormalizationOptIn(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[*appConfig](c, &appConfig{Port: 8080})
+
+	var notRegistered di.ErrNotRegistered
+	if _, err := di.Resolve[appConfig](c); !errors.As(err, &notRegistered) {
+		t.Errorf("expected ErrNotRegistered without normalization, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// Optional is a factory parameter for a dependency that may be absent.
+//
+// When a factory declares a parameter of type Optional[T], the container
+// resolves T as usual and sets Present. If T is not registered, the factory
+// receives the zero Optional instead of the resolution failing, so services
+// can work with or without optional collaborators such as caches. Other
+// failures, such as a registered T whose own dependencies are missing, still
+// fail the resolution.
+//
+// Optional parameters are not reported as dependencies by
+// [Container.Registrations] or checked by validation, since the registration
+// works without them.
+//
+// Example:
+//
+//	di.Register[Service](c, func(cache di.Optional[Cache]) Service {
+//	    if cache.Present {
+//	        return newCachedService(cache.Value)
+//	    }
+//	    return newService()
+//	})
+type Optional[T any] struct {
+	// Value is the resolved dependency, or the zero value if it is absent.
+	Value T
+	// Present reports whether the dependency was registered.
+	Present bool
+}
+
+// optionalParam is implemented by every instantiation of Optional.
+type optionalParam interface {
+	optionalType() reflect.Type
+	present(value any) any
+}
+
+func (Optional[T]) optionalType() reflect.Type {
+	var zero T
+	return reflect.TypeOf(&zero).Elem()
+}
+
+func (Optional[T]) present(value any) any {
+	v, _ := value.(T)
+	return Optional[T]{Value: v, Present: true}
+}
+
+// optionalParamType is the reflect.Type of optionalParam.
+var optionalParamType = reflect.TypeOf((*optionalParam)(nil)).Elem()
+
+// asOptional returns the Optional behind a parameter type, if it is one.
+func asOptional(paramType reflect.Type) (optionalParam, bool) {
+	if paramType.Kind() != reflect.Struct || !paramType.Implements(optionalParamType) {
+		return nil, false
+	}
+	return reflect.Zero(paramType).Interface().(optionalParam), true
+}
+
+// resolveOptional resolves an Optional parameter, treating an unregistered
+// dependency as absent.
+func (c *Container) resolveOptional(ctx context.Context, paramType reflect.Type, opt optionalParam, scope *Scope, chain []reflect.Type) (reflect.Value, error) {
+	typ := opt.optionalType()
+	resolved, err := c.resolve(ctx, typ, "", scope, chain)
+	if err != nil {
+		var missing ErrNotRegistered
+		if errors.As(err, &missing) && missing.Type == typ && missing.Name == "" {
+			return reflect.Zero(paramType), nil
+		}
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(opt.present(resolved)), nil
+}
@@ -0,0 +1,55 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Optional Dependency Tests
+// =============================================================================
+
+func TestOptionalDependency(t *testing.T) {
+	c := di.New()
+	di.Register[Service](c, func(logger di.Optional[Logger], greeter Greeter) Service {
+		svc := &DefaultService{greeter: greeter}
+		if logger.Present {
+			svc.logger = logger.Value
+		}
+		return svc
+	})
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+
+	svc, err := di.Resolve[Service](c)
+	if err != nil {
+		t.Fatalf("expected a missing optional dependency not to fail, got %v", err)
+	}
+	if svc.(*DefaultService).logger != nil {
+		t.Error("expected an absent optional dependency")
+	}
+
+	logger := &TestLogger{}
+	di.RegisterInstance[Logger](c, logger)
+	svc, _ = di.Resolve[Service](c)
+	if svc.(*DefaultService).logger != logger {
+		t.Error("expected the registered optional dependency to be injected")
+	}
+	if deps := c.Registrations()[0].Dependencies; len(deps) != 1 {
+		t.Errorf("expected optional parameters not to be reported as dependencies, got %v", deps)
+	}
+}
+
+func TestOptionalDependencyFailure(t *testing.T) {
+	c := di.New()
+	di.Register[Service](c, func(greeter di.Optional[Greeter]) Service { return &DefaultService{} })
+	di.Register[Greeter](c, func(logger Logger) Greeter { return &SimpleGreeter{} })
+
+	var missing di.ErrNotRegistered
+	if _, err := di.Resolve[Service](c); !errors.As(err, &missing) {
+		t.Errorf("expected a registered optional dependency's failure to propagate, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package di
+
+// ContainerOption configures a [Container] at construction time.
+//
+// Options are passed to [New]:
+//
+//	c := di.New(di.WithStrictMode())
+type ContainerOption func(*Container)
+
+// WithStrictMode makes registration reject ambiguous or conflicting names.
+//
+// In strict mode:
+//   - [WithName] with an empty name is rejected with [ErrInvalidName] instead of
+//     silently registering under the unnamed key
+//   - Registering a type and name that is already registered is rejected with
+//     [ErrDuplicateRegistration] instead of overwriting the earlier registration
+//
+// Example:
+//
+//	c := di.New(di.WithStrictMode())
+//	di.Register[Logger](c, newConsoleLogger, di.WithName("console"))
+//	err := di.Register[Logger](c, newFileLogger, di.WithName("console"))
+//	// err is di.ErrDuplicateRegistration
+func WithStrictMode() ContainerOption {
+	return func(c *Container) {
+		c.strict = true
+	}
+}
+
+// WithNamingPolicy sets a callback that validates every non-empty registration name.
+//
+// When the policy returns an error, registration fails with [ErrInvalidName]
+// carrying the policy's message. This lets teams enforce naming conventions
+// such as lowercase-kebab names across all modules.
+//
+// Example:
+//
+//	kebab := regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+//	c := di.New(di.WithNamingPolicy(func(name string) error {
+//	    if !kebab.MatchString(name) {
+//	        return errors.New("name must be lowercase-kebab")
+//	    }
+//	    return nil
+//	}))
+func WithNamingPolicy(policy func(name string) error) ContainerOption {
+	return func(c *Container) {
+		c.namingPolicy = policy
+	}
+}
+
+// WithNilChecks makes resolution fail with [ErrNilInstance] when a factory
+// returns a nil instance.
+//
+// A factory declared to return an interface can return a nil pointer of a
+// concrete type. The resulting interface value is not nil, so it passes
+// "!= nil" checks and flows through the container until a method call panics,
+// usually far from the factory at fault. With nil checks enabled, plain nil
+// results and such typed-nil results are reported when the factory returns,
+// naming the registration and the concrete type of the nil value.
+//
+// Example:
+//
+//	c := di.New(di.WithNilChecks())
+//	di.Register[Store](c, func() Store {
+//	    var s *redisStore // never assigned
+//	    return s
+//	})
+//	_, err := di.Resolve[Store](c)
+//	// err wraps di.ErrNilInstance: factory for Store returned a nil *redisStore
+func WithNilChecks() ContainerOption {
+	return func(c *Container) {
+		c.nilChecks = true
+	}
+}
@@ -0,0 +1,186 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Strict Mode and Naming Policy Tests
+// =============================================================================
+
+func TestStrictModeRejectsEmptyName(t *testing.T) {
+	c := di.New(di.WithStrictMode())
+
+	err := di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName(""))
+
+	var invalid di.ErrInvalidName
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected ErrInvalidName, got %v", err)
+	}
+	if di.Has[Greeter](c) {
+		t.Error("expected rejected registration not to be stored")
+	}
+}
+
+func TestStrictModeRejectsDuplicates(t *testing.T) {
+	c := di.New(di.WithStrictMode())
+
+	if err := di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("greeter")); err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+
+	err := di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("greeter"))
+	var dup di.ErrDuplicateRegistration
+	if !errors.As(err, &dup) {
+		t.Fatalf("expected ErrDuplicateRegistration, got %v", err)
+	}
+	if dup.Name != "greeter" {
+		t.Errorf("expected name 'greeter', got %q", dup.Name)
+	}
+
+	err = di.RegisterInstance[Greeter](c, &formalGreeter{}, di.WithName("greeter"))
+	if !errors.As(err, &dup) {
+		t.Fatalf("expected ErrDuplicateRegistration for instance, got %v", err)
+	}
+
+	greeter := di.MustResolveNamed[Greeter](c, "greeter")
+	if greeter.Greet("Test") != "Hello, Test" {
+		t.Error("expected original registration to be kept")
+	}
+}
+
+func TestNonStrictModeAllowsOverwrite(t *testing.T) {
+	c := di.New()
+
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName(""))
+	if err := di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }); err != nil {
+		t.Fatalf("expected overwrite to succeed, got %v", err)
+	}
+}
+
+func TestNamingPolicy(t *testing.T) {
+	c := di.New(di.WithNamingPolicy(func(name string) error {
+		if strings.ToLower(name) != name {
+			return errors.New("name must be lowercase")
+		}
+		return nil
+	}))
+
+	err := di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("Formal"))
+	var invalid di.ErrInvalidName
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected ErrInvalidName, got %v", err)
+	}
+	if invalid.Reason != "name must be lowercase" {
+		t.Errorf("unexpected reason: %s", invalid.Reason)
+	}
+
+	if err := di.RegisterType[Greeter, SimpleGreeter](c, di.WithName("formal")); err != nil {
+		t.Errorf("expected valid name to be accepted, got %v", err)
+	}
+	if err := di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }); err != nil {
+		t.Errorf("expected unnamed registration to bypass the policy, got %v", err)
+	}
+}
+
+func TestErrInvalidNameError(t *testing.T) {
+	err := di.ErrInvalidName{Name: "Bad", Reason: "uppercase"}
+	if !contains(err.Error(), `"Bad"`) || !contains(err.Error(), "uppercase") {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+}
+
+func TestErrDuplicateRegistrationError(t *testing.T) {
+	if msg := (di.ErrDuplicateRegistration{Name: "x"}).Error(); !contains(msg, `"x"`) {
+		t.Errorf("unexpected error message: %s", msg)
+	}
+	if msg := (di.ErrDuplicateRegistration{}).Error(); !contains(msg, "already registered") {
+		t.Errorf("unexpected error message: %s", msg)
+	}
+}
+
+// =============================================================================
+// Option Bundle Tests
+// =============================================================================
+
+var infraDefaults = di.Options(di.AsSingleton(), di.WithTags("infra"), nil)
+
+func TestOptionsBundleAppliesEveryOption(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} },
+		di.Options(infraDefaults, di.Phase("infrastructure")), di.WithTags("logging"))
+
+	info := c.Registrations()[0]
+	if info.Lifetime != di.Singleton || info.Phase != "infrastructure" {
+		t.Errorf("expected bundle options to apply, got %+v", info)
+	}
+	if got := strings.Join(info.Tags, ","); got != "infra,logging" {
+		t.Errorf("expected tags infra,logging, got %q", got)
+	}
+}
+
+func TestOptionsBundleCanBeOverridden(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, infraDefaults, di.AsTransient())
+
+	first, _ := di.Resolve[Logger](c)
+	second, _ := di.Resolve[Logger](c)
+	if first == second {
+		t.Error("expected a later option to override the bundle's lifetime")
+	}
+}
+
+// =============================================================================
+// Nil Check Tests
+// =============================================================================
+
+func TestNilChecksRejectTypedNil(t *testing.T) {
+	c := di.New(di.WithNilChecks())
+	di.Register[Logger](c, func() Logger {
+		var logger *TestLogger
+		return logger
+	}, di.WithName("broken"))
+
+	_, err := di.ResolveNamed[Logger](c, "broken")
+	var nilErr di.ErrNilInstance
+	if !errors.As(err, &nilErr) {
+		t.Fatalf("expected ErrNilInstance, got %v", err)
+	}
+	if nilErr.Name != "broken" || nilErr.Kind.String() != "*di_test.TestLogger" {
+		t.Errorf("unexpected error details: %+v", nilErr)
+	}
+	if !strings.Contains(err.Error(), "returned a nil *di_test.TestLogger") {
+		t.Errorf("expected the error to name the nil type, got %v", err)
+	}
+}
+
+func TestNilChecksRejectPlainNil(t *testing.T) {
+	c := di.New(di.WithNilChecks())
+	di.Register[*TestLogger](c, func() *TestLogger { return nil })
+
+	_, err := di.Resolve[*TestLogger](c)
+	if !errors.As(err, new(di.ErrNilInstance)) {
+		t.Fatalf("expected ErrNilInstance, got %v", err)
+	}
+}
+
+func TestNilChecksDisabledByDefault(t *testing.T) {
+	c := di.New()
+	di.Register[*TestLogger](c, func() *TestLogger { return nil })
+
+	if _, err := di.Resolve[*TestLogger](c); err != nil {
+		t.Errorf("expected nil results to pass without nil checks, got %v", err)
+	}
+
+	checked := di.New(di.WithNilChecks())
+	di.Register[[]string](checked, func() []string { return nil })
+	if _, err := di.Resolve[[]string](checked); err != nil {
+		t.Errorf("expected nil slices to pass nil checks, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package di
+
+import "reflect"
+
+// Out marks a result object: when the type registered with [Register] is a
+// struct that embeds Out, each of its exported fields is also registered as
+// its own type, so one constructor can provide several related services.
+//
+// Field registrations resolve the result object and return the field. They
+// take the registration options passed to Register, followed by those of the
+// field's struct tags:
+//   - name:"..." registers the field under a name, as [WithName] does
+//   - group:"..." adds the field to a group, as [InGroup] does
+//
+// Register result objects as singletons or scoped, so their fields share one
+// constructor call; a transient result object is constructed again for every
+// field resolved. Result objects themselves cannot be named or grouped; name
+// their fields instead.
+//
+// Example:
+//
+//	type DBHandles struct {
+//	    di.Out
+//
+//	    Reader *sql.DB `name:"reader"`
+//	    Writer *sql.DB `name:"writer"`
+//	}
+//
+//	di.Register[DBHandles](c, func(cfg Config) (DBHandles, error) {
+//	    pool, err := openPool(cfg)
+//	    return DBHandles{Reader: pool.Replica(), Writer: pool.Primary()}, err
+//	}, di.AsSingleton())
+//
+//	writer, _ := di.ResolveNamed[*sql.DB](c, "writer")
+type Out struct{}
+
+// outType is the reflect.Type of Out.
+var outType = reflect.TypeOf(Out{})
+
+// isResultObject reports whether typ is a struct that embeds Out.
+func isResultObject(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.Anonymous && field.Type == outType {
+			return true
+		}
+	}
+	return false
+}
+
+// resultRegistrations returns the registrations of the fields of the result
+// object registered by result, checked but not yet added.
+func (c *Container) resultRegistrations(result *registration, opts []RegistrationOption) ([]*registration, error) {
+	resultType := result.targetType
+	if result.name != "" || len(result.groups) > 0 {
+		return nil, ErrInvalidFactory{
+			Type:    resultType,
+			Message: "result objects cannot be named or grouped; tag their fields instead",
+		}
+	}
+
+	var fields []*registration
+	for i := 0; i < resultType.NumField(); i++ {
+		field := resultType.Field(i)
+		if !field.IsExported() || field.Type == outType {
+			continue
+		}
+
+		reg := &registration{
+			targetType: field.Type,
+			factory:    fieldFactory(resultType, i),
+			lifetime:   Transient,
+		}
+		for _, opt := range opts {
+			opt(reg)
+		}
+		if name, ok := field.Tag.Lookup("name"); ok {
+			WithName(name)(reg)
+		}
+		if group, ok := field.Tag.Lookup("group"); ok {
+			if group == "" {
+				return nil, ErrInvalidFactory{
+					Type:    resultType,
+					Message: "field " + field.Name + ": group tag must not be empty",
+				}
+			}
+			InGroup(group)(reg)
+		}
+		if err := c.checkRegistration(reg); err != nil {
+			return nil, err
+		}
+		fields = append(fields, reg)
+	}
+
+	if len(fields) == 0 {
+		return nil, ErrInvalidFactory{
+			Type:    resultType,
+			Message: "result object has no exported fields to register",
+		}
+	}
+	return fields, nil
+}
+
+// fieldFactory returns a factory that takes a result object of resultType and
+// returns its field at index.
+func fieldFactory(resultType reflect.Type, index int) any {
+	fieldType := resultType.Field(index).Type
+	fnType := reflect.FuncOf([]reflect.Type{resultType}, []reflect.Type{fieldType}, false)
+	return makeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+		return []reflect.Value{args[0].Field(index)}
+	}).Interface()
+}
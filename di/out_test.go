@@ -0,0 +1,66 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Result Object Tests
+// =============================================================================
+
+type loggerPair struct {
+	di.Out
+
+	Primary *TestLogger `name:"primary"`
+	Audit   *TestLogger `name:"audit" group:"sinks"`
+	Greeter Greeter
+	hidden  *TestLogger
+}
+
+func TestResultObject(t *testing.T) {
+	c := di.New()
+	calls := 0
+	err := di.Register[loggerPair](c, func() loggerPair {
+		calls++
+		return loggerPair{Primary: &TestLogger{}, Audit: &TestLogger{}, Greeter: &SimpleGreeter{}}
+	}, di.AsSingleton())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	primary := di.MustResolveNamed[*TestLogger](c, "primary")
+	audit := di.MustResolveNamed[*TestLogger](c, "audit")
+	if primary == audit {
+		t.Error("expected each field to be registered separately")
+	}
+	if greeting := di.MustResolve[Greeter](c).Greet("Ada"); greeting != "Hello, Ada" {
+		t.Errorf("expected the untagged field to be registered by type, got %q", greeting)
+	}
+	if sinks, _ := di.ResolveGroup[*TestLogger](c, "sinks"); len(sinks) != 1 || sinks[0] != audit {
+		t.Errorf("expected the grouped field to join its group, got %v", sinks)
+	}
+	if calls != 1 {
+		t.Errorf("expected the singleton result object to be constructed once, got %d", calls)
+	}
+	if pair := di.MustResolve[loggerPair](c); pair.Primary != primary {
+		t.Error("expected the result object itself to stay resolvable")
+	}
+}
+
+func TestResultObjectInvalid(t *testing.T) {
+	c := di.New()
+	factory := func() loggerPair { return loggerPair{} }
+
+	var invalid di.ErrInvalidFactory
+	if err := di.Register[loggerPair](c, factory, di.WithName("pair")); !errors.As(err, &invalid) {
+		t.Errorf("expected a named result object to be rejected, got %v", err)
+	}
+	if di.HasNamed[*TestLogger](c, "primary") {
+		t.Error("expected a rejected result object to register no fields")
+	}
+}
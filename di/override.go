@@ -0,0 +1,79 @@
+package di
+
+import (
+	"context"
+	"reflect"
+)
+
+// Override makes resolutions of T in scope return instance instead of the
+// registered T, so a single request or job can run against, for example, a
+// tenant-specific configuration while the rest of the process uses the
+// global one.
+//
+// The override applies to resolutions of T through the scope, including the
+// dependencies of scoped and transient services constructed in it, whatever
+// T's lifetime. Singletons are shared by the whole process, so they never see
+// an override: a singleton first constructed from the scope is built with the
+// registered T. T must be registered; the override does not make an
+// unregistered type resolvable. Forks inherit the scope's overrides. The
+// instance belongs to the caller and is not disposed with the scope.
+//
+// Example:
+//
+//	scope := container.CreateScope("request-123")
+//	defer scope.Dispose()
+//	di.Override[*Config](scope, tenantConfig)
+//
+//	handler, _ := di.ResolveInScope[*Handler](container, scope) // uses tenantConfig
+func Override[T any](scope *Scope, instance T) {
+	OverrideNamed[T](scope, "", instance)
+}
+
+// OverrideNamed is like [Override] for the registration of T with the given
+// name.
+//
+// Example:
+//
+//	di.OverrideNamed[*sql.DB](scope, "reports", tenantReportsDB)
+func OverrideNamed[T any](scope *Scope, name string, instance T) {
+	var zero T
+	key := registrationKey{typ: reflect.TypeOf(&zero).Elem(), name: name}
+
+	scope.mu.Lock()
+	defer scope.mu.Unlock()
+	if scope.overrides == nil {
+		scope.overrides = make(map[registrationKey]any)
+	}
+	scope.overrides[key] = instance
+}
+
+// override returns the instance overriding key in the scope, if any. A nil
+// scope has no overrides.
+func (s *Scope) override(key registrationKey) (any, bool) {
+	if s == nil {
+		return nil, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	instance, ok := s.overrides[key]
+	return instance, ok
+}
+
+// hasOverrides reports whether the scope overrides any registration.
+func (s *Scope) hasOverrides() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.overrides) > 0
+}
+
+// buildingSingletonKey marks the context of a singleton's construction, whose
+// dependencies must not see scope overrides.
+type buildingSingletonKey struct{}
+
+// overridable reports whether resolutions with ctx may use scope overrides.
+func overridable(ctx context.Context) bool {
+	return ctx.Value(buildingSingletonKey{}) == nil
+}
@@ -0,0 +1,231 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Phase assigns the registration to a named startup phase.
+//
+// [Container.Start] runs phase by phase: every eager singleton (see [Eager])
+// and hosted service of a phase is constructed and started before the next
+// phase begins. This gives a deterministic startup order beyond the edges of
+// the dependency graph, for example starting a migration runner before the
+// servers that expect the migrated schema, even though neither depends on the
+// other.
+//
+// Phases run in the order declared with [WithPhases], followed by undeclared
+// phases in the order of their first registration. Registrations without a
+// phase run last. [Container.Stop] stops services in reverse order, so later
+// phases stop first.
+//
+// Example:
+//
+//	c := di.New(di.WithPhases("infrastructure", "application"))
+//	di.Register[*Migrator](c, NewMigrator, di.AsSingleton(), di.Phase("infrastructure"))
+//	di.Register[*Server](c, NewServer, di.AsSingleton(), di.Phase("application"))
+func Phase(name string) RegistrationOption {
+	return func(r *registration) {
+		r.phase = name
+	}
+}
+
+// Eager constructs a singleton during [Container.Start] instead of on its first
+// resolution.
+//
+// Eager singletons surface construction errors at startup rather than on the
+// first request, and pay their construction cost before traffic arrives. They
+// are constructed in their [Phase], in registration order. The option has no
+// effect on transient and scoped registrations.
+//
+// Example:
+//
+//	di.Register[*Cache](c, NewCache, di.AsSingleton(), di.Eager())
+func Eager() RegistrationOption {
+	return func(r *registration) {
+		r.eager = true
+	}
+}
+
+// WithPhases declares the order in which startup phases run (see [Phase]).
+//
+// Phases that registrations use but that are not declared run after the
+// declared ones.
+//
+// Example:
+//
+//	c := di.New(di.WithPhases("infrastructure", "application"))
+func WithPhases(names ...string) ContainerOption {
+	return func(c *Container) {
+		c.phases = append(c.phases, names...)
+	}
+}
+
+// startupPhase is a group of registrations started together by Container.Start.
+type startupPhase struct {
+	name string
+	regs []*registration
+}
+
+// wrap annotates a startup error with the phase it happened in.
+func (p startupPhase) wrap(err error) error {
+	if p.name == "" {
+		return err
+	}
+	return fmt.Errorf("phase %q: %w", p.name, err)
+}
+
+// startupPhases groups the registrations that Start acts on by phase, in phase
+// order. Within a phase, registrations keep their registration order.
+func (c *Container) startupPhases() []startupPhase {
+	byName := make(map[string]int)
+	var phases []startupPhase
+	add := func(name string) {
+		if _, ok := byName[name]; !ok {
+			byName[name] = len(phases)
+			phases = append(phases, startupPhase{name: name})
+		}
+	}
+
+	c.mu.RLock()
+	for _, name := range c.phases {
+		if name != "" {
+			add(name)
+		}
+	}
+	c.mu.RUnlock()
+
+	var unphased []*registration
+	for _, reg := range c.orderedRegistrations() {
+		if (!reg.startsEagerly() && !reg.implements(hostedServiceType)) || !reg.enabled(c) {
+			continue
+		}
+		if reg.phase == "" {
+			unphased = append(unphased, reg)
+			continue
+		}
+		add(reg.phase)
+		i := byName[reg.phase]
+		phases[i].regs = append(phases[i].regs, reg)
+	}
+
+	if len(unphased) > 0 {
+		phases = append(phases, startupPhase{regs: unphased})
+	}
+	return phases
+}
+
+// startsEagerly reports whether Start should construct the registration.
+func (r *registration) startsEagerly() bool {
+	return r.eager && r.lifetime == Singleton && r.instance == nil
+}
+
+// WithParallelStartup makes [Container.Start] construct the eager singletons
+// and hosted services of each phase concurrently instead of one after another.
+// Services are still started in registration order once every instance of
+// the phase is constructed. A singleton that several of them depend on is
+// constructed once; the others wait for it.
+//
+// When one construction fails, the context passed to the others is cancelled
+// with the failure as its cause, so factories that accept a context.Context
+// can abort early; whatever factories that ignore it return after the
+// cancellation is disposed. Singletons the phase constructed before the
+// failure, including their dependencies, are evicted from the singleton cache
+// and disposed, so a failed startup does not leak connections. Start reports
+// the failure that triggered the cancellation.
+//
+// Example:
+//
+//	c := di.New(di.WithParallelStartup())
+func WithParallelStartup() ContainerOption {
+	return func(c *Container) {
+		c.parallelStartup = true
+	}
+}
+
+// constructPhase resolves the registrations of a startup phase, concurrently
+// if parallel startup is enabled, and returns the instances in the order of
+// phase.regs.
+func (c *Container) constructPhase(ctx context.Context, phase startupPhase) ([]any, error) {
+	instances := make([]any, len(phase.regs))
+	if !c.parallelStartup || len(phase.regs) < 2 {
+		for i, reg := range phase.regs {
+			instance, err := c.resolve(ctx, reg.targetType, reg.name, nil, make([]reflect.Type, 0))
+			if err != nil {
+				return nil, err
+			}
+			instances[i] = instance
+		}
+		return instances, nil
+	}
+
+	// Remember how many singletons were constructed before, so only this
+	// phase's work, including the dependencies it constructed, is undone
+	c.mu.RLock()
+	constructedBefore := c.constructions
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+	for i, reg := range phase.regs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			instance, err := c.resolve(ctx, reg.targetType, reg.name, nil, make([]reflect.Type, 0))
+			if err != nil {
+				cancel(err)
+				return
+			}
+			instances[i] = instance
+		}()
+	}
+	wg.Wait()
+
+	cause := context.Cause(ctx)
+	if cause == nil {
+		return instances, nil
+	}
+	c.evictConstructedSince(constructedBefore)
+	return nil, cause
+}
+
+// evictConstructedSince removes the singletons constructed after the given
+// construction count from the cache and disposes them, most recently
+// constructed first. It undoes a failed startup phase, including the
+// dependencies the phase constructed. Registrations are matched by their
+// construction order rather than by instance, since instances such as maps
+// are not comparable.
+func (c *Container) evictConstructedSince(constructedBefore uint64) {
+	type evicted struct {
+		reg      *registration
+		instance any
+	}
+	var victims []evicted
+	c.mu.Lock()
+	for key, reg := range c.registrations {
+		if reg.lifetime != Singleton || reg.instance != nil || reg.borrowed || reg.constructedAt <= constructedBefore {
+			continue
+		}
+		if instance, ok := c.singletons[key]; ok {
+			delete(c.singletons, key)
+			if reg.idleTimer != nil {
+				reg.idleTimer.Stop()
+				reg.idleTimer = nil
+			}
+			victims = append(victims, evicted{reg: reg, instance: instance})
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Slice(victims, func(i, j int) bool { return victims[i].reg.constructedAt > victims[j].reg.constructedAt })
+	for _, v := range victims {
+		if err := disposeInstance(v.instance); err != nil {
+			c.reportDisposeError(v.reg.targetType, v.reg.name, err)
+		}
+	}
+}
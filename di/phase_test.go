@@ -0,0 +1,256 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Startup Phase Tests
+// =============================================================================
+
+func TestStartRunsPhasesInDeclaredOrder(t *testing.T) {
+	c := di.New(di.WithPhases("infrastructure", "application"))
+	var events []string
+
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "server", events: &events},
+		di.WithName("server"), di.Phase("application"))
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "worker", events: &events},
+		di.WithName("worker"))
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "migrator", events: &events},
+		di.WithName("migrator"), di.Phase("infrastructure"))
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected stop error: %v", err)
+	}
+
+	want := "start migrator,start server,start worker,stop worker,stop server,stop migrator"
+	if got := strings.Join(events, ","); got != want {
+		t.Errorf("expected events %q, got %q", want, got)
+	}
+}
+
+func TestStartUndeclaredPhasesFollowRegistrationOrder(t *testing.T) {
+	c := di.New()
+	var events []string
+
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "b", events: &events},
+		di.WithName("b"), di.Phase("second"))
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "a", events: &events},
+		di.WithName("a"), di.Phase("first"))
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "c", events: &events},
+		di.WithName("c"), di.Phase("second"))
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+
+	want := "start b,start c,start a"
+	if got := strings.Join(events, ","); got != want {
+		t.Errorf("expected events %q, got %q", want, got)
+	}
+}
+
+func TestEagerSingletonsConstructedInPhase(t *testing.T) {
+	c := di.New(di.WithPhases("infrastructure", "application"))
+	var events []string
+
+	di.Register[Greeter](c, func() Greeter {
+		events = append(events, "construct greeter")
+		return &SimpleGreeter{}
+	}, di.AsSingleton(), di.Eager(), di.Phase("infrastructure"))
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "server", events: &events},
+		di.Phase("application"))
+	di.Register[Logger](c, func() Logger {
+		events = append(events, "construct logger")
+		return &TestLogger{}
+	}, di.AsSingleton())
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+
+	want := "construct greeter,start server"
+	if got := strings.Join(events, ","); got != want {
+		t.Errorf("expected events %q, got %q", want, got)
+	}
+}
+
+func TestStartPhaseFailureStopsEarlierPhases(t *testing.T) {
+	c := di.New(di.WithPhases("infrastructure", "application"))
+	var events []string
+	boom := errors.New("boom")
+
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "migrator", events: &events},
+		di.WithName("migrator"), di.Phase("infrastructure"))
+	di.Register[Greeter](c, func() (Greeter, error) {
+		return nil, boom
+	}, di.AsSingleton(), di.Eager(), di.Phase("application"))
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "server", events: &events},
+		di.WithName("server"), di.Phase("application"))
+
+	err := c.Start(context.Background())
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected factory error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `phase "application"`) {
+		t.Errorf("expected error to name the phase, got %v", err)
+	}
+
+	want := "start migrator,stop migrator"
+	if got := strings.Join(events, ","); got != want {
+		t.Errorf("expected events %q, got %q", want, got)
+	}
+}
+
+func TestRegistrationInfoReportsPhase(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} },
+		di.AsSingleton(), di.Eager(), di.Phase("infrastructure"))
+
+	info := c.Registrations()[0]
+	if info.Phase != "infrastructure" || !info.Eager {
+		t.Errorf("expected phase and eager flag, got %+v", info)
+	}
+}
+
+// =============================================================================
+// Parallel Startup Tests
+// =============================================================================
+
+func TestParallelStartupCancelsSiblingsOnFailure(t *testing.T) {
+	c := di.New(di.WithParallelStartup())
+	boom := errors.New("dial tcp: connection refused")
+	opened := &closableResource{}
+	cancelled := make(chan error, 1)
+	built := make(chan struct{})
+	waiting := make(chan struct{})
+
+	di.Register[*closableResource](c, func() *closableResource {
+		defer close(built)
+		return opened
+	}, di.AsSingleton(), di.Eager())
+	di.Register[Logger](c, func(ctx context.Context) (Logger, error) {
+		close(waiting)
+		<-ctx.Done()
+		cancelled <- context.Cause(ctx)
+		return nil, ctx.Err()
+	}, di.AsSingleton(), di.Eager())
+	di.Register[Greeter](c, func() (Greeter, error) {
+		<-built
+		<-waiting
+		return nil, boom
+	}, di.AsSingleton(), di.Eager())
+
+	err := c.Start(context.Background())
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected the sibling failure, got %v", err)
+	}
+	select {
+	case cause := <-cancelled:
+		if !errors.Is(cause, boom) {
+			t.Errorf("expected the context cause to be the failure, got %v", cause)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the waiting sibling to be cancelled")
+	}
+	if !opened.closed.Load() {
+		t.Error("expected the constructed sibling to be disposed")
+	}
+	if cached := c.CachedSingletons(); len(cached) != 0 {
+		t.Errorf("expected the disposed sibling to be evicted, got %v", cached)
+	}
+}
+
+// configMap is a map-typed singleton, whose values are not comparable.
+type configMap map[string]string
+
+func TestParallelStartupEvictsUncomparableAndTransitiveSingletons(t *testing.T) {
+	c := di.New(di.WithParallelStartup())
+	boom := errors.New("boom")
+	dependency := &closableResource{}
+	built := make(chan struct{})
+
+	di.Register[*closableResource](c, func() *closableResource { return dependency }, di.AsSingleton())
+	di.Register[configMap](c, func(*closableResource) configMap {
+		defer close(built)
+		return configMap{"env": "test"}
+	}, di.AsSingleton(), di.Eager())
+	di.Register[Greeter](c, func() (Greeter, error) {
+		<-built
+		time.Sleep(10 * time.Millisecond)
+		return nil, boom
+	}, di.AsSingleton(), di.Eager())
+
+	if err := c.Start(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("expected the sibling failure, got %v", err)
+	}
+	if !dependency.closed.Load() {
+		t.Error("expected the dependency constructed by the phase to be disposed")
+	}
+	if cached := c.CachedSingletons(); len(cached) != 0 {
+		t.Errorf("expected the phase's singletons to be evicted, got %v", cached)
+	}
+}
+
+func TestParallelStartupSharesDependencies(t *testing.T) {
+	c := di.New(di.WithParallelStartup())
+	var built atomic.Int64
+	var mu sync.Mutex
+	var opened []*closableResource
+
+	di.Register[*closableResource](c, func() *closableResource {
+		built.Add(1)
+		time.Sleep(10 * time.Millisecond) // Let both consumers ask for it
+		r := &closableResource{}
+		mu.Lock()
+		opened = append(opened, r)
+		mu.Unlock()
+		return r
+	}, di.AsSingleton())
+	di.Register[Logger](c, func(r *closableResource) Logger { return &TestLogger{} }, di.AsSingleton(), di.Eager())
+	di.Register[Greeter](c, func(r *closableResource) Greeter { return &SimpleGreeter{} }, di.AsSingleton(), di.Eager())
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	if built.Load() != 1 {
+		t.Errorf("expected the shared singleton to be constructed once, got %d", built.Load())
+	}
+
+	c.Close(context.Background())
+	mu.Lock()
+	defer mu.Unlock()
+	for _, r := range opened {
+		if !r.closed.Load() {
+			t.Error("expected every constructed instance to be disposed on Close")
+		}
+	}
+}
+
+func TestParallelStartupStartsServicesInOrder(t *testing.T) {
+	c := di.New(di.WithParallelStartup())
+	var events []string
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "a", events: &events}, di.WithName("a"))
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "b", events: &events}, di.WithName("b"))
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	defer c.Stop(context.Background())
+	if got := strings.Join(events, ","); got != "start a,start b" {
+		t.Errorf("expected services started in order, got %q", got)
+	}
+}
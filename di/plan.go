@@ -0,0 +1,74 @@
+package di
+
+import (
+	"reflect"
+	"sync"
+)
+
+// paramKind says how a function parameter is supplied.
+type paramKind uint8
+
+const (
+	paramResolved paramKind = iota // Resolved from the container
+	paramContext                   // The resolution context
+	paramScope                     // The resolving scope
+	paramMetadata                  // Metadata of the registration
+	paramProvider                  // A provider, unless the function type is registered or reflection is disabled
+	paramLazy                      // A Lazy handle
+	paramOptional                  // An Optional, absent if unregistered
+	paramObject                    // A struct embedding In, resolved field by field
+)
+
+// plannedParam is a parameter of a function the container calls.
+type plannedParam struct {
+	typ      reflect.Type
+	kind     paramKind
+	lazy     lazyParam
+	optional optionalParam
+}
+
+// paramPlans caches the parameter plans of factories, decorators, and invoked
+// functions by function type. A plan depends only on the signature, so it never
+// goes stale, and deep graphs skip re-classifying parameters on every
+// resolution.
+//
+// Plans are not expanded into a resolution graph per root type: selectors,
+// name resolvers, conditions, and scope overrides can send a type to a
+// different registration on each resolution, and the lookups such a graph
+// would save are a small share of resolving a deep transient chain, which is
+// dominated by calling the factories (see BenchmarkResolveDeepTransientChain).
+var paramPlans sync.Map // reflect.Type -> []plannedParam
+
+// planParams returns how each parameter of fnType is supplied.
+func planParams(fnType reflect.Type) []plannedParam {
+	if plan, ok := paramPlans.Load(fnType); ok {
+		return plan.([]plannedParam)
+	}
+
+	plan := make([]plannedParam, fnType.NumIn())
+	for i := range plan {
+		paramType := fnType.In(i)
+		plan[i].typ = paramType
+		switch paramType {
+		case contextType:
+			plan[i].kind = paramContext
+		case scopeType:
+			plan[i].kind = paramScope
+		case metadataType:
+			plan[i].kind = paramMetadata
+		default:
+			if _, ok := providerType(paramType); ok && reflectiveFactories {
+				plan[i].kind = paramProvider
+			} else if lazy, ok := asLazy(paramType); ok {
+				plan[i].kind, plan[i].lazy = paramLazy, lazy
+			} else if opt, ok := asOptional(paramType); ok {
+				plan[i].kind, plan[i].optional = paramOptional, opt
+			} else if isParamObject(paramType) {
+				plan[i].kind = paramObject
+			}
+		}
+	}
+
+	actual, _ := paramPlans.LoadOrStore(fnType, plan)
+	return actual.([]plannedParam)
+}
@@ -0,0 +1,79 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Policy is an organizational rule that every registration must satisfy, such
+// as "all *sql.DB registrations must be singletons". A policy returns an error
+// describing the violation, or nil if the registration is acceptable.
+type Policy func(info RegistrationInfo) error
+
+// AddPolicy installs a policy on the container.
+//
+// The policy is checked against every existing registration when it is added
+// and against every later registration before it is stored. A violation fails
+// fast with [ErrRegistrationRejected], whose Cause is the policy's error. If
+// an existing registration violates the policy, the policy is not installed.
+//
+// Policies are implemented as registration extensions (see [Extension]) and
+// run in the order they were added, interleaved with other extensions.
+//
+// Example:
+//
+//	c.AddPolicy(di.RequireLifetime[*sql.DB](di.Singleton))
+//	c.AddPolicy(func(info di.RegistrationInfo) error {
+//	    if info.Type.Kind() != reflect.Interface && strings.HasPrefix(info.Type.PkgPath(), "example.com/app/domain") {
+//	        return errors.New("domain types must be registered behind interfaces")
+//	    }
+//	    return nil
+//	})
+func (c *Container) AddPolicy(policy Policy) error {
+	if policy == nil {
+		return fmt.Errorf("di: policy must not be nil")
+	}
+
+	c.mu.RLock()
+	index := 1
+	for _, ext := range c.extensions {
+		if _, ok := ext.(*policyExtension); ok {
+			index++
+		}
+	}
+	c.mu.RUnlock()
+
+	return c.AddExtension(&policyExtension{index: index, policy: policy})
+}
+
+// RequireLifetime returns a policy that requires registrations of T to use the
+// given lifetime.
+//
+// Example:
+//
+//	c.AddPolicy(di.RequireLifetime[*sql.DB](di.Singleton))
+func RequireLifetime[T any](lifetime Lifetime) Policy {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	return func(info RegistrationInfo) error {
+		if info.Type == targetType && info.Lifetime != lifetime {
+			return fmt.Errorf("%s must be registered as %s, not %s", targetType, lifetime, info.Lifetime)
+		}
+		return nil
+	}
+}
+
+// policyExtension adapts a Policy to a RegistrationExtension.
+type policyExtension struct {
+	index  int
+	policy Policy
+}
+
+func (p *policyExtension) Name() string {
+	return fmt.Sprintf("policy %d", p.index)
+}
+
+func (p *policyExtension) OnRegister(info RegistrationInfo) error {
+	return p.policy(info)
+}
@@ -0,0 +1,64 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Policy Tests
+// =============================================================================
+
+func TestPolicyRejectsViolations(t *testing.T) {
+	c := di.New()
+
+	if err := c.AddPolicy(di.RequireLifetime[Logger](di.Singleton)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := di.Register[Logger](c, func() Logger { return &TestLogger{} })
+	var rejected di.ErrRegistrationRejected
+	if !errors.As(err, &rejected) {
+		t.Fatalf("expected ErrRegistrationRejected, got %v", err)
+	}
+	if rejected.By != "policy 1" {
+		t.Errorf("expected rejection by policy 1, got %q", rejected.By)
+	}
+	if !contains(err.Error(), "must be registered as Singleton, not Transient") {
+		t.Errorf("expected a clear message, got %q", err.Error())
+	}
+
+	if err := di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton()); err != nil {
+		t.Errorf("expected compliant registration to succeed, got %v", err)
+	}
+	if err := di.RegisterInstance[Greeter](c, &SimpleGreeter{}); err != nil {
+		t.Errorf("expected unrelated registration to succeed, got %v", err)
+	}
+}
+
+func TestPolicyChecksExistingRegistrations(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("Legacy"))
+
+	noUppercase := func(info di.RegistrationInfo) error {
+		for _, r := range info.Name {
+			if r >= 'A' && r <= 'Z' {
+				return errors.New("names must be lowercase")
+			}
+		}
+		return nil
+	}
+
+	if err := c.AddPolicy(noUppercase); err == nil {
+		t.Fatal("expected existing violation to be reported")
+	}
+
+	// The rejected policy was not installed
+	if err := di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("Other")); err != nil {
+		t.Errorf("expected registration to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// WithPrewarm keeps up to n pre-constructed instances of an expensive transient
+// registration ready, trading memory for tail latency.
+//
+// The buffer is filled in the background on the first resolution. Each
+// resolution takes an instance from the buffer when one is ready, and a
+// replacement is constructed in the background; when the buffer is empty the
+// factory runs as usual. Instances served from the buffer count as cache hits
+// in [Container.Stats].
+//
+// Prewarmed instances are constructed outside of any scope and with a
+// background context, so the option suits factories whose dependencies are
+// singletons or transients. Background construction errors are counted in the
+// registration's statistics and otherwise ignored.
+//
+// [Container.Close] and [Container.Clear] dispose the buffered instances and
+// stop refilling the buffer; instances still being constructed are disposed
+// when they complete. After Close, the registration is resolved without
+// prewarming.
+//
+// The option has no effect on singleton and scoped registrations.
+//
+// Example:
+//
+//	di.Register[*PDFRenderer](c, newPDFRenderer, di.WithPrewarm(4))
+func WithPrewarm(n int) RegistrationOption {
+	return func(r *registration) {
+		r.prewarm = n
+	}
+}
+
+// takePrewarmed returns a prewarmed instance if one is ready, and schedules
+// its replacement.
+func (c *Container) takePrewarmed(reg *registration) (any, bool) {
+	reg.poolMu.Lock()
+	closed := reg.poolClosed
+	reg.poolMu.Unlock()
+	if closed {
+		return nil, false
+	}
+
+	reg.prewarmOnce.Do(func() {
+		reg.pool = make(chan any, reg.prewarm)
+		for range reg.prewarm {
+			go c.refillPrewarmed(reg)
+		}
+	})
+
+	select {
+	case instance := <-reg.pool:
+		go c.refillPrewarmed(reg)
+		return instance, true
+	default:
+		return nil, false
+	}
+}
+
+// refillPrewarmed constructs one instance and adds it to the buffer if there
+// is room, disposing it otherwise.
+func (c *Container) refillPrewarmed(reg *registration) {
+	reg.poolMu.Lock()
+	closed := reg.poolClosed
+	reg.poolMu.Unlock()
+	if closed {
+		return
+	}
+
+	start := time.Now()
+	ctx, chain := context.Background(), []reflect.Type{reg.targetType}
+	instance, err := c.invokeFactory(ctx, reg, nil, chain, nil)
+	if err == nil {
+		instance, err = c.decorate(ctx, reg, instance, nil, chain)
+	}
+	if err == nil && len(reg.validators) > 0 {
+		err = reg.validate(instance)
+	}
+	if err != nil {
+		reg.stats.errors.Add(1)
+		return
+	}
+	end := time.Now()
+	reg.stats.recordConstruction(end.Sub(start), end)
+
+	c.mu.RLock()
+	onConstructed := c.onConstructed
+	c.mu.RUnlock()
+	for _, fn := range onConstructed {
+		fn(instance)
+	}
+
+	if !reg.offerPrewarmed(instance) {
+		if err := disposeInstance(instance); err != nil {
+			c.reportDisposeError(reg.targetType, reg.name, err)
+		}
+	}
+}
+
+// offerPrewarmed adds instance to the buffer unless it is full or closed.
+func (reg *registration) offerPrewarmed(instance any) bool {
+	reg.poolMu.Lock()
+	defer reg.poolMu.Unlock()
+
+	if reg.poolClosed {
+		return false
+	}
+	select {
+	case reg.pool <- instance:
+		return true
+	default:
+		return false
+	}
+}
+
+// drainPrewarmed closes the buffer, so that it is no longer refilled, and
+// returns the instances left in it.
+func (reg *registration) drainPrewarmed() []any {
+	reg.poolMu.Lock()
+	defer reg.poolMu.Unlock()
+
+	reg.poolClosed = true
+	var instances []any
+	for {
+		select {
+		case instance := <-reg.pool:
+			instances = append(instances, instance)
+		default:
+			return instances
+		}
+	}
+}
+
+// prewarmedRegistrations returns the registrations with a prewarm buffer.
+func (c *Container) prewarmedRegistrations() []*registration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var regs []*registration
+	for _, reg := range c.registrations {
+		if reg.prewarm > 0 && reg.lifetime == Transient {
+			regs = append(regs, reg)
+		}
+	}
+	return regs
+}
@@ -0,0 +1,141 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Prewarm Tests
+// =============================================================================
+
+type heavyRenderer struct {
+	id int64
+}
+
+func TestPrewarmServesBufferedInstances(t *testing.T) {
+	c := di.New()
+	var built atomic.Int64
+
+	di.Register[*heavyRenderer](c, func() *heavyRenderer {
+		return &heavyRenderer{id: built.Add(1)}
+	}, di.WithPrewarm(3))
+
+	// The first resolution constructs synchronously and starts filling the buffer
+	first := di.MustResolve[*heavyRenderer](c)
+	waitFor(t, func() bool { return built.Load() >= 4 })
+
+	second := di.MustResolve[*heavyRenderer](c)
+	if second == first {
+		t.Error("expected a distinct transient instance")
+	}
+	if second.id < 2 || second.id > 4 {
+		t.Errorf("expected one of the 3 prewarmed instances, got instance %d", second.id)
+	}
+
+	// The taken instance is replaced in the background
+	waitFor(t, func() bool { return built.Load() >= 5 })
+
+	stats := c.Stats().Registrations[0]
+	if stats.CacheHits != 1 {
+		t.Errorf("expected 1 prewarmed hit, got %d", stats.CacheHits)
+	}
+}
+
+func TestPrewarmIgnoredForSingletons(t *testing.T) {
+	c := di.New()
+	var built atomic.Int64
+
+	di.Register[*heavyRenderer](c, func() *heavyRenderer {
+		return &heavyRenderer{id: built.Add(1)}
+	}, di.AsSingleton(), di.WithPrewarm(3))
+
+	di.MustResolve[*heavyRenderer](c)
+	di.MustResolve[*heavyRenderer](c)
+
+	if built.Load() != 1 {
+		t.Errorf("expected a single construction, got %d", built.Load())
+	}
+}
+
+func TestPrewarmCloseDisposesBuffer(t *testing.T) {
+	c := di.New()
+	var mu sync.Mutex
+	var built []*closableResource
+
+	di.Register[*closableResource](c, func() *closableResource {
+		r := &closableResource{}
+		mu.Lock()
+		built = append(built, r)
+		mu.Unlock()
+		return r
+	}, di.WithPrewarm(2))
+
+	taken := di.MustResolve[*closableResource](c)
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(built) == 3
+	})
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mu.Lock()
+	for _, r := range built {
+		if r != taken && !r.closed.Load() {
+			t.Error("expected prewarmed instances to be disposed on Close")
+		}
+	}
+	mu.Unlock()
+	if taken.closed.Load() {
+		t.Error("expected the resolved transient to be left to its owner")
+	}
+
+	// Resolving after Close constructs directly and does not refill the buffer
+	di.MustResolve[*closableResource](c)
+	time.Sleep(10 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(built) != 4 {
+		t.Errorf("expected no refills after Close, got %d constructions", len(built))
+	}
+}
+
+type foregroundKey struct{}
+
+func TestPrewarmRefillAfterClearIsDisposed(t *testing.T) {
+	c := di.New()
+	stalled := make(chan struct{}, 1)
+	release := make(chan struct{})
+	blocked := make(chan *closableResource, 1)
+
+	di.Register[*closableResource](c, func(ctx context.Context) *closableResource {
+		r := &closableResource{}
+		if ctx.Value(foregroundKey{}) == nil {
+			// A background refill stalls until the container is cleared
+			stalled <- struct{}{}
+			<-release
+			blocked <- r
+		}
+		return r
+	}, di.WithPrewarm(1))
+
+	// The first resolution starts filling the buffer in the background
+	ctx := context.WithValue(context.Background(), foregroundKey{}, true)
+	di.ResolveCtx[*closableResource](ctx, c)
+	<-stalled
+
+	c.Clear()
+	close(release)
+
+	r := <-blocked
+	waitFor(t, func() bool { return r.closed.Load() })
+}
@@ -0,0 +1,118 @@
+package di
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+)
+
+// InProfiles restricts the registration to containers whose active profile is
+// one of names (see [WithProfile]).
+//
+// Registrations for other profiles are not resolvable, but they are kept so
+// that [Container.ValidateProfiles] can check every profile's wiring from one
+// container, whichever profile is active. Profiles usually select bindings per
+// environment, such as an in-memory mailer in dev and test and an SMTP mailer
+// in prod; a build-tagged file can choose the active profile.
+//
+// Example:
+//
+//	di.Register[Mailer](c, NewMemoryMailer, di.InProfiles("dev", "test"))
+//	di.Register[Mailer](c, NewSMTPMailer, di.InProfiles("prod"))
+func InProfiles(names ...string) RegistrationOption {
+	return func(r *registration) {
+		r.profiles = append(r.profiles, names...)
+	}
+}
+
+// WithProfile sets the container's active profile. Registrations made with
+// [InProfiles] only take effect when their profiles include it; registrations
+// without profiles always do.
+//
+// Example:
+//
+//	c := di.New(di.WithProfile(os.Getenv("APP_PROFILE")))
+func WithProfile(name string) ContainerOption {
+	return func(c *Container) {
+		c.profile = name
+	}
+}
+
+// ValidateProfiles checks the wiring of each profile independently, so that a
+// binding that only exists under one profile cannot silently break another.
+//
+// For each profile, the registrations that would be active under it are
+// collected, and every factory dependency of those registrations must be
+// registered among them or in the fallback container (see [WithFallback]).
+// With no arguments, every profile named by a registration is validated.
+// Nothing is constructed.
+//
+// Returns the [ErrUnsatisfiedDependency] errors found, joined, or nil.
+//
+// Example:
+//
+//	// In a test, with every module's registrations applied
+//	if err := c.ValidateProfiles("dev", "test", "prod"); err != nil {
+//	    t.Fatal(err)
+//	}
+func (c *Container) ValidateProfiles(profiles ...string) error {
+	c.mu.RLock()
+	declared := slices.Clone(c.declared)
+	fallback := c.fallback
+	c.mu.RUnlock()
+
+	if len(profiles) == 0 {
+		for _, reg := range declared {
+			for _, profile := range reg.profiles {
+				if !slices.Contains(profiles, profile) {
+					profiles = append(profiles, profile)
+				}
+			}
+		}
+	}
+
+	var errs []error
+	for _, profile := range profiles {
+		registered := make(map[registrationKey]bool)
+		for _, reg := range declared {
+			if reg.activeIn(profile) {
+				registered[registrationKey{typ: reg.targetType, name: reg.name}] = true
+			}
+		}
+
+		for _, reg := range declared {
+			if !reg.activeIn(profile) {
+				continue
+			}
+			for _, dep := range reg.dependencyTypes() {
+				if registered[registrationKey{typ: dep}] || fallback.hasRegistration(dep) {
+					continue
+				}
+				errs = append(errs, ErrUnsatisfiedDependency{
+					Profile:    profile,
+					Type:       reg.targetType,
+					Name:       reg.name,
+					Dependency: dep,
+				})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// activeIn reports whether the registration takes effect under profile.
+func (r *registration) activeIn(profile string) bool {
+	return len(r.profiles) == 0 || slices.Contains(r.profiles, profile)
+}
+
+// hasRegistration reports whether c, which may be nil, has an unnamed
+// registration of typ.
+func (c *Container) hasRegistration(typ reflect.Type) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.registrations[registrationKey{typ: typ}]
+	return ok
+}
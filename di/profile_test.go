@@ -0,0 +1,64 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Profile Tests
+// =============================================================================
+
+func TestProfilesSelectActiveRegistrations(t *testing.T) {
+	c := di.New(di.WithProfile("prod"))
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.InProfiles("dev", "test"))
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.InProfiles("prod"))
+	di.RegisterInstance[Logger](c, &TestLogger{}, di.InProfiles("dev"))
+
+	if _, ok := di.MustResolve[Greeter](c).(*formalGreeter); !ok {
+		t.Error("expected the prod greeter")
+	}
+	if di.Has[Logger](c) {
+		t.Error("expected the dev-only logger to be inactive")
+	}
+}
+
+func TestValidateProfiles(t *testing.T) {
+	c := di.New(di.WithProfile("dev"))
+	di.RegisterInstance[Logger](c, &TestLogger{}, di.InProfiles("dev"))
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.Register[Service](c, func(logger Logger, greeter Greeter) Service {
+		return &DefaultService{logger: logger, greeter: greeter}
+	})
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.InProfiles("prod"), di.WithName("formal"))
+
+	if err := c.ValidateProfiles("dev"); err != nil {
+		t.Errorf("expected dev to be valid, got %v", err)
+	}
+
+	err := c.ValidateProfiles()
+	var unsatisfied di.ErrUnsatisfiedDependency
+	if !errors.As(err, &unsatisfied) {
+		t.Fatalf("expected ErrUnsatisfiedDependency, got %v", err)
+	}
+	if unsatisfied.Profile != "prod" || unsatisfied.Dependency.String() != "di_test.Logger" {
+		t.Errorf("unexpected error: %+v", unsatisfied)
+	}
+}
+
+func TestValidateProfilesUsesFallback(t *testing.T) {
+	platform := di.New()
+	di.RegisterInstance[Logger](platform, &TestLogger{})
+
+	c := di.New(di.WithFallback(platform))
+	di.Register[Service](c, func(logger Logger) Service { return &DefaultService{logger: logger} },
+		di.InProfiles("prod"))
+
+	if err := c.ValidateProfiles("prod"); err != nil {
+		t.Errorf("expected fallback to satisfy the dependency, got %v", err)
+	}
+}
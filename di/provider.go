@@ -0,0 +1,30 @@
+package di
+
+import "reflect"
+
+// providerType returns T if paramType is a provider function of the form
+// func() (T, error). Factory parameters of that type receive a provider
+// synthesized by the container unless the function type is registered.
+func providerType(paramType reflect.Type) (reflect.Type, bool) {
+	if paramType.Kind() != reflect.Func || paramType.NumIn() != 0 || paramType.NumOut() != 2 || paramType.Out(1) != errorType {
+		return nil, false
+	}
+	return paramType.Out(0), true
+}
+
+// newProvider synthesizes a provider function of type providerFn that
+// resolves T in scope on every call.
+func (c *Container) newProvider(providerFn reflect.Type, scope *Scope) reflect.Value {
+	typ := providerFn.Out(0)
+	return makeFunc(providerFn, func([]reflect.Value) []reflect.Value {
+		instance := reflect.New(typ).Elem()
+		resolved, err := c.resolve(scope.Context(), typ, "", scope, make([]reflect.Type, 0))
+		if err != nil {
+			return []reflect.Value{instance, reflect.ValueOf(&err).Elem()}
+		}
+		if resolved != nil {
+			instance.Set(reflect.ValueOf(resolved))
+		}
+		return []reflect.Value{instance, reflect.Zero(errorType)}
+	})
+}
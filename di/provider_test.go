@@ -0,0 +1,77 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Provider Parameter Tests
+// =============================================================================
+
+type jobDispatcher struct {
+	newJob func() (*unitOfWork, error)
+}
+
+func TestProviderParameter(t *testing.T) {
+	c := di.New()
+	count := 0
+	di.Register[*unitOfWork](c, func() *unitOfWork {
+		count++
+		return &unitOfWork{ID: count}
+	})
+	di.Register[*jobDispatcher](c, func(newJob func() (*unitOfWork, error)) *jobDispatcher {
+		return &jobDispatcher{newJob: newJob}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request")
+	dispatcher, err := di.ResolveInScope[*jobDispatcher](c, scope)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 0 {
+		t.Fatal("expected the provider not to resolve during construction")
+	}
+	first, _ := dispatcher.newJob()
+	second, err := dispatcher.newJob()
+	if err != nil || first == second || count != 2 {
+		t.Errorf("expected a fresh transient per call, got %v, %v, %v", first, second, err)
+	}
+	if deps := c.Registrations()[1].Dependencies; len(deps) != 0 {
+		t.Errorf("expected providers not to be reported as dependencies, got %v", deps)
+	}
+}
+
+func TestProviderParameterError(t *testing.T) {
+	c := di.New()
+	di.Register[*jobDispatcher](c, func(newJob func() (*unitOfWork, error)) *jobDispatcher {
+		return &jobDispatcher{newJob: newJob}
+	})
+
+	dispatcher := di.MustResolve[*jobDispatcher](c)
+	if job, err := dispatcher.newJob(); !errors.As(err, new(di.ErrNotRegistered)) || job != nil {
+		t.Errorf("expected the provider to report the resolution error, got %v, %v", job, err)
+	}
+}
+
+func TestProviderParameterFollowsRegistrationChanges(t *testing.T) {
+	c := di.New()
+	di.Register[*unitOfWork](c, func() *unitOfWork { return &unitOfWork{ID: 1} })
+	di.Register[*jobDispatcher](c, func(newJob func() (*unitOfWork, error)) *jobDispatcher {
+		return &jobDispatcher{newJob: newJob}
+	})
+	di.MustResolve[*jobDispatcher](c)
+
+	// Registering the function type itself takes over from the synthesized provider
+	di.RegisterInstance[func() (*unitOfWork, error)](c, func() (*unitOfWork, error) {
+		return &unitOfWork{ID: 42}, nil
+	})
+	job, _ := di.MustResolve[*jobDispatcher](c).newJob()
+	if job.ID != 42 {
+		t.Errorf("expected the registered provider after the registration changed, got %d", job.ID)
+	}
+}
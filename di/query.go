@@ -0,0 +1,94 @@
+package di
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Predicate selects registrations in [Container.Query].
+type Predicate func(info RegistrationInfo) bool
+
+// Query returns the registrations that match pred, in the order of
+// [Container.Registrations].
+//
+// Predicates compose like a SQL WHERE clause, which lets tooling and policies
+// select subsets of the graph without filtering loops.
+//
+// Example:
+//
+//	infra := container.Query(di.Where(di.LifetimeIs(di.Singleton), di.TaggedWith("infra")))
+//	for _, info := range infra {
+//	    fmt.Println(info.Type)
+//	}
+func (c *Container) Query(pred Predicate) []RegistrationInfo {
+	var matches []RegistrationInfo
+	for _, info := range c.Registrations() {
+		if pred(info) {
+			matches = append(matches, info)
+		}
+	}
+	return matches
+}
+
+// Where matches registrations that match every one of preds. Where with no
+// predicates matches every registration.
+func Where(preds ...Predicate) Predicate {
+	return func(info RegistrationInfo) bool {
+		for _, pred := range preds {
+			if !pred(info) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any matches registrations that match at least one of preds.
+func Any(preds ...Predicate) Predicate {
+	return func(info RegistrationInfo) bool {
+		for _, pred := range preds {
+			if pred(info) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches registrations that do not match pred.
+func Not(pred Predicate) Predicate {
+	return func(info RegistrationInfo) bool {
+		return !pred(info)
+	}
+}
+
+// LifetimeIs matches registrations with the given lifetime.
+func LifetimeIs(lifetime Lifetime) Predicate {
+	return func(info RegistrationInfo) bool {
+		return info.Lifetime == lifetime
+	}
+}
+
+// TaggedWith matches registrations that carry tag (see [WithTags]).
+func TaggedWith(tag string) Predicate {
+	return func(info RegistrationInfo) bool {
+		return slices.Contains(info.Tags, tag)
+	}
+}
+
+// NameIs matches registrations with the given name; "" matches unnamed
+// registrations.
+func NameIs(name string) Predicate {
+	return func(info RegistrationInfo) bool {
+		return info.Name == name
+	}
+}
+
+// TypeIs matches registrations of T.
+func TypeIs[T any]() Predicate {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+	return func(info RegistrationInfo) bool {
+		return info.Type == targetType
+	}
+}
@@ -0,0 +1,39 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Query Tests
+// =============================================================================
+
+func TestQuery(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton(), di.WithTags("infra"))
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithTags("infra"))
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.AsSingleton(), di.WithName("formal"))
+
+	infra := c.Query(di.Where(di.LifetimeIs(di.Singleton), di.TaggedWith("infra")))
+	if len(infra) != 1 || infra[0].Name != "" || infra[0].Lifetime != di.Singleton {
+		t.Errorf("expected the infra singleton, got %+v", infra)
+	}
+
+	greeters := c.Query(di.Where(di.TypeIs[Greeter](), di.Not(di.NameIs(""))))
+	if len(greeters) != 1 || greeters[0].Name != "formal" {
+		t.Errorf("expected the formal greeter, got %+v", greeters)
+	}
+
+	either := c.Query(di.Any(di.TaggedWith("infra"), di.NameIs("formal")))
+	if len(either) != 3 {
+		t.Errorf("expected every registration, got %d", len(either))
+	}
+
+	if all := c.Query(di.Where()); len(all) != 3 {
+		t.Errorf("expected empty Where to match everything, got %d", len(all))
+	}
+}
@@ -0,0 +1,136 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrContainerReset is returned by resolutions that were in flight when
+// [Container.Clear] removed the registrations they were constructing.
+var ErrContainerReset = errors.New("di: container was reset during resolution")
+
+// Quiesce holds new resolutions and waits for the resolutions in flight to
+// finish, so the container can be reset without failing them.
+//
+// While the container is quiesced, top-level resolutions block until resume
+// is called or their context is done. Once every resolution that was already
+// in flight has finished, Quiesce returns a resume function that releases the
+// held resolutions; calling it more than once has no effect. If ctx is done
+// first, the container is resumed and ctx's error is returned.
+//
+// A factory that resolves from the container itself while it is quiesced
+// waits for resume, so the resolution it belongs to never finishes and
+// Quiesce waits until ctx is done. Calling Quiesce from a factory waits for
+// its own resolution in the same way.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//
+//	resume, err := container.Quiesce(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	container.Clear()
+//	registerAll(container)
+//	resume()
+func (c *Container) Quiesce(ctx context.Context) (resume func(), err error) {
+	return c.gate.quiesce(ctx)
+}
+
+// resolveGate counts top-level resolutions and holds new ones while the
+// container is quiesced.
+type resolveGate struct {
+	mu      sync.Mutex
+	active  int           // Top-level resolutions in flight
+	held    chan struct{} // Closed on resume; nil unless quiesced
+	drained chan struct{} // Closed when active reaches zero while quiesced
+}
+
+// enter registers a top-level resolution, waiting while the container is
+// quiesced.
+func (g *resolveGate) enter(ctx context.Context) error {
+	g.mu.Lock()
+	for g.held != nil {
+		held := g.held
+		g.mu.Unlock()
+		select {
+		case <-held:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		g.mu.Lock()
+	}
+	g.active++
+	g.mu.Unlock()
+	return nil
+}
+
+// leave unregisters a top-level resolution.
+func (g *resolveGate) leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.active--
+	if g.active == 0 && g.drained != nil {
+		close(g.drained)
+		g.drained = nil
+	}
+}
+
+// quiesce holds new resolutions and waits for active ones to finish.
+func (g *resolveGate) quiesce(ctx context.Context) (func(), error) {
+	// Wait for an earlier quiesce to be resumed first
+	if err := g.enter(ctx); err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	g.active--
+	held := make(chan struct{})
+	g.held = held
+	var drained chan struct{}
+	if g.active > 0 {
+		drained = make(chan struct{})
+		g.drained = drained
+	}
+	g.mu.Unlock()
+
+	var once sync.Once
+	resume := func() {
+		once.Do(func() {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			g.held = nil
+			g.drained = nil
+			close(held)
+		})
+	}
+
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			resume()
+			return nil, ctx.Err()
+		}
+	}
+	return resume, nil
+}
+
+// stale reports whether Clear removed reg after it was registered.
+func (c *Container) stale(reg *registration) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return reg.generation != c.generation
+}
+
+// discardStale disposes an instance constructed for a registration removed by
+// Clear and returns the error its resolution fails with.
+func (c *Container) discardStale(reg *registration, instance any) error {
+	if err := disposeInstance(instance); err != nil {
+		c.reportDisposeError(reg.targetType, reg.name, err)
+	}
+	return ErrResolutionFailed{Type: reg.targetType, Cause: ErrContainerReset}
+}
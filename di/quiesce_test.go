@@ -0,0 +1,132 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Clear and Quiesce Tests
+// =============================================================================
+
+func TestClearFailsInFlightResolutions(t *testing.T) {
+	c := di.New()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	resource := &closableResource{}
+	di.Register[*closableResource](c, func() *closableResource {
+		close(entered)
+		<-release
+		return resource
+	}, di.AsSingleton())
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := di.Resolve[*closableResource](c)
+		errs <- err
+	}()
+
+	<-entered
+	c.Clear()
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsSingleton())
+	close(release)
+
+	if err := <-errs; !errors.Is(err, di.ErrContainerReset) {
+		t.Fatalf("expected ErrContainerReset, got %v", err)
+	}
+	if !resource.closed.Load() {
+		t.Error("expected the discarded instance to be disposed")
+	}
+	fresh, err := di.Resolve[*closableResource](c)
+	if err != nil || fresh == resource {
+		t.Errorf("expected the new registration to construct a fresh instance, got %p, %v", fresh, err)
+	}
+}
+
+func TestQuiesceDrainsAndHoldsResolutions(t *testing.T) {
+	c := di.New()
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	di.Register[Logger](c, func() Logger {
+		entered <- struct{}{}
+		<-release
+		return &TestLogger{}
+	})
+
+	inFlight := make(chan error, 1)
+	go func() {
+		_, err := di.Resolve[Logger](c)
+		inFlight <- err
+	}()
+	<-entered
+
+	quiesced := make(chan func(), 1)
+	go func() {
+		resume, err := c.Quiesce(context.Background())
+		if err != nil {
+			t.Errorf("unexpected quiesce error: %v", err)
+		}
+		quiesced <- resume
+	}()
+
+	select {
+	case <-quiesced:
+		t.Fatal("expected Quiesce to wait for the in-flight resolution")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	if err := <-inFlight; err != nil {
+		t.Fatalf("expected the in-flight resolution to finish, got %v", err)
+	}
+	resume := <-quiesced
+
+	held := make(chan error, 1)
+	go func() {
+		_, err := di.Resolve[Logger](c)
+		held <- err
+	}()
+	select {
+	case <-held:
+		t.Fatal("expected new resolutions to be held while quiesced")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	resume()
+	resume()
+	if err := <-held; err != nil {
+		t.Errorf("expected the held resolution to succeed after resume, got %v", err)
+	}
+}
+
+func TestQuiesceTimesOut(t *testing.T) {
+	c := di.New()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	di.Register[Logger](c, func() Logger {
+		close(entered)
+		<-release
+		return &TestLogger{}
+	})
+	go di.Resolve[Logger](c)
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.Quiesce(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline error, got %v", err)
+	}
+
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	held, cancelHeld := context.WithTimeout(context.Background(), time.Second)
+	defer cancelHeld()
+	if _, err := di.ResolveCtx[Greeter](held, c); err != nil {
+		t.Errorf("expected the container to resume after a failed quiesce, got %v", err)
+	}
+}
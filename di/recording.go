@@ -0,0 +1,186 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ResolutionRecord is one resolution captured by [WithRecording].
+type ResolutionRecord struct {
+	// Type is the resolved type.
+	Type reflect.Type
+	// Name is the requested registration name, or "" for unnamed requests.
+	Name string
+	// Scope is the name of the scope the resolution ran in, or "" if it ran
+	// outside any scope.
+	Scope string
+	// Depth is the number of resolutions this one is nested in; resolutions
+	// requested directly by callers have depth 0.
+	Depth int
+	// Duration is how long the resolution took, including dependencies.
+	Duration time.Duration
+	// CacheHit reports whether the instance came from a cache rather than a
+	// factory.
+	CacheHit bool
+	// Err is the error the resolution failed with, or nil.
+	Err error
+}
+
+// Trace is an ordered log of resolutions.
+//
+// Resolutions are logged as they complete, so the dependencies of a
+// resolution precede it in the trace.
+type Trace []ResolutionRecord
+
+// Roots returns the resolutions requested directly by callers, those with
+// depth 0, in order.
+func (t Trace) Roots() Trace {
+	var roots Trace
+	for _, record := range t {
+		if record.Depth == 0 {
+			roots = append(roots, record)
+		}
+	}
+	return roots
+}
+
+// Duration returns the total time spent in the resolutions requested directly
+// by callers.
+func (t Trace) Duration() time.Duration {
+	var total time.Duration
+	for _, record := range t.Roots() {
+		total += record.Duration
+	}
+	return total
+}
+
+// WithRecording makes the container log every resolution, including the
+// resolution of factory parameters, for diagnosis and for replay against
+// another container (see [Replay]).
+//
+// The log grows with every resolution until it is read with
+// [Container.Recording] or reset with [Container.ResetRecording], so enable
+// recording in diagnostic builds and benchmarks rather than in long-running
+// production processes.
+//
+// Example:
+//
+//	c := di.New(di.WithRecording())
+//	app.Configure(c)
+//	app.HandleRequests(c)
+//
+//	for _, record := range c.Recording() {
+//	    fmt.Printf("%*s%s %v hit=%v\n", record.Depth*2, "", record.Type, record.Duration, record.CacheHit)
+//	}
+func WithRecording() ContainerOption {
+	return func(c *Container) {
+		c.recorder = &recorder{}
+		c.extensions = append(c.extensions, c.recorder)
+	}
+}
+
+// Recording returns the resolutions logged since the container was created or
+// the recording was last reset. It returns nil unless the container was
+// created with [WithRecording].
+func (c *Container) Recording() Trace {
+	if c.recorder == nil {
+		return nil
+	}
+	c.recorder.mu.Lock()
+	defer c.recorder.mu.Unlock()
+	return append(Trace(nil), c.recorder.trace...)
+}
+
+// ResetRecording discards the resolutions logged so far.
+func (c *Container) ResetRecording() {
+	if c.recorder == nil {
+		return
+	}
+	c.recorder.mu.Lock()
+	defer c.recorder.mu.Unlock()
+	c.recorder.trace = nil
+}
+
+// recorder is the resolution extension installed by WithRecording.
+type recorder struct {
+	mu    sync.Mutex
+	trace Trace
+}
+
+// Name implements Extension.
+func (r *recorder) Name() string { return "di.recording" }
+
+// OnResolve implements ResolutionExtension.
+func (r *recorder) OnResolve(event ResolveEvent) {
+	record := ResolutionRecord{
+		Type:     event.Type,
+		Name:     event.Name,
+		Depth:    event.Depth,
+		Duration: event.Duration,
+		CacheHit: event.CacheHit,
+		Err:      event.Err,
+	}
+	if event.Scope != nil {
+		record.Scope = event.Scope.Name()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trace = append(r.trace, record)
+}
+
+// Replay repeats the resolutions callers requested in trace against c, in
+// order, and returns a trace of the replayed resolutions, so the cost of the
+// same workload can be compared across two containers, such as before and
+// after a change to the registrations.
+//
+// Only resolutions of depth 0 are repeated; their dependencies are resolved
+// as c's registrations dictate. Resolutions that ran in a scope run in a scope
+// of the same name, created on first use and disposed when the replay ends.
+// Failures are recorded in the returned trace rather than stopping the
+// replay. Replayed records carry durations and errors only; record c itself
+// (see [WithRecording]) for a full trace.
+//
+// Example:
+//
+//	baseline := di.New(di.WithRecording())
+//	configure(baseline)
+//	runWorkload(baseline)
+//
+//	candidate := di.New()
+//	configureWithPooling(candidate)
+//	replayed := di.Replay(candidate, baseline.Recording())
+//	fmt.Println(baseline.Recording().Duration(), "vs", replayed.Duration())
+func Replay(c *Container, trace Trace) Trace {
+	scopes := make(map[string]*Scope)
+	defer func() {
+		for _, scope := range scopes {
+			scope.Dispose()
+		}
+	}()
+
+	var replayed Trace
+	for _, record := range trace.Roots() {
+		var scope *Scope
+		if record.Scope != "" {
+			scope = scopes[record.Scope]
+			if scope == nil {
+				scope = c.CreateScope(record.Scope)
+				scopes[record.Scope] = scope
+			}
+		}
+
+		start := time.Now()
+		_, err := c.resolve(context.Background(), record.Type, record.Name, scope, make([]reflect.Type, 0))
+		replayed = append(replayed, ResolutionRecord{
+			Type:     record.Type,
+			Name:     record.Name,
+			Scope:    record.Scope,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+	}
+	return replayed
+}
@@ -0,0 +1,83 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Recording Tests
+// =============================================================================
+
+func TestRecording(t *testing.T) {
+	c := di.New(di.WithRecording())
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton())
+	di.Register[Service](c, func(logger Logger) Service {
+		return &DefaultService{logger: logger}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request")
+	di.ResolveInScope[Service](c, scope)
+	di.ResolveInScope[Service](c, scope)
+
+	trace := c.Recording()
+	if len(trace) != 3 {
+		t.Fatalf("expected three resolutions, got %+v", trace)
+	}
+	logger, first, second := trace[0], trace[1], trace[2]
+	if logger.Type != reflect.TypeOf((*Logger)(nil)).Elem() || logger.Depth != 1 {
+		t.Errorf("expected the dependency to be recorded first, got %+v", logger)
+	}
+	if first.Scope != "request" || first.Depth != 0 || first.CacheHit {
+		t.Errorf("expected a constructing resolution in the scope, got %+v", first)
+	}
+	if !second.CacheHit {
+		t.Errorf("expected the second resolution to hit the scope's cache, got %+v", second)
+	}
+	if roots := trace.Roots(); len(roots) != 2 {
+		t.Errorf("expected two root resolutions, got %+v", roots)
+	}
+
+	c.ResetRecording()
+	if trace := c.Recording(); len(trace) != 0 {
+		t.Errorf("expected an empty recording after reset, got %+v", trace)
+	}
+	if di.New().Recording() != nil {
+		t.Error("expected no recording without WithRecording")
+	}
+}
+
+func TestReplay(t *testing.T) {
+	baseline := di.New(di.WithRecording())
+	di.Register[Logger](baseline, func() Logger { return &TestLogger{} })
+	di.Register[Greeter](baseline, func() Greeter { return &SimpleGreeter{} })
+	di.MustResolve[Logger](baseline)
+	di.ResolveInScope[Greeter](baseline, baseline.CreateScope("request"))
+
+	constructed := 0
+	candidate := di.New()
+	di.Register[Logger](candidate, func() Logger {
+		constructed++
+		return &TestLogger{}
+	})
+
+	replayed := di.Replay(candidate, baseline.Recording())
+	if len(replayed) != 2 || constructed != 1 {
+		t.Fatalf("expected both resolutions to be replayed, got %+v", replayed)
+	}
+	if replayed[0].Err != nil {
+		t.Errorf("unexpected error: %v", replayed[0].Err)
+	}
+	var notRegistered di.ErrNotRegistered
+	if replayed[1].Scope != "request" || !errors.As(replayed[1].Err, &notRegistered) {
+		t.Errorf("expected the unregistered resolution to fail in its scope, got %+v", replayed[1])
+	}
+	if len(candidate.Scopes()) != 0 {
+		t.Errorf("expected replay scopes to be disposed, got %v", candidate.Scopes())
+	}
+}
@@ -1,6 +1,11 @@
 package di
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // registration holds metadata about a registered dependency.
 // This is an internal type used by the container.
@@ -14,14 +19,103 @@ type registration struct {
 	// factory is the function to create instances.
 	factory any
 
+	// typed calls factory with resolved arguments without reflection, for
+	// registrations made with Register0 to Register3.
+	typed func(args []any) (any, error)
+
+	// userFactory is true when factory was supplied by the caller rather than
+	// synthesized by the container (as RegisterType does).
+	userFactory bool
+
 	// lifetime determines how long resolved instances live.
 	lifetime Lifetime
 
 	// instance is a pre-created instance (used by RegisterInstance).
 	instance any
 
+	// borrowed is true when the instance is owned, and disposed, by another
+	// container (see Bridge).
+	borrowed bool
+
 	// name is the identifier for named registrations.
 	name string
+
+	// nameSet records whether WithName was applied, even with an empty name.
+	nameSet bool
+
+	// tags are free-form labels used to group and inspect registrations.
+	tags []string
+
+	// groups are the groups the registration contributes to (see InGroup).
+	groups []string
+
+	// stats holds resolution counters for this registration.
+	stats registrationStats
+
+	// idleTimeout is how long a cached singleton may go unused before it is
+	// evicted and disposed (see WithIdleEviction). Zero disables eviction.
+	idleTimeout time.Duration
+
+	// lastUsed is the time of the last resolution, in Unix nanoseconds.
+	// It is only maintained when idleTimeout is set.
+	lastUsed atomic.Int64
+
+	// idleTimer fires when the cached singleton may have gone idle.
+	// Guarded by the container's mutex.
+	idleTimer *time.Timer
+
+	// shadow is the name of a registration of the same type that is resolved
+	// in the background whenever this one is constructed (see WithShadow).
+	shadow string
+
+	// prewarm is the number of pre-constructed transient instances to keep
+	// ready (see WithPrewarm). pool holds them once prewarmOnce has run.
+	// poolClosed is set by Close and Clear to stop refilling the pool; it and
+	// sends to pool are guarded by poolMu.
+	prewarm     int
+	pool        chan any
+	prewarmOnce sync.Once
+	poolMu      sync.Mutex
+	poolClosed  bool
+
+	// validators check every newly constructed instance (see WithValidation).
+	validators []func(instance any) error
+
+	// collection holds the values of a []T registration built by AppendValue.
+	collection any
+
+	// phase is the startup phase the registration belongs to (see Phase).
+	phase string
+
+	// eager marks a singleton that Start constructs (see Eager).
+	eager bool
+
+	// profiles restricts the registration to containers with one of these
+	// active profiles (see InProfiles). Empty means every profile.
+	profiles []string
+
+	// restart is the restart policy of a hosted service (see WithRestartPolicy).
+	restart RestartPolicy
+
+	// conditions must all hold for the registration to be visible (see
+	// WhenRegistered).
+	conditions []func(c *Container, evaluating []*registration) bool
+
+	// weight is how much an instance counts against a scope's instance budget
+	// (see WithWeight). Zero means 1.
+	weight int
+
+	// familySeparator ends the family prefix of scope names for the
+	// ScopeFamily lifetime (see AsScopeFamily).
+	familySeparator string
+
+	// constructedAt orders the construction of cached singletons, for
+	// disposing them in reverse. Guarded by the container's mu.
+	constructedAt uint64
+
+	// generation is the container's reset generation when the registration
+	// was added. A later Clear makes it stale.
+	generation uint64
 }
 
 // RegistrationOption configures a dependency registration.
@@ -35,6 +129,9 @@ type registration struct {
 //   - [AsScoped]: Single instance per scope
 //   - [WithLifetime]: Set lifetime explicitly
 //   - [WithName]: Register with a name for named resolution
+//   - [WithTags]: Attach labels for grouping and inspection
+//   - [InGroup]: Contribute to a group resolved with [ResolveGroup]
+//   - [WithIdleEviction]: Evict singletons that go unused
 type RegistrationOption func(*registration)
 
 // WithLifetime sets the lifetime for the registration.
@@ -128,9 +225,85 @@ func AsScoped() RegistrationOption {
 //
 //	// Resolve by name
 //	consoleLogger, _ := di.ResolveNamed[Logger](c, "console")
+//
+// Names are checked against the container's naming policy (see [WithNamingPolicy]),
+// and in strict mode (see [WithStrictMode]) an empty name is rejected rather than
+// silently registering under the unnamed key.
 func WithName(name string) RegistrationOption {
 	return func(r *registration) {
 		r.name = name
+		r.nameSet = true
+	}
+}
+
+// WithTags attaches free-form labels to a registration.
+//
+// Tags do not affect resolution. They are reported by [Container.Registrations]
+// and by tooling built on it, which makes it easy to group registrations by
+// layer, owner, or module. Applying WithTags more than once appends tags.
+//
+// Example:
+//
+//	di.Register[*sql.DB](c, openDB, di.AsSingleton(), di.WithTags("infra", "storage"))
+func WithTags(tags ...string) RegistrationOption {
+	return func(r *registration) {
+		r.tags = append(r.tags, tags...)
+	}
+}
+
+// Options combines several registration options into one, so a team can
+// define its registration conventions once and apply them consistently.
+//
+// The options are applied in order, and options passed after the bundle
+// override it, so a bundle acts as a set of defaults. Nil options are ignored.
+// Bundles can contain other bundles.
+//
+// Example:
+//
+//	var InfraDefaults = di.Options(di.AsSingleton(), di.Eager(), di.WithTags("infra"))
+//
+//	di.Register[*sql.DB](c, openDB, InfraDefaults)
+//	di.Register[*Cache](c, newCache, InfraDefaults, di.WithTags("cache"))
+func Options(opts ...RegistrationOption) RegistrationOption {
+	return func(r *registration) {
+		for _, opt := range opts {
+			if opt != nil {
+				opt(r)
+			}
+		}
+	}
+}
+
+// WithIdleEviction evicts a cached singleton that has not been resolved for the
+// given duration.
+//
+// When the singleton goes idle, it is removed from the cache and disposed if it
+// implements [Disposable] or io.Closer. The next resolution constructs a fresh instance. This keeps
+// memory bounded for rarely used heavy services such as large caches or report
+// generators.
+//
+// Only use idle eviction for services that callers resolve when they need them.
+// A singleton is not evicted while another cached singleton depends on it,
+// directly or through its dependencies; its idle timeout starts over instead.
+// Other consumers that captured the instance keep a reference to the evicted,
+// possibly closed, instance.
+//
+// The option has no effect on transient and scoped registrations.
+//
+// Example:
+//
+//	di.Register[*ReportEngine](c, newReportEngine,
+//	    di.AsSingleton(), di.WithIdleEviction(30*time.Minute))
+func WithIdleEviction(idle time.Duration) RegistrationOption {
+	return func(r *registration) {
+		r.idleTimeout = idle
+	}
+}
+
+// touch records that the registration was just used.
+func (r *registration) touch() {
+	if r.idleTimeout > 0 {
+		r.lastUsed.Store(time.Now().UnixNano())
 	}
 }
 
@@ -0,0 +1,148 @@
+package di
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// slowestConstructorsInReport is the number of constructors listed in the
+// "Slowest constructors" section of [Container.Report].
+const slowestConstructorsInReport = 5
+
+// Report writes a human-readable report about the container to w.
+//
+// The report contains:
+//   - A summary of registrations by lifetime, the depth of the dependency graph,
+//     and how many singletons have been constructed
+//   - A table of every registration with its lifetime, tags, graph depth, and
+//     resolution statistics
+//   - The slowest constructors observed so far
+//   - Detected smells: singletons capturing scoped dependencies, dependencies
+//     that are not registered, registrations that were never resolved, and any
+//     [Container.Warnings]
+//
+// Statistics reflect resolutions made so far, so the report is most useful
+// after the application has started or a test suite has run. Teams can attach
+// it to CI runs as a wiring artifact.
+//
+// Example:
+//
+//	f, _ := os.Create("di-report.txt")
+//	defer f.Close()
+//	if err := container.Report(f); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Container) Report(w io.Writer) error {
+	regs := c.orderedRegistrations()
+	graph := newDependencyGraph(regs)
+	stats := c.Stats()
+	cached := c.CachedSingletons()
+
+	statsByReg := make(map[registrationKey]RegistrationStats, len(stats.Registrations))
+	for _, s := range stats.Registrations {
+		statsByReg[registrationKey{typ: s.Type, name: s.Name}] = s
+	}
+
+	var b strings.Builder
+
+	// Summary
+	counts := make(map[Lifetime]int)
+	singletons, maxDepth := 0, 0
+	for _, reg := range regs {
+		counts[reg.lifetime]++
+		if reg.lifetime == Singleton {
+			singletons++
+		}
+		maxDepth = max(maxDepth, graph.depth(reg))
+	}
+
+	fmt.Fprintf(&b, "Dependency Injection Report\n")
+	fmt.Fprintf(&b, "===========================\n\n")
+	fmt.Fprintf(&b, "Registrations:          %d (%d singleton, %d scoped, %d transient)\n",
+		len(regs), counts[Singleton], counts[Scoped], counts[Transient])
+	fmt.Fprintf(&b, "Graph depth:            %d\n", maxDepth)
+	fmt.Fprintf(&b, "Singletons constructed: %d of %d\n", len(cached), singletons)
+	fmt.Fprintf(&b, "Resolutions:            %d (%d cache hits, %d errors)\n\n",
+		stats.Resolutions, stats.CacheHits, stats.Errors)
+
+	// Registration table
+	fmt.Fprintf(&b, "Registrations\n-------------\n")
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tNAME\tLIFETIME\tTAGS\tDEPTH\tRESOLUTIONS\tCONSTRUCTIONS\tAVG TIME")
+	for _, reg := range regs {
+		s := statsByReg[registrationKey{typ: reg.targetType, name: reg.name}]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+			reg.targetType, reg.name, reg.lifetime, strings.Join(reg.tags, ","),
+			graph.depth(reg), s.Resolutions, s.Constructions, averageDuration(s))
+	}
+	tw.Flush()
+	b.WriteString("\n")
+
+	// Slowest constructors
+	fmt.Fprintf(&b, "Slowest constructors\n--------------------\n")
+	slowest := make([]RegistrationStats, 0, len(stats.Registrations))
+	for _, s := range stats.Registrations {
+		if s.Constructions > 0 {
+			slowest = append(slowest, s)
+		}
+	}
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].MaxFactoryDuration > slowest[j].MaxFactoryDuration
+	})
+	if len(slowest) == 0 {
+		fmt.Fprintf(&b, "(nothing constructed yet)\n")
+	}
+	for i, s := range slowest[:min(len(slowest), slowestConstructorsInReport)] {
+		fmt.Fprintf(&b, "%d. %s: max %s, avg %s over %d constructions\n",
+			i+1, describeRegistration(s.Type, s.Name), s.MaxFactoryDuration, averageDuration(s), s.Constructions)
+	}
+	b.WriteString("\n")
+
+	// Smells
+	fmt.Fprintf(&b, "Smells\n------\n")
+	smells := 0
+	smell := func(format string, args ...any) {
+		smells++
+		fmt.Fprintf(&b, "- "+format+"\n", args...)
+	}
+	for _, reg := range regs {
+		for _, dep := range graph.dependencies(reg) {
+			if dep.target == nil {
+				smell("missing dependency: %s requires unregistered %s",
+					describeRegistration(reg.targetType, reg.name), dep.typ)
+			} else if reg.lifetime == Singleton && dep.target.lifetime == Scoped {
+				smell("captive dependency: singleton %s captures scoped %s",
+					describeRegistration(reg.targetType, reg.name), dep.typ)
+			} else if reg.lifetime == ScopeFamily && dep.target.lifetime == Scoped {
+				smell("captive dependency: scope family %s captures scoped %s",
+					describeRegistration(reg.targetType, reg.name), dep.typ)
+			}
+		}
+	}
+	for _, reg := range regs {
+		if statsByReg[registrationKey{typ: reg.targetType, name: reg.name}].Resolutions == 0 {
+			smell("unused registration: %s was never resolved", describeRegistration(reg.targetType, reg.name))
+		}
+	}
+	for _, warning := range c.Warnings() {
+		smell("%s", warning)
+	}
+	if smells == 0 {
+		fmt.Fprintf(&b, "(none detected)\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// averageDuration returns the mean construction time for a registration.
+func averageDuration(s RegistrationStats) time.Duration {
+	if s.Constructions == 0 {
+		return 0
+	}
+	return s.TotalFactoryDuration / time.Duration(s.Constructions)
+}
@@ -0,0 +1,76 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Report Tests
+// =============================================================================
+
+type requestState struct{}
+
+type cacheService struct {
+	state *requestState
+}
+
+func TestReport(t *testing.T) {
+	c := di.New()
+
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton(), di.WithTags("infra"))
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.Register[Service](c, func(log Logger, g Greeter) Service {
+		return &DefaultService{logger: log, greeter: g}
+	})
+	di.Register[*requestState](c, func() *requestState { return &requestState{} }, di.AsScoped())
+	di.Register[*cacheService](c, func(s *requestState) *cacheService {
+		return &cacheService{state: s}
+	}, di.AsSingleton())
+	di.Register[*ctxService](c, func(missing *unitOfWork) *ctxService { return nil })
+
+	di.MustResolve[Service](c)
+
+	var b strings.Builder
+	if err := c.Report(&b); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+	report := b.String()
+
+	for _, want := range []string{
+		"Registrations:          6 (2 singleton, 1 scoped, 3 transient)",
+		"Graph depth:            1",
+		"Singletons constructed: 1 of 2",
+		"di_test.Service",
+		"infra",
+		"Slowest constructors",
+		"captive dependency: singleton *di_test.cacheService captures scoped *di_test.requestState",
+		"missing dependency: *di_test.ctxService requires unregistered *di_test.unitOfWork",
+		"unused registration: *di_test.cacheService was never resolved",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q\n%s", want, report)
+		}
+	}
+
+	if strings.Contains(report, "unused registration: di_test.Logger") {
+		t.Error("expected resolved registrations not to be reported as unused")
+	}
+}
+
+func TestReportEmptyContainer(t *testing.T) {
+	var b strings.Builder
+	if err := di.New().Report(&b); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+
+	for _, want := range []string{"Registrations:          0", "(nothing constructed yet)", "(none detected)"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("expected report to contain %q\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,350 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Scope Forking Tests
+// =============================================================================
+
+type unitOfWork struct {
+	ID int
+}
+
+func TestScopeForkSharesExistingInstances(t *testing.T) {
+	c := di.New()
+
+	di.Register[*TestLogger](c, func() *TestLogger {
+		return &TestLogger{}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request")
+	parentLogger, _ := di.ResolveInScope[*TestLogger](c, scope)
+
+	fork := scope.Fork("request/branch")
+	if fork.Name() != "request/branch" {
+		t.Errorf("expected fork name 'request/branch', got '%s'", fork.Name())
+	}
+
+	forkLogger, err := di.ResolveInScope[*TestLogger](c, fork)
+	if err != nil {
+		t.Fatalf("failed to resolve in fork: %v", err)
+	}
+
+	if parentLogger != forkLogger {
+		t.Error("expected fork to share instances cached before the fork")
+	}
+}
+
+func TestScopeForkIsolatesNewInstances(t *testing.T) {
+	c := di.New()
+
+	count := 0
+	di.Register[*unitOfWork](c, func() *unitOfWork {
+		count++
+		return &unitOfWork{ID: count}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request")
+	fork1 := scope.Fork("branch-1")
+	fork2 := scope.Fork("branch-2")
+
+	uow1, _ := di.ResolveInScope[*unitOfWork](c, fork1)
+	uow2, _ := di.ResolveInScope[*unitOfWork](c, fork2)
+	uowParent, _ := di.ResolveInScope[*unitOfWork](c, scope)
+
+	if uow1 == uow2 || uow1 == uowParent || uow2 == uowParent {
+		t.Error("expected each scope to create its own instance after forking")
+	}
+
+	again, _ := di.ResolveInScope[*unitOfWork](c, fork1)
+	if again != uow1 {
+		t.Error("expected fork to cache its own instances")
+	}
+}
+
+// =============================================================================
+// Default Scope Tests
+// =============================================================================
+
+func TestSetDefaultScope(t *testing.T) {
+	c := di.New()
+
+	di.Register[*unitOfWork](c, func() *unitOfWork {
+		return &unitOfWork{}
+	}, di.AsScoped())
+
+	if c.DefaultScope() != nil {
+		t.Error("expected no default scope on a new container")
+	}
+
+	scope := c.CreateScope("job")
+	c.SetDefaultScope(scope)
+
+	if c.DefaultScope() != scope {
+		t.Error("expected DefaultScope to return the scope that was set")
+	}
+
+	uow1, _ := di.Resolve[*unitOfWork](c)
+	uow2, _ := di.Resolve[*unitOfWork](c)
+	if uow1 != uow2 {
+		t.Error("expected plain Resolve to use the default scope")
+	}
+
+	inScope, _ := di.ResolveInScope[*unitOfWork](c, scope)
+	if inScope != uow1 {
+		t.Error("expected default scope to share the scope's cache")
+	}
+
+	c.SetDefaultScope(nil)
+
+	uow3, _ := di.Resolve[*unitOfWork](c)
+	uow4, _ := di.Resolve[*unitOfWork](c)
+	if uow3 == uow4 {
+		t.Error("expected scoped registration to behave as transient without a scope")
+	}
+}
+
+func TestWithAmbientScope(t *testing.T) {
+	c := di.New()
+
+	di.Register[*unitOfWork](c, func() *unitOfWork {
+		return &unitOfWork{}
+	}, di.AsScoped())
+
+	outer := c.CreateScope("outer")
+	inner := c.CreateScope("inner")
+	c.SetDefaultScope(outer)
+
+	var fromInner *unitOfWork
+	c.WithAmbientScope(inner, func() {
+		if c.DefaultScope() != inner {
+			t.Error("expected ambient scope to be the default inside fn")
+		}
+		fromInner = di.MustResolve[*unitOfWork](c)
+	})
+
+	if c.DefaultScope() != outer {
+		t.Error("expected previous default scope to be restored")
+	}
+
+	expected, _ := di.ResolveInScope[*unitOfWork](c, inner)
+	if fromInner != expected {
+		t.Error("expected resolution inside fn to use the ambient scope")
+	}
+}
+
+func TestClearRemovesDefaultScope(t *testing.T) {
+	c := di.New()
+	c.SetDefaultScope(c.CreateScope("default"))
+
+	c.Clear()
+
+	if c.DefaultScope() != nil {
+		t.Error("expected Clear to remove the default scope")
+	}
+}
+
+// =============================================================================
+// Scope Disposal Tests
+// =============================================================================
+
+func TestScopeDispose(t *testing.T) {
+	c := di.New()
+
+	di.Register[*closableResource](c, func() *closableResource {
+		return &closableResource{}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("job")
+	c.SetDefaultScope(scope)
+	first := di.MustResolve[*closableResource](c)
+
+	if err := scope.Dispose(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !first.closed.Load() {
+		t.Error("expected scoped instance to be closed")
+	}
+	if c.DefaultScope() != nil {
+		t.Error("expected disposed scope to stop being the default scope")
+	}
+
+	second, _ := di.ResolveInScope[*closableResource](c, scope)
+	if second == first {
+		t.Error("expected disposed scope to start with an empty cache")
+	}
+}
+
+func TestScopeDisposeInReverseCreationOrder(t *testing.T) {
+	c := di.New()
+	var events []string
+	di.Register[*repository](c, func(conn *connection) *repository {
+		return &repository{&disposableRecorder{name: "repository", events: &events}}
+	}, di.AsScoped())
+	di.Register[*connection](c, func() *connection {
+		return &connection{&disposableRecorder{name: "connection", events: &events}}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request-1")
+	di.ResolveInScope[*repository](c, scope)
+	if got := c.Scopes(); len(got) != 1 || got[0] != "request-1" {
+		t.Errorf("expected the scope to be tracked, got %v", got)
+	}
+
+	if err := scope.Dispose(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(events, ","); got != "dispose repository,dispose connection" {
+		t.Errorf("expected dependents to be disposed first, got %q", got)
+	}
+	if got := c.Scopes(); len(got) != 0 {
+		t.Errorf("expected the disposed scope to be untracked, got %v", got)
+	}
+}
+
+func TestScopeDisposeSkipsInheritedInstances(t *testing.T) {
+	c := di.New()
+
+	di.Register[*closableResource](c, func() *closableResource {
+		return &closableResource{}
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request")
+	shared, _ := di.ResolveInScope[*closableResource](c, scope)
+
+	fork := scope.Fork("request/branch")
+	if err := fork.Dispose(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if shared.closed.Load() {
+		t.Error("expected fork disposal to leave the parent's instance open")
+	}
+
+	scope.Dispose()
+	if !shared.closed.Load() {
+		t.Error("expected owner disposal to close the instance")
+	}
+}
+
+func TestScopeArenaDisposesTransients(t *testing.T) {
+	c := di.New(di.WithScopeArena())
+	var events []string
+	di.Register[*repository](c, func(conn *connection) *repository {
+		return &repository{&disposableRecorder{name: "repository", events: &events}}
+	}, di.AsScoped())
+	di.Register[*connection](c, func() *connection {
+		return &connection{&disposableRecorder{name: "connection", events: &events}}
+	})
+
+	scope := c.CreateScope("request")
+	di.ResolveInScope[*repository](c, scope)
+	di.ResolveInScope[*connection](c, scope)
+	di.MustResolve[*connection](c) // no scope, not tracked
+
+	if err := scope.Dispose(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "dispose connection,dispose connection,dispose repository"
+	if got := strings.Join(events, ","); got != want {
+		t.Errorf("expected the scope's transients to be disposed before its scoped instances, got %q", got)
+	}
+
+	// The arena starts over for the next request
+	events = nil
+	next := c.CreateScope("next-request")
+	di.ResolveInScope[*connection](c, next)
+	next.Dispose()
+	if got := strings.Join(events, ","); got != "dispose connection" {
+		t.Errorf("expected only the new scope's transient to be disposed, got %q", got)
+	}
+}
+
+func TestScopeWithoutArenaLeavesTransients(t *testing.T) {
+	c := di.New()
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} })
+
+	scope := c.CreateScope("request")
+	resource, _ := di.ResolveInScope[*closableResource](c, scope)
+	scope.Dispose()
+	if resource.closed.Load() {
+		t.Error("expected transients to be owned by their consumers")
+	}
+}
+
+// =============================================================================
+// Scope Instance Enumeration Tests
+// =============================================================================
+
+func TestScopeInstances(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsScoped())
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsScoped())
+	di.Register[Service](c, func() Service { return &DefaultService{} })
+
+	scope := c.CreateScope("request-1")
+	defer scope.Dispose()
+	logger, _ := di.ResolveInScope[Logger](c, scope)
+	branch := scope.Fork("request-1/branch")
+	defer branch.Dispose()
+	di.ResolveInScope[Service](c, branch)
+	if _, err := di.ResolveInScope[Greeter](c, branch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	infos := branch.Instances()
+	if len(infos) != 2 {
+		t.Fatalf("expected the logger and greeter, got %+v", infos)
+	}
+	if infos[0].Instance != logger || !infos[0].Inherited {
+		t.Errorf("expected the inherited logger first, got %+v", infos[0])
+	}
+	if infos[1].Key.String() != "di_test.Greeter" || infos[1].Inherited {
+		t.Errorf("unexpected greeter info %+v", infos[1])
+	}
+	if len(scope.Instances()) != 1 {
+		t.Errorf("expected the parent scope to hold only the logger, got %+v", scope.Instances())
+	}
+}
+
+// =============================================================================
+// Scope Override Tests
+// =============================================================================
+
+func TestOverrideInScope(t *testing.T) {
+	c := di.New()
+	global := &TestLogger{}
+	di.RegisterInstance[Logger](c, global)
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.Register[Service](c, func(logger Logger, greeter Greeter) Service {
+		return &DefaultService{logger: logger, greeter: greeter}
+	})
+	di.Register[*DefaultService](c, func(logger Logger) *DefaultService {
+		return &DefaultService{logger: logger}
+	}, di.AsSingleton())
+
+	tenant := &TestLogger{}
+	scope := c.CreateScope("tenant-request")
+	di.Override[Logger](scope, tenant)
+
+	svc, _ := di.ResolveInScope[Service](c, scope)
+	if svc.(*DefaultService).logger != tenant {
+		t.Error("expected the scope's dependents to get the override")
+	}
+	if logger, _ := di.Resolve[Logger](c); logger != global {
+		t.Error("expected resolutions outside the scope to get the registered instance")
+	}
+	if shared, _ := di.ResolveInScope[*DefaultService](c, scope); shared.logger != global {
+		t.Error("expected singletons not to capture the override")
+	}
+	if forked, _ := di.ResolveInScope[Logger](c, scope.Fork("tenant-request/branch")); forked != tenant {
+		t.Error("expected forks to inherit the override")
+	}
+}
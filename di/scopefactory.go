@@ -0,0 +1,70 @@
+package di
+
+// ScopeFactory creates a scope with the given name and returns it together
+// with the function that disposes it. [Scope.Dispose] calls the returned
+// function.
+type ScopeFactory func(name string) (scope *Scope, dispose func() error)
+
+// ScopeMiddleware wraps a [ScopeFactory] to add behavior around scope creation
+// and disposal.
+type ScopeMiddleware func(next ScopeFactory) ScopeFactory
+
+// UseScope adds middleware around every scope created with
+// [Container.CreateScope] or [Scope.Fork], and so around the scopes created by
+// packages built on them, such as per-request and per-job scopes.
+//
+// Middleware can attach default values to new scopes, open resources whose
+// lifetime matches the scope, or measure how long scopes live. Middleware
+// added first is outermost: it sees creation first and disposal last. The
+// wrapped dispose function runs once, on the first call to [Scope.Dispose];
+// later calls only release instances created since.
+//
+// Middleware is applied when a scope is created, without holding container
+// locks, so it may resolve from the container. It also wraps the scopes
+// created by [Scope.Fork], which then copy their parent's state.
+//
+// Example:
+//
+//	c.UseScope(func(next di.ScopeFactory) di.ScopeFactory {
+//	    return func(name string) (*di.Scope, func() error) {
+//	        start := time.Now()
+//	        scope, dispose := next(name)
+//	        return scope, func() error {
+//	            err := dispose()
+//	            scopeLifetime.Observe(time.Since(start).Seconds())
+//	            return err
+//	        }
+//	    }
+//	})
+func (c *Container) UseScope(middleware ScopeMiddleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scopeMiddleware = append(c.scopeMiddleware, middleware)
+}
+
+// scopeFactory composes the container's scope middleware around the base
+// factory.
+func (c *Container) scopeFactory() ScopeFactory {
+	c.mu.RLock()
+	middleware := c.scopeMiddleware
+	c.mu.RUnlock()
+
+	factory := c.createScope
+	for i := len(middleware) - 1; i >= 0; i-- {
+		factory = middleware[i](factory)
+	}
+	return factory
+}
+
+// createScope is the base scope factory: it creates a scope and tracks it in
+// the container.
+func (c *Container) createScope(name string) (*Scope, func() error) {
+	scope := newScope(name, c)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scopes[name] = scope
+	return scope, scope.release
+}
@@ -0,0 +1,131 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Scope Middleware Tests
+// =============================================================================
+
+func TestUseScopeWrapsCreationAndDisposal(t *testing.T) {
+	c := di.New()
+	var events []string
+	trace := func(label string) di.ScopeMiddleware {
+		return func(next di.ScopeFactory) di.ScopeFactory {
+			return func(name string) (*di.Scope, func() error) {
+				events = append(events, "create "+label)
+				scope, dispose := next(name)
+				return scope, func() error {
+					events = append(events, "dispose "+label)
+					return dispose()
+				}
+			}
+		}
+	}
+	c.UseScope(trace("outer"))
+	c.UseScope(trace("inner"))
+
+	scope := c.CreateScope("request-1")
+	scope.Dispose()
+	scope.Dispose()
+
+	want := []string{"create outer", "create inner", "dispose outer", "dispose inner"}
+	if len(events) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("expected events %v, got %v", want, events)
+		}
+	}
+}
+
+func TestUseScopeAttachesValues(t *testing.T) {
+	c := di.New()
+	di.RegisterInstance[string](c, "acme", di.WithName("default-tenant"))
+	c.UseScope(func(next di.ScopeFactory) di.ScopeFactory {
+		return func(name string) (*di.Scope, func() error) {
+			scope, dispose := next(name)
+			scope.SetValue(tenantKey{}, di.MustResolveNamed[string](c, "default-tenant"))
+			return scope, dispose
+		}
+	})
+
+	scope := c.CreateScope("request-1")
+	defer scope.Dispose()
+	if tenant := scope.Value(tenantKey{}); tenant != "acme" {
+		t.Errorf("expected middleware to set the tenant, got %v", tenant)
+	}
+}
+
+func TestUseScopeDisposeError(t *testing.T) {
+	c := di.New()
+	rollback := errors.New("rollback failed")
+	c.UseScope(func(next di.ScopeFactory) di.ScopeFactory {
+		return func(name string) (*di.Scope, func() error) {
+			scope, dispose := next(name)
+			return scope, func() error { return errors.Join(dispose(), rollback) }
+		}
+	})
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsScoped())
+
+	scope := c.CreateScope("job")
+	resource, _ := di.ResolveInScope[*closableResource](c, scope)
+	if err := scope.Dispose(); !errors.Is(err, rollback) {
+		t.Errorf("expected the middleware's error, got %v", err)
+	}
+	if !resource.closed.Load() {
+		t.Error("expected the scope's instances to be disposed")
+	}
+}
+
+type forkKey struct{}
+
+func TestUseScopeWrapsForks(t *testing.T) {
+	c := di.New()
+	var created, disposed []string
+	c.UseScope(func(next di.ScopeFactory) di.ScopeFactory {
+		return func(name string) (*di.Scope, func() error) {
+			created = append(created, name)
+			scope, dispose := next(name)
+			scope.SetValue(forkKey{}, name)
+			return scope, func() error {
+				disposed = append(disposed, name)
+				return dispose()
+			}
+		}
+	})
+	di.Register[*closableResource](c, func() *closableResource { return &closableResource{} }, di.AsScoped())
+
+	scope := c.CreateScope("request-1")
+	scope.SetValue(tenantKey{}, "acme")
+	shared, _ := di.ResolveInScope[*closableResource](c, scope)
+
+	branch := scope.Fork("request-1/branch")
+	if got, _ := di.ResolveInScope[*closableResource](c, branch); got != shared {
+		t.Error("expected the fork to share the parent's instances")
+	}
+	if branch.Value(tenantKey{}) != "acme" {
+		t.Error("expected the fork to copy the parent's values")
+	}
+	if branch.Value(forkKey{}) != "request-1/branch" {
+		t.Errorf("expected the middleware's value to take precedence, got %v", branch.Value(forkKey{}))
+	}
+
+	branch.Dispose()
+	if len(created) != 2 || created[1] != "request-1/branch" {
+		t.Errorf("expected middleware to wrap the fork's creation, got %v", created)
+	}
+	if len(disposed) != 1 || disposed[0] != "request-1/branch" {
+		t.Errorf("expected middleware to wrap the fork's disposal, got %v", disposed)
+	}
+	if shared.closed.Load() {
+		t.Error("expected the fork to leave shared instances open")
+	}
+}
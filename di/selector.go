@@ -0,0 +1,148 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"sort"
+)
+
+// Selector chooses which named registration serves an unnamed request for a type.
+//
+// A selector returns the name of the registration to use, or "" to use the
+// unnamed registration. Returning an error fails the resolution with
+// [ErrResolutionFailed].
+type Selector func(ctx SelectionContext) (name string, err error)
+
+// SelectionContext describes the resolution a [Selector] is choosing for.
+type SelectionContext struct {
+	// Context is the resolution context (see [ResolveCtx]).
+	Context context.Context
+	// Type is the type being resolved.
+	Type reflect.Type
+	// Scope is the scope the resolution happens in, or nil if there is none.
+	Scope *Scope
+}
+
+// Value returns the value for key from the scope, falling back to the
+// resolution context when the scope has no such value.
+func (s SelectionContext) Value(key any) any {
+	if s.Scope != nil {
+		if v := s.Scope.Value(key); v != nil {
+			return v
+		}
+	}
+	if s.Context != nil {
+		return s.Context.Value(key)
+	}
+	return nil
+}
+
+// RegisterSelector installs a selector that routes unnamed requests for T to one
+// of T's named registrations at resolution time.
+//
+// Whenever T is resolved without a name, including as a factory parameter, the
+// selector is called and the registration it names is resolved instead. Named
+// requests such as [ResolveNamed] bypass the selector. Registering a selector
+// for T again replaces the previous one.
+//
+// This makes it possible to route between implementations by tenant, feature
+// flag, or any other value available from the scope or context.
+//
+// Example:
+//
+//	di.Register[PaymentGateway](c, newStripeGateway, di.WithName("stripe"))
+//	di.Register[PaymentGateway](c, newAdyenGateway, di.WithName("adyen"))
+//
+//	di.RegisterSelector[PaymentGateway](c, func(ctx di.SelectionContext) (string, error) {
+//	    tenant, _ := ctx.Value(tenantKey{}).(string)
+//	    if tenant == "" {
+//	        return "", errors.New("no tenant in scope")
+//	    }
+//	    return tenantGateways[tenant], nil
+//	})
+//
+//	scope := c.CreateScope("request-1")
+//	scope.SetValue(tenantKey{}, "acme")
+//	gateway, err := di.ResolveInScope[PaymentGateway](c, scope)
+func RegisterSelector[T any](c *Container, selector Selector) error {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	if selector == nil {
+		return ErrInvalidFactory{Type: targetType, Message: "selector must not be nil"}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.selectors[targetType] = selector
+	return nil
+}
+
+// WithRollout returns a [Selector] that routes percent of resolutions to the
+// registration named name and the rest to the unnamed registration.
+//
+// Percent is clamped to the range 0 to 100. Each resolution is routed
+// independently at random, so a canary implementation can be exercised by a
+// fraction of traffic inside a single process.
+//
+// Example:
+//
+//	di.Register[Search](c, newSearch)                          // current implementation
+//	di.Register[Search](c, newSearchV2, di.WithName("v2"))     // canary
+//	di.RegisterSelector[Search](c, di.WithRollout("v2", 10))   // 10% to v2
+func WithRollout(name string, percent float64) Selector {
+	fraction := min(max(percent, 0), 100) / 100
+
+	return func(SelectionContext) (string, error) {
+		if rand.Float64() < fraction {
+			return name, nil
+		}
+		return "", nil
+	}
+}
+
+// WeightedSelector returns a [Selector] that routes resolutions between named
+// registrations in proportion to their weights.
+//
+// The empty name refers to the unnamed registration. Names with a zero weight
+// never receive traffic. If no name has a positive weight, every resolution
+// fails with an error.
+//
+// Example:
+//
+//	di.RegisterSelector[Search](c, di.WeightedSelector(map[string]uint{
+//	    "v1": 80,
+//	    "v2": 15,
+//	    "v3": 5,
+//	}))
+func WeightedSelector(weights map[string]uint) Selector {
+	names := make([]string, 0, len(weights))
+	var total uint64
+	for name, weight := range weights {
+		if weight > 0 {
+			names = append(names, name)
+			total += uint64(weight)
+		}
+	}
+	sort.Strings(names)
+
+	// Copy the weights so later changes to the caller's map have no effect
+	cumulative := make([]uint64, len(names))
+	var running uint64
+	for i, name := range names {
+		running += uint64(weights[name])
+		cumulative[i] = running
+	}
+
+	return func(SelectionContext) (string, error) {
+		if total == 0 {
+			return "", errors.New("di: weighted selector has no positive weights")
+		}
+		pick := rand.Uint64N(total)
+		i := sort.Search(len(cumulative), func(i int) bool { return cumulative[i] > pick })
+		return names[i], nil
+	}
+}
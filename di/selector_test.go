@@ -0,0 +1,227 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Selector Tests
+// =============================================================================
+
+func registerGreeters(c *di.Container) {
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("simple"))
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("formal"))
+}
+
+func TestSelectorChoosesByScopeValue(t *testing.T) {
+	c := di.New()
+	registerGreeters(c)
+
+	di.RegisterSelector[Greeter](c, func(ctx di.SelectionContext) (string, error) {
+		if ctx.Value(tenantKey{}) == "bank" {
+			return "formal", nil
+		}
+		return "simple", nil
+	})
+
+	bank := c.CreateScope("bank-request")
+	bank.SetValue(tenantKey{}, "bank")
+	shop := c.CreateScope("shop-request")
+	shop.SetValue(tenantKey{}, "shop")
+
+	g1, err := di.ResolveInScope[Greeter](c, bank)
+	if err != nil {
+		t.Fatalf("failed to resolve: %v", err)
+	}
+	if g1.Greet("Test") != "Good day, Test" {
+		t.Error("expected formal greeter for bank tenant")
+	}
+
+	g2, _ := di.ResolveInScope[Greeter](c, shop)
+	if g2.Greet("Test") != "Hello, Test" {
+		t.Error("expected simple greeter for shop tenant")
+	}
+}
+
+func TestSelectorUsesContextValues(t *testing.T) {
+	c := di.New()
+	registerGreeters(c)
+
+	di.RegisterSelector[Greeter](c, func(ctx di.SelectionContext) (string, error) {
+		name, _ := ctx.Value(tenantKey{}).(string)
+		return name, nil
+	})
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "formal")
+	g, err := di.ResolveCtx[Greeter](ctx, c)
+	if err != nil {
+		t.Fatalf("failed to resolve: %v", err)
+	}
+	if g.Greet("Test") != "Good day, Test" {
+		t.Error("expected selector to read context values")
+	}
+}
+
+func TestSelectorAppliesToDependencies(t *testing.T) {
+	c := di.New()
+	registerGreeters(c)
+	di.Register[Logger](c, func() Logger { return &TestLogger{} })
+	di.Register[Service](c, func(log Logger, g Greeter) Service {
+		return &DefaultService{logger: log, greeter: g}
+	})
+
+	di.RegisterSelector[Greeter](c, func(di.SelectionContext) (string, error) {
+		return "formal", nil
+	})
+
+	svc := di.MustResolve[Service](c)
+	if svc.DoWork() != "Good day, World" {
+		t.Error("expected selector to apply to factory parameters")
+	}
+}
+
+func TestSelectorBypassedForNamedRequests(t *testing.T) {
+	c := di.New()
+	registerGreeters(c)
+
+	di.RegisterSelector[Greeter](c, func(di.SelectionContext) (string, error) {
+		return "formal", nil
+	})
+
+	g := di.MustResolveNamed[Greeter](c, "simple")
+	if g.Greet("Test") != "Hello, Test" {
+		t.Error("expected named request to bypass selector")
+	}
+}
+
+func TestSelectorErrors(t *testing.T) {
+	c := di.New()
+	registerGreeters(c)
+
+	selectErr := errors.New("no tenant")
+	di.RegisterSelector[Greeter](c, func(di.SelectionContext) (string, error) {
+		return "", selectErr
+	})
+
+	_, err := di.Resolve[Greeter](c)
+	if !errors.Is(err, selectErr) {
+		t.Errorf("expected selector error, got %v", err)
+	}
+}
+
+func TestSelectorUnknownName(t *testing.T) {
+	c := di.New()
+	registerGreeters(c)
+
+	di.RegisterSelector[Greeter](c, func(di.SelectionContext) (string, error) {
+		return "missing", nil
+	})
+
+	_, err := di.Resolve[Greeter](c)
+	var notReg di.ErrNotRegistered
+	if !errors.As(err, &notReg) {
+		t.Fatalf("expected ErrNotRegistered, got %v", err)
+	}
+	if notReg.Name != "missing" || !contains(notReg.Error(), `"missing"`) {
+		t.Errorf("expected error to name the selected registration, got %v", notReg)
+	}
+}
+
+func TestRegisterSelectorNil(t *testing.T) {
+	c := di.New()
+	if err := di.RegisterSelector[Greeter](c, nil); err == nil {
+		t.Error("expected error for nil selector")
+	}
+}
+
+func TestScopeValuesCopiedToFork(t *testing.T) {
+	c := di.New()
+	scope := c.CreateScope("request")
+	scope.SetValue(tenantKey{}, "acme")
+
+	fork := scope.Fork("branch")
+	if fork.Value(tenantKey{}) != "acme" {
+		t.Error("expected fork to copy scope values")
+	}
+
+	fork.SetValue(tenantKey{}, "other")
+	if scope.Value(tenantKey{}) != "acme" {
+		t.Error("expected fork values to be independent")
+	}
+}
+
+// =============================================================================
+// Rollout Selector Tests
+// =============================================================================
+
+func countSelections(t *testing.T, selector di.Selector, n int) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	for range n {
+		name, err := selector(di.SelectionContext{})
+		if err != nil {
+			t.Fatalf("selector failed: %v", err)
+		}
+		counts[name]++
+	}
+	return counts
+}
+
+func TestWithRollout(t *testing.T) {
+	counts := countSelections(t, di.WithRollout("v2", 10), 10000)
+
+	if counts["v2"] < 700 || counts["v2"] > 1300 {
+		t.Errorf("expected roughly 10%% routed to v2, got %d of 10000", counts["v2"])
+	}
+	if counts["v2"]+counts[""] != 10000 {
+		t.Errorf("unexpected selections: %v", counts)
+	}
+}
+
+func TestWithRolloutBounds(t *testing.T) {
+	if counts := countSelections(t, di.WithRollout("v2", 0), 1000); counts["v2"] != 0 {
+		t.Errorf("expected 0%% rollout to never select v2, got %d", counts["v2"])
+	}
+	if counts := countSelections(t, di.WithRollout("v2", 150), 1000); counts["v2"] != 1000 {
+		t.Errorf("expected rollout above 100%% to always select v2, got %d", counts["v2"])
+	}
+}
+
+func TestWithRolloutResolution(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("v2"))
+	di.RegisterSelector[Greeter](c, di.WithRollout("v2", 100))
+
+	if di.MustResolve[Greeter](c).Greet("Test") != "Good day, Test" {
+		t.Error("expected full rollout to resolve v2")
+	}
+}
+
+func TestWeightedSelector(t *testing.T) {
+	counts := countSelections(t, di.WeightedSelector(map[string]uint{
+		"v1": 3,
+		"v2": 1,
+		"v3": 0,
+	}), 10000)
+
+	if counts["v3"] != 0 {
+		t.Errorf("expected zero-weight name to be skipped, got %d", counts["v3"])
+	}
+	if counts["v1"] < 7000 || counts["v1"] > 8000 {
+		t.Errorf("expected roughly 75%% routed to v1, got %d of 10000", counts["v1"])
+	}
+}
+
+func TestWeightedSelectorNoWeights(t *testing.T) {
+	selector := di.WeightedSelector(map[string]uint{"v1": 0})
+	if _, err := selector(di.SelectionContext{}); err == nil {
+		t.Error("expected error when no weights are positive")
+	}
+}
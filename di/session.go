@@ -0,0 +1,109 @@
+package di
+
+import (
+	"sync"
+	"time"
+)
+
+// SessionScope ties a scope to a long-lived connection such as a websocket,
+// server-sent events stream, or gRPC stream.
+//
+// Unlike a per-request scope, a session scope lives as long as the connection:
+// it is opened on connect, closed on disconnect, and also closed when the
+// connection has been idle for the configured timeout. Scoped dependencies
+// resolved in [SessionScope.Scope] are shared by every message on the
+// connection and disposed when the session ends.
+//
+// Use [NewSessionScope] to open a session.
+type SessionScope struct {
+	scope       *Scope
+	idleTimeout time.Duration
+	done        chan struct{}
+
+	mu     sync.Mutex
+	timer  *time.Timer
+	closed bool
+	err    error
+}
+
+// NewSessionScope opens a session scope named name in the container.
+//
+// If idleTimeout is positive, the session closes itself once [SessionScope.Touch]
+// has not been called for that long; the connection handler should watch
+// [SessionScope.Done] and drop the connection when it fires. A zero idleTimeout
+// keeps the session open until [SessionScope.Close] is called.
+//
+// Example:
+//
+//	func serveWS(conn *websocket.Conn) {
+//	    session := di.NewSessionScope(container, "ws-"+conn.ID(), 5*time.Minute)
+//	    defer session.Close()
+//
+//	    go func() {
+//	        <-session.Done()
+//	        conn.Close() // idle timeout
+//	    }()
+//
+//	    for msg := range conn.Messages() {
+//	        session.Touch()
+//	        handler, _ := di.ResolveInScope[*ChatHandler](container, session.Scope())
+//	        handler.Handle(msg)
+//	    }
+//	}
+func NewSessionScope(c *Container, name string, idleTimeout time.Duration) *SessionScope {
+	s := &SessionScope{
+		scope:       c.CreateScope(name),
+		idleTimeout: idleTimeout,
+		done:        make(chan struct{}),
+	}
+	if idleTimeout > 0 {
+		s.timer = time.AfterFunc(idleTimeout, func() {
+			s.Close()
+		})
+	}
+	return s
+}
+
+// Scope returns the scope to resolve session dependencies in.
+func (s *SessionScope) Scope() *Scope {
+	return s.scope
+}
+
+// Touch records activity on the session, restarting its idle timeout.
+// Touch has no effect once the session is closed.
+func (s *SessionScope) Touch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timer != nil && !s.closed {
+		s.timer.Reset(s.idleTimeout)
+	}
+}
+
+// Done returns a channel that is closed when the session ends, either because
+// [SessionScope.Close] was called or because the idle timeout expired.
+func (s *SessionScope) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close ends the session and disposes its scope (see [Scope.Dispose]).
+//
+// Close is safe to call more than once and from multiple goroutines. Every call
+// returns the error from disposing the scope, including when the session was
+// closed by its idle timeout.
+func (s *SessionScope) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return s.err
+	}
+	s.closed = true
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+
+	s.err = s.scope.Dispose()
+	close(s.done)
+	return s.err
+}
@@ -0,0 +1,72 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Session Scope Tests
+// =============================================================================
+
+func TestSessionScopeClose(t *testing.T) {
+	c := di.New()
+	di.Register[*closableResource](c, func() *closableResource {
+		return &closableResource{}
+	}, di.AsScoped())
+
+	session := di.NewSessionScope(c, "ws-1", 0)
+	first, _ := di.ResolveInScope[*closableResource](c, session.Scope())
+	second, _ := di.ResolveInScope[*closableResource](c, session.Scope())
+	if first != second {
+		t.Error("expected one instance per session")
+	}
+
+	if err := session.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := session.Close(); err != nil {
+		t.Fatalf("unexpected error on second close: %v", err)
+	}
+
+	select {
+	case <-session.Done():
+	default:
+		t.Error("expected Done to be closed")
+	}
+	if !first.closed.Load() {
+		t.Error("expected session instance to be disposed")
+	}
+}
+
+func TestSessionScopeIdleTimeout(t *testing.T) {
+	c := di.New()
+	di.Register[*closableResource](c, func() *closableResource {
+		return &closableResource{}
+	}, di.AsScoped())
+
+	session := di.NewSessionScope(c, "sse-1", 100*time.Millisecond)
+	resource, _ := di.ResolveInScope[*closableResource](c, session.Scope())
+
+	// Activity keeps the session alive past the timeout
+	for range 10 {
+		time.Sleep(10 * time.Millisecond)
+		session.Touch()
+	}
+	if resource.closed.Load() {
+		t.Fatal("expected touched session to stay open")
+	}
+
+	select {
+	case <-session.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected idle session to close")
+	}
+	if !resource.closed.Load() {
+		t.Error("expected idle session instance to be disposed")
+	}
+}
@@ -0,0 +1,84 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// ShadowResult compares a construction with the shadow resolution it triggered.
+//
+// Shadow results are reported through [Hooks.OnShadowResult] for registrations
+// made with [WithShadow].
+type ShadowResult struct {
+	// Type is the resolved type.
+	Type reflect.Type
+	// Name is the name of the primary registration.
+	Name string
+	// ShadowName is the name of the shadow registration.
+	ShadowName string
+	// Err is the error the primary construction failed with, or nil.
+	Err error
+	// ShadowErr is the error the shadow resolution failed with, or nil.
+	ShadowErr error
+	// Duration is how long the primary construction took.
+	Duration time.Duration
+	// ShadowDuration is how long the shadow resolution took.
+	ShadowDuration time.Duration
+}
+
+// WithShadow resolves a named registration of the same type in the background
+// every time this registration is constructed, and reports how the two compare.
+//
+// Shadow resolution lets teams validate a new implementation against production
+// traffic before switching to it. The caller always receives the primary
+// instance; the shadow instance is discarded, and its errors and duration are
+// only reported through [Hooks.OnShadowResult]. If no OnShadowResult hook is
+// installed, no shadow resolutions are made.
+//
+// The shadow resolves in the same scope as the primary, so scoped shadows are
+// cached in that scope under their own name. Shadow resolutions never trigger
+// further shadows, and cache hits of the primary do not trigger a shadow.
+//
+// Example:
+//
+//	c := di.New(di.WithHooks(di.Hooks{
+//	    OnShadowResult: func(r di.ShadowResult) {
+//	        metrics.RecordShadow(r.ShadowName, r.Err, r.ShadowErr, r.ShadowDuration-r.Duration)
+//	    },
+//	}))
+//
+//	di.Register[PricingEngine](c, newPricingEngine, di.WithShadow("v2"))
+//	di.Register[PricingEngine](c, newPricingEngineV2, di.WithName("v2"))
+func WithShadow(name string) RegistrationOption {
+	return func(r *registration) {
+		r.shadow = name
+	}
+}
+
+// shadowKey marks the context of a shadow resolution.
+type shadowKey struct{}
+
+// startShadow resolves the registration's shadow in the background and reports
+// the comparison with the primary construction.
+func (c *Container) startShadow(ctx context.Context, reg *registration, scope *Scope, err error, elapsed time.Duration) {
+	onResult := c.hooks.OnShadowResult
+	if onResult == nil || ctx.Value(shadowKey{}) != nil {
+		return
+	}
+
+	shadowCtx := context.WithValue(context.WithoutCancel(ctx), shadowKey{}, true)
+	go func() {
+		start := time.Now()
+		_, shadowErr := c.resolve(shadowCtx, reg.targetType, reg.shadow, scope, make([]reflect.Type, 0))
+		onResult(ShadowResult{
+			Type:           reg.targetType,
+			Name:           reg.name,
+			ShadowName:     reg.shadow,
+			Err:            err,
+			ShadowErr:      shadowErr,
+			Duration:       elapsed,
+			ShadowDuration: time.Since(start),
+		})
+	}()
+}
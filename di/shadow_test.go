@@ -0,0 +1,74 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Shadow Resolution Tests
+// =============================================================================
+
+func TestShadowResolution(t *testing.T) {
+	results := make(chan di.ShadowResult, 10)
+	c := di.New(di.WithHooks(di.Hooks{
+		OnShadowResult: func(r di.ShadowResult) { results <- r },
+	}))
+
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithShadow("v2"))
+	di.Register[Greeter](c, func() (Greeter, error) {
+		return nil, errors.New("v2 not ready")
+	}, di.WithName("v2"), di.WithShadow("v1"))
+
+	greeter, err := di.Resolve[Greeter](c)
+	if err != nil {
+		t.Fatalf("shadow failure must not affect the primary: %v", err)
+	}
+	if _, ok := greeter.(*SimpleGreeter); !ok {
+		t.Errorf("expected primary instance, got %T", greeter)
+	}
+
+	select {
+	case r := <-results:
+		if r.ShadowName != "v2" || r.Name != "" || r.Err != nil {
+			t.Errorf("unexpected shadow result: %+v", r)
+		}
+		if r.ShadowErr == nil {
+			t.Error("expected shadow error to be reported")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a shadow result")
+	}
+
+	// The shadow's own WithShadow is not followed
+	select {
+	case r := <-results:
+		t.Errorf("unexpected nested shadow result: %+v", r)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestShadowSkippedOnCacheHit(t *testing.T) {
+	results := make(chan di.ShadowResult, 10)
+	c := di.New(di.WithHooks(di.Hooks{
+		OnShadowResult: func(r di.ShadowResult) { results <- r },
+	}))
+
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsSingleton(), di.WithShadow("v2"))
+	di.Register[Greeter](c, func() Greeter { return &formalGreeter{} }, di.WithName("v2"))
+
+	di.MustResolve[Greeter](c)
+	di.MustResolve[Greeter](c)
+
+	<-results
+	select {
+	case r := <-results:
+		t.Errorf("expected no shadow for cache hit, got %+v", r)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
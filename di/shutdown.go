@@ -0,0 +1,116 @@
+package di
+
+import (
+	"fmt"
+	"slices"
+)
+
+// runningService is a hosted service started by Start.
+type runningService struct {
+	service HostedService
+	reg     *registration
+	deps    map[registrationKey]bool // Registrations it depends on, transitively
+}
+
+// reachable returns the keys of the registrations reg depends on, directly or
+// transitively.
+func (g *dependencyGraph) reachable(reg *registration) map[registrationKey]bool {
+	deps := make(map[registrationKey]bool)
+	var visit func(from *registration)
+	visit = func(from *registration) {
+		for _, edge := range g.dependencies(from) {
+			if edge.target == nil {
+				continue
+			}
+			key := registrationKey{typ: edge.target.targetType, name: edge.target.name}
+			if !deps[key] {
+				deps[key] = true
+				visit(edge.target)
+			}
+		}
+	}
+	visit(reg)
+	return deps
+}
+
+// runningServices returns the hosted services started by the last Start that
+// have not exited or been stopped, with their dependencies in graph. Unlike
+// c.started, it can be read while Start is running, such as from a service's
+// Start.
+func (c *Container) runningServices(graph *dependencyGraph) []runningService {
+	c.mu.RLock()
+	supervisors := c.supervisors
+	c.mu.RUnlock()
+
+	var running []runningService
+	for _, s := range supervisors {
+		if state := s.stats().State; state == ServiceStopped || state == ServiceFailed {
+			continue
+		}
+		running = append(running, runningService{service: s.service, reg: s.reg, deps: graph.reachable(s.reg)})
+	}
+	return running
+}
+
+// isRunning reports whether reg is the registration of one of services.
+func isRunning(services []runningService, reg *registration) bool {
+	for _, s := range services {
+		if s.reg == reg {
+			return true
+		}
+	}
+	return false
+}
+
+// shutdownOrder returns the order to stop services in: reverse start order,
+// except that a service is stopped before the services it depends on, so no
+// service is left running against a stopped dependency. Services on a
+// dependency cycle keep reverse start order.
+func shutdownOrder(services []runningService) []runningService {
+	remaining := slices.Clone(services)
+	slices.Reverse(remaining)
+
+	order := make([]runningService, 0, len(services))
+	for len(remaining) > 0 {
+		next := 0
+		for i, candidate := range remaining {
+			if !dependedOn(candidate, remaining) {
+				next = i
+				break
+			}
+		}
+		order = append(order, remaining[next])
+		remaining = slices.Delete(remaining, next, next+1)
+	}
+	return order
+}
+
+// dependedOn reports whether another of services depends on s.
+func dependedOn(s runningService, services []runningService) bool {
+	key := registrationKey{typ: s.reg.targetType, name: s.reg.name}
+	for _, other := range services {
+		if other.reg != s.reg && other.deps[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// outlivedWarnings returns a warning for each of services that depends on the
+// registration reg, whose instance is being disposed for the given reason.
+func outlivedWarnings(services []runningService, reg *registration, reason string) []Warning {
+	key := registrationKey{typ: reg.targetType, name: reg.name}
+	var warnings []Warning
+	for _, s := range services {
+		if !s.deps[key] {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			Kind: WarningOutlivedDependency,
+			Message: fmt.Sprintf("hosted service %s still references %s, which is being disposed: %s",
+				describeRegistration(s.reg.targetType, s.reg.name), describeRegistration(reg.targetType, reg.name), reason),
+			Registrations: []RegistrationInfo{s.reg.info(), reg.info()},
+		})
+	}
+	return warnings
+}
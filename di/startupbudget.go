@@ -0,0 +1,167 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxStartupTimings is how many of the slowest constructions a startup budget
+// report lists.
+const maxStartupTimings = 5
+
+// WithStartupBudget makes [Container.Start] fail with
+// [ErrStartupBudgetExceeded] when constructing the eager singletons and
+// hosted services takes longer than limit in total, so cold-start regressions
+// are caught as the dependency graph grows.
+//
+// The time is measured phase by phase (see [Phase]); Start fails after the
+// phase that exhausts the budget, before starting its services, and stops the
+// services of earlier phases. Time spent in the services' Start methods does
+// not count. Use [WithStartupBudgetWarning] to report an exceeded budget
+// without failing.
+//
+// Example:
+//
+//	c := di.New(di.WithStartupBudget(2 * time.Second))
+//	if err := c.Start(ctx); err != nil {
+//	    var slow di.ErrStartupBudgetExceeded
+//	    if errors.As(err, &slow) {
+//	        log.Printf("slowest constructions: %v", slow.Slowest)
+//	    }
+//	}
+func WithStartupBudget(limit time.Duration) ContainerOption {
+	return func(c *Container) {
+		c.startupBudget = limit
+		c.startupBudgetWarns = false
+	}
+}
+
+// WithStartupBudgetWarning is like [WithStartupBudget], but an exceeded budget
+// is reported once Start completes as a [WarningStartupBudget] warning
+// through [Hooks.OnWarning] instead of failing Start.
+//
+// Example:
+//
+//	c := di.New(
+//	    di.WithStartupBudgetWarning(2*time.Second),
+//	    di.WithHooks(di.Hooks{OnWarning: func(w di.Warning) { log.Print(w) }}),
+//	)
+func WithStartupBudgetWarning(limit time.Duration) ContainerOption {
+	return func(c *Container) {
+		c.startupBudget = limit
+		c.startupBudgetWarns = true
+	}
+}
+
+// StartupTiming reports the time a registration's factory spent constructing
+// instances during [Container.Start].
+type StartupTiming struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Duration is the factory time, including the resolution of dependencies.
+	Duration time.Duration
+}
+
+// String renders the registration and its duration.
+func (t StartupTiming) String() string {
+	return fmt.Sprintf("%s (%s)", describeRegistration(t.Type, t.Name), t.Duration)
+}
+
+// ErrStartupBudgetExceeded is returned by [Container.Start] when eager
+// construction takes longer than the budget set with [WithStartupBudget].
+type ErrStartupBudgetExceeded struct {
+	// Budget is the startup budget.
+	Budget time.Duration
+	// Elapsed is the construction time when the budget was found exceeded.
+	Elapsed time.Duration
+	// Slowest lists the registrations whose factories took longest during
+	// startup, slowest first.
+	Slowest []StartupTiming
+}
+
+func (e ErrStartupBudgetExceeded) Error() string {
+	msg := fmt.Sprintf("di: startup construction took %s, over its %s budget", e.Elapsed, e.Budget)
+	if len(e.Slowest) == 0 {
+		return msg
+	}
+	slowest := make([]string, len(e.Slowest))
+	for i, t := range e.Slowest {
+		slowest[i] = t.String()
+	}
+	return msg + "; slowest: " + strings.Join(slowest, ", ")
+}
+
+// startupClock measures eager construction against the startup budget.
+type startupClock struct {
+	c       *Container
+	before  map[*registration]time.Duration // Factory time per registration before Start
+	elapsed time.Duration
+}
+
+// newStartupClock starts measuring, or returns nil if there is no budget.
+func (c *Container) newStartupClock() *startupClock {
+	if c.startupBudget <= 0 {
+		return nil
+	}
+	before := make(map[*registration]time.Duration)
+	for _, reg := range c.orderedRegistrations() {
+		before[reg] = time.Duration(reg.stats.totalNanos.Load())
+	}
+	return &startupClock{c: c, before: before}
+}
+
+// add records the construction time of a phase and returns the error Start
+// fails with if the budget is exhausted and exceeding it is fatal.
+func (s *startupClock) add(d time.Duration) error {
+	if s == nil {
+		return nil
+	}
+	s.elapsed += d
+	if s.elapsed <= s.c.startupBudget || s.c.startupBudgetWarns {
+		return nil
+	}
+	return s.exceeded()
+}
+
+// finish reports an exceeded budget as a warning once Start has completed.
+func (s *startupClock) finish() {
+	if s == nil || s.elapsed <= s.c.startupBudget || !s.c.startupBudgetWarns {
+		return
+	}
+	err := s.exceeded()
+	infos := make([]RegistrationInfo, 0, len(err.Slowest))
+	for _, t := range err.Slowest {
+		for reg := range s.before {
+			if reg.targetType == t.Type && reg.name == t.Name {
+				infos = append(infos, reg.info())
+				break
+			}
+		}
+	}
+	s.c.emitWarnings([]Warning{{
+		Kind:          WarningStartupBudget,
+		Message:       strings.TrimPrefix(err.Error(), "di: "),
+		Registrations: infos,
+	}})
+}
+
+// exceeded builds the report of the slowest startup constructions.
+func (s *startupClock) exceeded() ErrStartupBudgetExceeded {
+	var timings []StartupTiming
+	for _, reg := range s.c.orderedRegistrations() {
+		spent := time.Duration(reg.stats.totalNanos.Load()) - s.before[reg]
+		if spent > 0 {
+			timings = append(timings, StartupTiming{Type: reg.targetType, Name: reg.name, Duration: spent})
+		}
+	}
+	sort.SliceStable(timings, func(i, j int) bool { return timings[i].Duration > timings[j].Duration })
+	if len(timings) > maxStartupTimings {
+		timings = timings[:maxStartupTimings]
+	}
+	return ErrStartupBudgetExceeded{Budget: s.c.startupBudget, Elapsed: s.elapsed, Slowest: timings}
+}
@@ -0,0 +1,56 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Startup Budget Tests
+// =============================================================================
+
+func TestStartupBudgetFailsStart(t *testing.T) {
+	c := di.New(di.WithStartupBudget(time.Millisecond))
+	di.Register[*closableResource](c, func() *closableResource {
+		time.Sleep(5 * time.Millisecond)
+		return &closableResource{}
+	}, di.AsSingleton(), di.Eager())
+
+	err := c.Start(context.Background())
+	var exceeded di.ErrStartupBudgetExceeded
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("expected ErrStartupBudgetExceeded, got %v", err)
+	}
+	if len(exceeded.Slowest) != 1 || exceeded.Slowest[0].Duration < 5*time.Millisecond {
+		t.Errorf("expected the slow singleton to be reported, got %+v", exceeded.Slowest)
+	}
+	if c.CheckReady() == nil {
+		t.Error("expected the container not to be ready")
+	}
+}
+
+func TestStartupBudgetWarning(t *testing.T) {
+	var warnings []di.Warning
+	c := di.New(
+		di.WithStartupBudgetWarning(time.Millisecond),
+		di.WithHooks(di.Hooks{OnWarning: func(w di.Warning) { warnings = append(warnings, w) }}),
+	)
+	di.Register[*closableResource](c, func() *closableResource {
+		time.Sleep(5 * time.Millisecond)
+		return &closableResource{}
+	}, di.AsSingleton(), di.Eager())
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("expected Start to succeed, got %v", err)
+	}
+	defer c.Stop(context.Background())
+	if len(warnings) != 1 || warnings[0].Kind != di.WarningStartupBudget || len(warnings[0].Registrations) != 1 {
+		t.Errorf("expected one startup budget warning, got %+v", warnings)
+	}
+}
@@ -0,0 +1,114 @@
+package di
+
+import "reflect"
+
+// ContainerStatus summarizes where a container is in its lifecycle.
+type ContainerStatus string
+
+const (
+	// StatusCold means [Container.Start] has not completed: it was never
+	// called, failed, or was followed by [Container.Stop].
+	StatusCold ContainerStatus = "cold"
+	// StatusStarting means Start is constructing singletons and starting
+	// services.
+	StatusStarting ContainerStatus = "starting"
+	// StatusReady means Start completed and every hosted service is running.
+	StatusReady ContainerStatus = "ready"
+	// StatusDegraded means Start completed but a hosted service has since
+	// exited, is restarting, or failed.
+	StatusDegraded ContainerStatus = "degraded"
+)
+
+// ContainerState is a point-in-time snapshot of a container's startup state,
+// returned by [Container.State].
+type ContainerState struct {
+	// Status summarizes the state.
+	Status ContainerStatus
+	// Components describes the eager singletons and hosted services that
+	// Start constructs, in startup order.
+	Components []ComponentState
+}
+
+// ComponentState describes one eager singleton or hosted service.
+type ComponentState struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Phase is the startup phase (see [Phase]), or "" for none.
+	Phase string
+	// Eager reports whether the registration is an eager singleton.
+	Eager bool
+	// HostedService reports whether the registration implements [HostedService].
+	HostedService bool
+	// Constructed reports whether a singleton instance exists.
+	Constructed bool
+	// Service is the supervision state of the hosted service started by the
+	// last Start, or "" if it has not been started.
+	Service ServiceState
+}
+
+// State reports whether each eager singleton has been constructed and each
+// hosted service started, so startup and readiness probes can tell a
+// container that is still booting from one that is degraded.
+//
+// Unlike [Container.CheckReady], which only says whether the container is
+// ready, State describes every component. It never constructs anything.
+//
+// Example:
+//
+//	state := container.State()
+//	if state.Status == di.StatusStarting {
+//	    for _, comp := range state.Components {
+//	        if !comp.Constructed {
+//	            log.Printf("waiting for %s", comp.Type)
+//	        }
+//	    }
+//	}
+func (c *Container) State() ContainerState {
+	var regs []*registration
+	for _, phase := range c.startupPhases() {
+		regs = append(regs, phase.regs...)
+	}
+
+	c.mu.RLock()
+	supervisors := c.supervisors
+	constructed := make([]bool, len(regs))
+	for i, reg := range regs {
+		_, constructed[i] = c.singletons[registrationKey{typ: reg.targetType, name: reg.name}]
+	}
+	c.mu.RUnlock()
+
+	services := make(map[*registration]ServiceState, len(supervisors))
+	degraded := false
+	for _, s := range supervisors {
+		stats := s.stats()
+		services[s.reg] = stats.State
+		degraded = degraded || stats.State != ServiceRunning
+	}
+
+	state := ContainerState{Components: make([]ComponentState, len(regs))}
+	for i, reg := range regs {
+		state.Components[i] = ComponentState{
+			Type:          reg.targetType,
+			Name:          reg.name,
+			Phase:         reg.phase,
+			Eager:         reg.startsEagerly(),
+			HostedService: reg.implements(hostedServiceType),
+			Constructed:   constructed[i],
+			Service:       services[reg],
+		}
+	}
+
+	switch {
+	case c.starting.Load():
+		state.Status = StatusStarting
+	case !c.ready.Load():
+		state.Status = StatusCold
+	case degraded:
+		state.Status = StatusDegraded
+	default:
+		state.Status = StatusReady
+	}
+	return state
+}
@@ -0,0 +1,52 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Container State Tests
+// =============================================================================
+
+func TestContainerState(t *testing.T) {
+	c := di.New()
+	var events []string
+	var during di.ContainerStatus
+	di.Register[*TestLogger](c, func() *TestLogger {
+		during = c.State().Status
+		return &TestLogger{}
+	}, di.AsSingleton(), di.Eager())
+	di.RegisterInstance[di.HostedService](c, &recordingService{name: "a", events: &events})
+
+	state := c.State()
+	if state.Status != di.StatusCold || len(state.Components) != 2 {
+		t.Fatalf("expected a cold container with two components, got %+v", state)
+	}
+	if state.Components[0].Constructed || !state.Components[0].Eager {
+		t.Errorf("expected the eager singleton to be unconstructed, got %+v", state.Components[0])
+	}
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	if during != di.StatusStarting {
+		t.Errorf("expected the status to be starting during Start, got %q", during)
+	}
+	state = c.State()
+	if state.Status != di.StatusReady {
+		t.Errorf("expected a ready container, got %q", state.Status)
+	}
+	if !state.Components[0].Constructed || state.Components[1].Service != di.ServiceRunning || !state.Components[1].HostedService {
+		t.Errorf("expected constructed and running components, got %+v", state.Components)
+	}
+
+	c.Stop(context.Background())
+	if state := c.State(); state.Status != di.StatusCold || state.Components[1].Service != di.ServiceStopped {
+		t.Errorf("expected a cold container with a stopped service, got %+v", state)
+	}
+}
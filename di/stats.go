@@ -0,0 +1,301 @@
+package di
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a point-in-time snapshot of a container's resolution statistics.
+//
+// Counters accumulate from the time a registration is made until the container
+// is cleared. Nested resolutions are counted too, so resolving a service with
+// two dependencies counts three resolutions.
+//
+// Use [Container.Stats] to take a snapshot, or [Handler] to expose the statistics
+// over HTTP.
+type Stats struct {
+	// Resolutions is the total number of resolutions of registered types.
+	Resolutions uint64
+	// CacheHits is the number of resolutions served from a singleton, scope,
+	// or instance cache without invoking a factory.
+	CacheHits uint64
+	// Constructions is the number of successful factory invocations.
+	Constructions uint64
+	// Errors is the number of failed resolutions, including attempts to
+	// resolve unregistered types.
+	Errors uint64
+	// ConstructionErrors is the number of constructions that failed in the
+	// factory or its validators (see RegistrationStats.ConstructionErrors).
+	ConstructionErrors uint64
+	// FactoryDuration is the total time spent in factories, including the
+	// resolution of their dependencies.
+	FactoryDuration time.Duration
+	// Registrations holds per-registration statistics, in registration order.
+	Registrations []RegistrationStats
+	// Services describes the hosted services started by the last
+	// [Container.Start], in start order.
+	Services []ServiceStats
+	// Lifetimes aggregates the registration statistics by lifetime, in the
+	// order Transient, Singleton, Scoped, ScopeFamily.
+	Lifetimes []LifetimeStats
+}
+
+// HitRatio returns the fraction of resolutions served from a cache, or 0 if
+// there were no resolutions.
+func (s Stats) HitRatio() float64 {
+	return hitRatio(s.CacheHits, s.Resolutions)
+}
+
+// LifetimeStats aggregates resolution statistics for the registrations of one
+// lifetime, to show how effective singleton and scoped caching is.
+type LifetimeStats struct {
+	// Lifetime is the lifetime the statistics cover.
+	Lifetime Lifetime
+	// Registrations is the number of registrations with this lifetime.
+	Registrations int
+	// Resolutions is the total number of resolutions.
+	Resolutions uint64
+	// CacheHits is the number of resolutions served without invoking a factory.
+	CacheHits uint64
+	// Constructions is the number of successful factory invocations.
+	Constructions uint64
+	// Errors is the number of failed resolutions.
+	Errors uint64
+	// ConstructionsLastMinute is the number of constructions in the last minute.
+	ConstructionsLastMinute uint64
+	// ConstructionsLastHour is the number of constructions in the last hour.
+	ConstructionsLastHour uint64
+}
+
+// HitRatio returns the fraction of resolutions served from a cache, or 0 if
+// there were no resolutions.
+func (s LifetimeStats) HitRatio() float64 {
+	return hitRatio(s.CacheHits, s.Resolutions)
+}
+
+// RegistrationStats holds resolution statistics for a single registration.
+type RegistrationStats struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Lifetime is the registration's lifetime.
+	Lifetime Lifetime
+	// Resolutions is the number of times the registration was resolved.
+	Resolutions uint64
+	// CacheHits is the number of resolutions served without invoking the factory.
+	CacheHits uint64
+	// Constructions is the number of successful factory invocations.
+	Constructions uint64
+	// Errors is the number of failed resolutions.
+	Errors uint64
+	// ConstructionErrors is the number of constructions that failed in the
+	// registration's own factory or validators, as opposed to failures to
+	// resolve its dependencies, which are counted on the dependencies.
+	ConstructionErrors uint64
+	// ConstructionErrorsLastHour is the number of construction errors in the
+	// last hour.
+	ConstructionErrorsLastHour uint64
+	// LastConstructionError is the message of the most recent construction
+	// error, or "" if there was none. Factory errors can embed secrets such as
+	// connection strings, and the message bypasses [Hooks.InterceptError], so
+	// it is left out of the JSON encoding served by [Handler].
+	LastConstructionError string
+	// TotalFactoryDuration is the total time spent constructing instances.
+	TotalFactoryDuration time.Duration
+	// MaxFactoryDuration is the slowest single construction.
+	MaxFactoryDuration time.Duration
+	// ConstructionsLastMinute is the number of constructions in the last minute.
+	ConstructionsLastMinute uint64
+	// ConstructionsLastHour is the number of constructions in the last hour.
+	ConstructionsLastHour uint64
+}
+
+// HitRatio returns the fraction of resolutions served from a cache, or 0 if
+// there were no resolutions.
+func (s RegistrationStats) HitRatio() float64 {
+	return hitRatio(s.CacheHits, s.Resolutions)
+}
+
+// Misses returns the number of resolutions that were not served from a cache.
+func (s RegistrationStats) Misses() uint64 {
+	return s.Resolutions - min(s.CacheHits, s.Resolutions)
+}
+
+// hitRatio returns hits/total, or 0 if total is 0.
+func hitRatio(hits, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total)
+}
+
+// Stats returns a snapshot of the container's resolution statistics.
+//
+// Example:
+//
+//	stats := container.Stats()
+//	fmt.Printf("%d resolutions, %d cache hits\n", stats.Resolutions, stats.CacheHits)
+//	for _, r := range stats.Registrations {
+//	    fmt.Printf("%s: built %d times in %s\n", r.Type, r.Constructions, r.TotalFactoryDuration)
+//	}
+func (c *Container) Stats() Stats {
+	regs := c.orderedRegistrations()
+
+	stats := Stats{
+		Errors:        c.stats.unregistered.Load(),
+		Registrations: make([]RegistrationStats, 0, len(regs)),
+		Lifetimes: []LifetimeStats{
+			{Lifetime: Transient},
+			{Lifetime: Singleton},
+			{Lifetime: Scoped},
+			{Lifetime: ScopeFamily},
+		},
+	}
+	now := time.Now()
+	for _, reg := range regs {
+		rs := reg.stats.snapshot(reg, now)
+		stats.Resolutions += rs.Resolutions
+		stats.CacheHits += rs.CacheHits
+		stats.Constructions += rs.Constructions
+		stats.Errors += rs.Errors
+		stats.ConstructionErrors += rs.ConstructionErrors
+		stats.FactoryDuration += rs.TotalFactoryDuration
+		stats.Registrations = append(stats.Registrations, rs)
+
+		if int(rs.Lifetime) < len(stats.Lifetimes) {
+			ls := &stats.Lifetimes[rs.Lifetime]
+			ls.Registrations++
+			ls.Resolutions += rs.Resolutions
+			ls.CacheHits += rs.CacheHits
+			ls.Constructions += rs.Constructions
+			ls.Errors += rs.Errors
+			ls.ConstructionsLastMinute += rs.ConstructionsLastMinute
+			ls.ConstructionsLastHour += rs.ConstructionsLastHour
+		}
+	}
+
+	c.mu.RLock()
+	supervisors := c.supervisors
+	c.mu.RUnlock()
+	for _, s := range supervisors {
+		stats.Services = append(stats.Services, s.stats())
+	}
+
+	return stats
+}
+
+// containerStats holds counters that are not tied to a registration.
+type containerStats struct {
+	unregistered atomic.Uint64
+}
+
+// reset zeroes the container-level counters.
+func (s *containerStats) reset() {
+	s.unregistered.Store(0)
+}
+
+// registrationStats holds the live counters for a registration.
+type registrationStats struct {
+	resolutions   atomic.Uint64
+	cacheHits     atomic.Uint64
+	constructions atomic.Uint64
+	errors        atomic.Uint64
+	totalNanos    atomic.Int64
+	maxNanos      atomic.Int64
+	recent        constructionWindow
+
+	constructionErrors atomic.Uint64
+	recentErrors       constructionWindow
+	lastError          atomic.Pointer[string]
+}
+
+// recordConstructionError records a failure of the registration's factory or
+// validators.
+func (s *registrationStats) recordConstructionError(err error) {
+	s.constructionErrors.Add(1)
+	s.recentErrors.record(time.Now())
+	msg := err.Error()
+	s.lastError.Store(&msg)
+}
+
+// recordConstruction records a successful factory invocation of duration d
+// that completed at end.
+func (s *registrationStats) recordConstruction(d time.Duration, end time.Time) {
+	s.constructions.Add(1)
+	s.recent.record(end)
+	s.totalNanos.Add(int64(d))
+	for {
+		current := s.maxNanos.Load()
+		if int64(d) <= current || s.maxNanos.CompareAndSwap(current, int64(d)) {
+			return
+		}
+	}
+}
+
+// snapshot copies the counters into a RegistrationStats.
+func (s *registrationStats) snapshot(reg *registration, now time.Time) RegistrationStats {
+	var lastError string
+	if msg := s.lastError.Load(); msg != nil {
+		lastError = *msg
+	}
+	return RegistrationStats{
+		Type:                 reg.targetType,
+		Name:                 reg.name,
+		Lifetime:             reg.lifetime,
+		Resolutions:          s.resolutions.Load(),
+		CacheHits:            s.cacheHits.Load(),
+		Constructions:        s.constructions.Load(),
+		Errors:               s.errors.Load(),
+		TotalFactoryDuration: time.Duration(s.totalNanos.Load()),
+		MaxFactoryDuration:   time.Duration(s.maxNanos.Load()),
+
+		ConstructionsLastMinute: s.recent.sum(now, 1),
+		ConstructionsLastHour:   s.recent.sum(now, windowMinutes),
+
+		ConstructionErrors:         s.constructionErrors.Load(),
+		ConstructionErrorsLastHour: s.recentErrors.sum(now, windowMinutes),
+		LastConstructionError:      lastError,
+	}
+}
+
+// windowMinutes is the span of a constructionWindow, in one-minute buckets.
+const windowMinutes = 60
+
+// constructionWindow counts events, such as constructions, per minute over the
+// last hour.
+//
+// Each bucket holds the count for one minute and the minute it belongs to, so
+// stale buckets are recognized and reset lazily. Counts recorded concurrently
+// with a reset may be lost, which is acceptable for statistics.
+type constructionWindow struct {
+	buckets [windowMinutes]struct {
+		minute atomic.Int64
+		count  atomic.Uint64
+	}
+}
+
+// record counts one event at now.
+func (w *constructionWindow) record(now time.Time) {
+	minute := now.Unix() / 60
+	b := &w.buckets[minute%windowMinutes]
+	if current := b.minute.Load(); current != minute && b.minute.CompareAndSwap(current, minute) {
+		b.count.Store(0)
+	}
+	b.count.Add(1)
+}
+
+// sum returns the events counted in the last n minutes, including the current
+// one.
+func (w *constructionWindow) sum(now time.Time, n int) uint64 {
+	minute := now.Unix() / 60
+	var total uint64
+	for i := range w.buckets {
+		b := &w.buckets[i]
+		if age := minute - b.minute.Load(); age >= 0 && age < int64(n) {
+			total += b.count.Load()
+		}
+	}
+	return total
+}
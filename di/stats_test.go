@@ -0,0 +1,248 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Stats Tests
+// =============================================================================
+
+func TestStatsCountsResolutions(t *testing.T) {
+	c := di.New()
+
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton())
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.Register[Service](c, func(log Logger, g Greeter) Service {
+		return &DefaultService{logger: log, greeter: g}
+	})
+
+	di.Resolve[Service](c)
+	di.Resolve[Service](c)
+
+	stats := c.Stats()
+	if stats.Resolutions != 6 {
+		t.Errorf("expected 6 resolutions, got %d", stats.Resolutions)
+	}
+	if stats.Constructions != 5 {
+		t.Errorf("expected 5 constructions, got %d", stats.Constructions)
+	}
+	if stats.CacheHits != 1 {
+		t.Errorf("expected 1 cache hit, got %d", stats.CacheHits)
+	}
+	if len(stats.Registrations) != 3 {
+		t.Fatalf("expected 3 registration stats, got %d", len(stats.Registrations))
+	}
+
+	for _, r := range stats.Registrations {
+		if r.Lifetime == di.Singleton && (r.Constructions != 1 || r.CacheHits != 1) {
+			t.Errorf("unexpected singleton stats: %+v", r)
+		}
+	}
+}
+
+func TestStatsCountsErrors(t *testing.T) {
+	c := di.New()
+
+	di.Register[Greeter](c, func() (Greeter, error) {
+		return nil, errors.New("boom")
+	})
+
+	di.Resolve[Greeter](c)
+	di.Resolve[Logger](c)
+
+	stats := c.Stats()
+	if stats.Errors != 2 {
+		t.Errorf("expected 2 errors, got %d", stats.Errors)
+	}
+	if stats.Registrations[0].Errors != 1 {
+		t.Errorf("expected 1 registration error, got %d", stats.Registrations[0].Errors)
+	}
+}
+
+func TestClearResetsStats(t *testing.T) {
+	c := di.New()
+	di.Resolve[Logger](c)
+
+	c.Clear()
+
+	if stats := c.Stats(); stats.Errors != 0 {
+		t.Errorf("expected stats to be reset, got %+v", stats)
+	}
+}
+
+func TestStatsBreaksDownByLifetime(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.AsSingleton())
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+
+	for range 4 {
+		di.Resolve[Logger](c)
+		di.Resolve[Greeter](c)
+	}
+
+	stats := c.Stats()
+	if len(stats.Lifetimes) != 4 {
+		t.Fatalf("expected 4 lifetime stats, got %d", len(stats.Lifetimes))
+	}
+	singleton := stats.Lifetimes[di.Singleton]
+	if singleton.Lifetime != di.Singleton || singleton.Registrations != 1 || singleton.Constructions != 1 {
+		t.Errorf("unexpected singleton stats: %+v", singleton)
+	}
+	if ratio := singleton.HitRatio(); ratio != 0.75 {
+		t.Errorf("expected singleton hit ratio 0.75, got %v", ratio)
+	}
+	if ratio := stats.Lifetimes[di.Transient].HitRatio(); ratio != 0 {
+		t.Errorf("expected transient hit ratio 0, got %v", ratio)
+	}
+	if ratio := stats.HitRatio(); ratio != 3.0/8 {
+		t.Errorf("expected overall hit ratio 0.375, got %v", ratio)
+	}
+	if stats.Lifetimes[di.Transient].ConstructionsLastMinute != 4 {
+		t.Errorf("expected 4 transient constructions in the last minute, got %+v", stats.Lifetimes[di.Transient])
+	}
+
+	for _, r := range stats.Registrations {
+		if r.ConstructionsLastHour != r.Constructions {
+			t.Errorf("expected every construction within the last hour, got %+v", r)
+		}
+		if r.Misses() != r.Constructions {
+			t.Errorf("expected misses to equal constructions, got %+v", r)
+		}
+	}
+}
+
+func TestStatsCountsConstructionErrors(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() (Logger, error) {
+		return nil, errors.New("dial tcp: connection refused")
+	})
+	di.Register[Service](c, func(log Logger) Service { return &DefaultService{logger: log} })
+
+	di.Resolve[Logger](c)
+	di.Resolve[Service](c)
+
+	stats := c.Stats()
+	if stats.ConstructionErrors != 2 {
+		t.Errorf("expected 2 construction errors, got %d", stats.ConstructionErrors)
+	}
+	for _, r := range stats.Registrations {
+		switch r.Type.String() {
+		case "di_test.Logger":
+			if r.ConstructionErrors != 2 || r.ConstructionErrorsLastHour != 2 {
+				t.Errorf("expected the failing factory to be counted twice, got %+v", r)
+			}
+			if r.LastConstructionError != "dial tcp: connection refused" {
+				t.Errorf("expected the last error message, got %q", r.LastConstructionError)
+			}
+		case "di_test.Service":
+			if r.ConstructionErrors != 0 || r.Errors != 1 {
+				t.Errorf("expected a dependency failure not to count as a construction error, got %+v", r)
+			}
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	di.Handler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics?format=prometheus", nil))
+	want := `di_construction_errors_total{type="di_test.Logger",name="",lifetime="Transient"} 2`
+	if !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected output to contain %q\n%s", want, rec.Body.String())
+	}
+}
+
+func TestHandlerOmitsConstructionErrorMessages(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() (Logger, error) {
+		return nil, errors.New("dial postgres://admin:hunter2@db: connection refused")
+	})
+	di.Resolve[Logger](c)
+
+	rec := httptest.NewRecorder()
+	di.Handler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if body := rec.Body.String(); strings.Contains(body, "hunter2") || !strings.Contains(body, `"construction_errors":1`) {
+		t.Errorf("expected the error to be counted but its message omitted\n%s", body)
+	}
+}
+
+// =============================================================================
+// Metrics Handler Tests
+// =============================================================================
+
+func TestHandlerJSON(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("simple"))
+	di.ResolveNamed[Greeter](c, "simple")
+
+	rec := httptest.NewRecorder()
+	di.Handler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/di", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("expected JSON content type, got %s", ct)
+	}
+
+	var body struct {
+		Resolutions   uint64 `json:"resolutions"`
+		Registrations []struct {
+			Type        string `json:"type"`
+			Name        string `json:"name"`
+			Resolutions uint64 `json:"resolutions"`
+		} `json:"registrations"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if body.Resolutions != 1 || len(body.Registrations) != 1 {
+		t.Fatalf("unexpected body: %s", rec.Body.String())
+	}
+	if body.Registrations[0].Name != "simple" || body.Registrations[0].Type != "di_test.Greeter" {
+		t.Errorf("unexpected registration: %+v", body.Registrations[0])
+	}
+}
+
+func TestHandlerPrometheus(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.AsSingleton())
+	di.Resolve[Greeter](c)
+	di.Resolve[Logger](c)
+
+	rec := httptest.NewRecorder()
+	di.Handler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics?format=prometheus", nil))
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE di_resolutions_total counter",
+		`di_resolutions_total{type="di_test.Greeter",name="",lifetime="Singleton"} 1`,
+		`di_constructions_total{type="di_test.Greeter",name="",lifetime="Singleton"} 1`,
+		"di_unregistered_resolutions_total 1",
+		`di_constructions_last_hour{type="di_test.Greeter",name="",lifetime="Singleton"} 1`,
+		`di_lifetime_cache_hit_ratio{lifetime="Singleton"} 0`,
+		`di_factory_duration_seconds_count{type="di_test.Greeter",name="",lifetime="Singleton"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected output to contain %q\n%s", want, body)
+		}
+	}
+}
+
+func TestHandlerPrometheusAcceptHeader(t *testing.T) {
+	c := di.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "text/plain;version=0.0.4")
+	rec := httptest.NewRecorder()
+	di.Handler(c).ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("expected text/plain content type, got %s", ct)
+	}
+}
@@ -0,0 +1,329 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Default backoff bounds used when a [RestartPolicy] leaves them unset.
+const (
+	defaultRestartBackoff    = time.Second
+	defaultRestartMaxBackoff = 30 * time.Second
+)
+
+// RestartMode selects when a supervised hosted service is restarted after
+// its background work ends.
+type RestartMode int
+
+const (
+	// RestartNever leaves a service down once it exits. This is the default.
+	RestartNever RestartMode = iota
+	// RestartOnFailure restarts a service that exits with an error.
+	RestartOnFailure
+	// RestartAlways restarts a service whenever it exits, with or without an
+	// error.
+	RestartAlways
+)
+
+// String returns the name of the restart mode.
+func (m RestartMode) String() string {
+	switch m {
+	case RestartNever:
+		return "Never"
+	case RestartOnFailure:
+		return "OnFailure"
+	case RestartAlways:
+		return "Always"
+	default:
+		return fmt.Sprintf("RestartMode(%d)", m)
+	}
+}
+
+// RestartPolicy controls how the container restarts a [SupervisedService].
+type RestartPolicy struct {
+	// Mode selects which exits cause a restart.
+	Mode RestartMode
+	// Backoff is the delay before the first restart. It doubles after each
+	// restart, up to MaxBackoff. Zero means one second.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between restarts. Zero means 30 seconds.
+	MaxBackoff time.Duration
+	// MaxRestarts is the number of restarts after which the service is left
+	// down. Zero means no limit.
+	MaxRestarts int
+}
+
+// restarts reports whether the policy restarts a service that exited with
+// err after the given number of restarts.
+func (p RestartPolicy) restarts(err error, restarts uint64) bool {
+	if p.MaxRestarts > 0 && restarts >= uint64(p.MaxRestarts) {
+		return false
+	}
+	switch p.Mode {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// WithRestartPolicy sets the restart policy for a hosted service that
+// implements [SupervisedService].
+//
+// After [Container.Start] starts the service, the container watches its Done
+// channel. When the service exits and the policy calls for a restart, the
+// container waits for the backoff delay and calls Start again on the same
+// instance, so the service must support being started again after it exits.
+// A restart whose Start fails counts as another exit. Supervision ends when
+// [Container.Stop] is called: pending restarts are abandoned before services
+// are stopped.
+//
+// Exits and restarts are reported through [Hooks.OnServiceExit], and each
+// service's state and restart count are listed in [Stats].Services.
+//
+// Example:
+//
+//	di.Register[*QueueConsumer](c, NewQueueConsumer, di.AsSingleton(),
+//	    di.WithRestartPolicy(di.RestartPolicy{
+//	        Mode:        di.RestartOnFailure,
+//	        Backoff:     time.Second,
+//	        MaxBackoff:  time.Minute,
+//	        MaxRestarts: 10,
+//	    }))
+func WithRestartPolicy(policy RestartPolicy) RegistrationOption {
+	return func(r *registration) {
+		r.restart = policy
+	}
+}
+
+// SupervisedService is a [HostedService] that reports when its background
+// work ends on its own, so the container can detect and restart services that
+// die after starting.
+type SupervisedService interface {
+	HostedService
+	// Done returns a channel that receives the error that ended the service's
+	// background work, or nil for a clean exit, after a successful Start. Each
+	// Start may return a new channel.
+	Done() <-chan error
+}
+
+// ServiceState is the supervision state of a hosted service.
+type ServiceState string
+
+const (
+	// ServiceRunning means the service started and has not exited.
+	ServiceRunning ServiceState = "running"
+	// ServiceRestarting means the service exited and is waiting to be
+	// restarted.
+	ServiceRestarting ServiceState = "restarting"
+	// ServiceFailed means the service exited with an error and will not be
+	// restarted.
+	ServiceFailed ServiceState = "failed"
+	// ServiceStopped means the service exited cleanly or was stopped by
+	// [Container.Stop].
+	ServiceStopped ServiceState = "stopped"
+)
+
+// ServiceStats describes a hosted service started by [Container.Start].
+type ServiceStats struct {
+	// Type is the registered type of the service.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// State is the service's supervision state.
+	State ServiceState
+	// Restarts is the number of restarts attempted.
+	Restarts uint64
+	// LastError is the message of the error from the most recent failed exit
+	// or restart, or "" if there was none. Service errors can embed secrets
+	// such as connection strings, so it is left out of the JSON encoding
+	// served by [Handler].
+	LastError string
+}
+
+// ServiceExit describes a hosted service whose background work ended. It is
+// reported through [Hooks.OnServiceExit].
+type ServiceExit struct {
+	// Type is the registered type of the service.
+	Type reflect.Type
+	// Name is the registration name, or "" for unnamed registrations.
+	Name string
+	// Err is the error that ended the service, or nil for a clean exit.
+	Err error
+	// Restarts is the number of restarts attempted before this exit.
+	Restarts uint64
+	// Restarting reports whether the service will be restarted.
+	Restarting bool
+	// Delay is the backoff before the restart, if Restarting.
+	Delay time.Duration
+}
+
+// supervisor tracks a started hosted service and restarts it according to
+// its registration's policy.
+type supervisor struct {
+	reg     *registration
+	service HostedService
+	cancel  context.CancelFunc
+	done    chan struct{} // Closed when supervision ends
+
+	mu       sync.Mutex
+	state    ServiceState
+	restarts uint64
+	lastErr  error
+}
+
+// supervise records a started service and, if it reports exits, watches it
+// in the background. The supervision context keeps ctx's values but not its
+// cancellation, so a startup deadline does not end supervision.
+func (c *Container) supervise(ctx context.Context, reg *registration, service HostedService) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s := &supervisor{
+		reg:     reg,
+		service: service,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		state:   ServiceRunning,
+	}
+
+	c.mu.Lock()
+	c.supervisors = append(c.supervisors, s)
+	c.mu.Unlock()
+
+	supervised, ok := service.(SupervisedService)
+	if !ok {
+		close(s.done)
+		return
+	}
+	go s.run(ctx, c, supervised)
+}
+
+// run watches a supervised service until ctx is done, restarting it as its
+// policy allows.
+func (s *supervisor) run(ctx context.Context, c *Container, service SupervisedService) {
+	defer close(s.done)
+
+	policy := s.reg.restart
+	backoff := policy.Backoff
+	if backoff <= 0 {
+		backoff = defaultRestartBackoff
+	}
+	maxBackoff := policy.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRestartMaxBackoff
+	}
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case err = <-service.Done():
+		}
+
+		for {
+			delay := min(backoff, maxBackoff)
+			if !s.exited(c, err, delay) {
+				return
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			backoff = min(backoff*2, maxBackoff)
+
+			if err = service.Start(ctx); err == nil {
+				s.setState(ServiceRunning)
+				break
+			}
+			err = fmt.Errorf("restart: %w", err)
+		}
+	}
+}
+
+// exited records an exit, reports it, and returns whether the service is to
+// be restarted after delay.
+func (s *supervisor) exited(c *Container, err error, delay time.Duration) bool {
+	s.mu.Lock()
+	restarts := s.restarts
+	restarting := s.reg.restart.restarts(err, restarts)
+	switch {
+	case restarting:
+		s.state = ServiceRestarting
+		s.restarts++
+	case err != nil:
+		s.state = ServiceFailed
+	default:
+		s.state = ServiceStopped
+	}
+	if err != nil {
+		s.lastErr = err
+	}
+	s.mu.Unlock()
+
+	if c.hooks.OnServiceExit != nil {
+		exit := ServiceExit{
+			Type:       s.reg.targetType,
+			Name:       s.reg.name,
+			Err:        err,
+			Restarts:   restarts,
+			Restarting: restarting,
+		}
+		if restarting {
+			exit.Delay = delay
+		}
+		c.hooks.OnServiceExit(exit)
+	}
+	return restarting
+}
+
+// setState sets the supervision state.
+func (s *supervisor) setState(state ServiceState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+}
+
+// stats returns the service's statistics.
+func (s *supervisor) stats() ServiceStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := ServiceStats{
+		Type:     s.reg.targetType,
+		Name:     s.reg.name,
+		State:    s.state,
+		Restarts: s.restarts,
+	}
+	if s.lastErr != nil {
+		stats.LastError = s.lastErr.Error()
+	}
+	return stats
+}
+
+// endSupervision stops watching every service, waiting for pending restarts
+// to be abandoned until ctx is done, and marks the services stopped.
+func (c *Container) endSupervision(ctx context.Context) {
+	c.mu.RLock()
+	supervisors := c.supervisors
+	c.mu.RUnlock()
+
+	for _, s := range supervisors {
+		s.cancel()
+	}
+	for _, s := range supervisors {
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+		}
+		s.setState(ServiceStopped)
+	}
+}
@@ -0,0 +1,209 @@
+package di_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Hosted Service Supervision Tests
+// =============================================================================
+
+// crashingWorker is a supervised service whose exits are triggered by tests.
+type crashingWorker struct {
+	mu       sync.Mutex
+	starts   int
+	startErr error
+	done     chan error
+	started  chan struct{}
+}
+
+func newCrashingWorker() *crashingWorker {
+	return &crashingWorker{started: make(chan struct{}, 16)}
+}
+
+func (w *crashingWorker) Start(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.starts++
+	if w.startErr != nil {
+		err := w.startErr
+		w.startErr = nil
+		return err
+	}
+	w.done = make(chan error, 1)
+	w.started <- struct{}{}
+	return nil
+}
+
+func (w *crashingWorker) Stop(ctx context.Context) error { return nil }
+
+func (w *crashingWorker) Done() <-chan error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.done
+}
+
+func (w *crashingWorker) exit(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.done <- err
+}
+
+func (w *crashingWorker) waitStarted(t *testing.T) {
+	t.Helper()
+	select {
+	case <-w.started:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the worker to start")
+	}
+}
+
+func waitServiceState(t *testing.T, c *di.Container, state di.ServiceState) di.ServiceStats {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		services := c.Stats().Services
+		if len(services) == 1 && services[0].State == state {
+			return services[0]
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for state %s, got %+v", state, services)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRestartOnFailure(t *testing.T) {
+	exits := make(chan di.ServiceExit, 4)
+	c := di.New(di.WithHooks(di.Hooks{
+		OnServiceExit: func(e di.ServiceExit) { exits <- e },
+	}))
+	worker := newCrashingWorker()
+	di.RegisterInstance[di.HostedService](c, worker, di.WithRestartPolicy(di.RestartPolicy{
+		Mode:    di.RestartOnFailure,
+		Backoff: time.Millisecond,
+	}))
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	defer c.Stop(context.Background())
+	worker.waitStarted(t)
+
+	boom := errors.New("connection reset")
+	worker.exit(boom)
+	exit := <-exits
+	if !errors.Is(exit.Err, boom) || !exit.Restarting || exit.Delay != time.Millisecond {
+		t.Errorf("unexpected exit event %+v", exit)
+	}
+	worker.waitStarted(t)
+
+	service := waitServiceState(t, c, di.ServiceRunning)
+	if service.Restarts != 1 || service.LastError != "connection reset" {
+		t.Errorf("unexpected service stats %+v", service)
+	}
+	if encoded, err := json.Marshal(c.Stats()); err != nil || strings.Contains(string(encoded), "connection reset") {
+		t.Errorf("expected the service error message to be left out of the JSON, got %s, %v", encoded, err)
+	}
+
+	worker.exit(nil)
+	if exit := <-exits; exit.Restarting || exit.Err != nil {
+		t.Errorf("expected a clean exit not to restart on-failure, got %+v", exit)
+	}
+	if state := c.Stats().Services[0].State; state != di.ServiceStopped {
+		t.Errorf("expected stopped state, got %s", state)
+	}
+}
+
+func TestRestartRetriesFailedStartWithBackoff(t *testing.T) {
+	exits := make(chan di.ServiceExit, 4)
+	c := di.New(di.WithHooks(di.Hooks{
+		OnServiceExit: func(e di.ServiceExit) { exits <- e },
+	}))
+	worker := newCrashingWorker()
+	di.RegisterInstance[di.HostedService](c, worker, di.WithRestartPolicy(di.RestartPolicy{
+		Mode:       di.RestartAlways,
+		Backoff:    time.Millisecond,
+		MaxBackoff: 2 * time.Millisecond,
+	}))
+
+	c.Start(context.Background())
+	defer c.Stop(context.Background())
+	worker.waitStarted(t)
+
+	worker.mu.Lock()
+	worker.startErr = errors.New("still down")
+	worker.mu.Unlock()
+	worker.exit(nil)
+
+	first, second := <-exits, <-exits
+	if !first.Restarting || first.Delay != time.Millisecond {
+		t.Errorf("unexpected first exit %+v", first)
+	}
+	if !second.Restarting || second.Delay != 2*time.Millisecond || second.Err == nil {
+		t.Errorf("expected the failed restart to back off, got %+v", second)
+	}
+	worker.waitStarted(t)
+}
+
+func TestRestartGivesUpAfterMaxRestarts(t *testing.T) {
+	exits := make(chan di.ServiceExit, 4)
+	c := di.New(di.WithHooks(di.Hooks{
+		OnServiceExit: func(e di.ServiceExit) { exits <- e },
+	}))
+	worker := newCrashingWorker()
+	di.RegisterInstance[di.HostedService](c, worker, di.WithRestartPolicy(di.RestartPolicy{
+		Mode:        di.RestartOnFailure,
+		Backoff:     time.Millisecond,
+		MaxRestarts: 1,
+	}))
+
+	c.Start(context.Background())
+	defer c.Stop(context.Background())
+	worker.waitStarted(t)
+
+	boom := errors.New("boom")
+	worker.exit(boom)
+	<-exits
+	worker.waitStarted(t)
+	worker.exit(boom)
+	if exit := <-exits; exit.Restarting {
+		t.Errorf("expected supervision to give up, got %+v", exit)
+	}
+	if state := c.Stats().Services[0].State; state != di.ServiceFailed {
+		t.Errorf("expected failed state, got %s", state)
+	}
+}
+
+func TestStopEndsSupervision(t *testing.T) {
+	c := di.New()
+	worker := newCrashingWorker()
+	di.RegisterInstance[di.HostedService](c, worker, di.WithRestartPolicy(di.RestartPolicy{
+		Mode:    di.RestartAlways,
+		Backoff: time.Hour,
+	}))
+
+	c.Start(context.Background())
+	worker.waitStarted(t)
+	worker.exit(errors.New("boom"))
+
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected stop error: %v", err)
+	}
+	if state := c.Stats().Services[0].State; state != di.ServiceStopped {
+		t.Errorf("expected stopped state, got %s", state)
+	}
+	worker.mu.Lock()
+	defer worker.mu.Unlock()
+	if worker.starts != 1 {
+		t.Errorf("expected no restart after Stop, got %d starts", worker.starts)
+	}
+}
@@ -0,0 +1,103 @@
+package di
+
+import "reflect"
+
+// template creates registrations on demand for the names of a type.
+type template struct {
+	// factory returns the registration factory for a name.
+	factory func(name string) any
+	// typed returns the factory's call for builds without reflective
+	// factories (see Register0).
+	typed func(name string) func(args []any) (any, error)
+	// opts are applied to every registration created from the template.
+	opts []RegistrationOption
+}
+
+// RegisterTemplate serves every requested name of T that has no explicit
+// registration by calling fn with the name.
+//
+// This replaces one registration per name when instances differ only by a
+// name-derived setting, such as per-queue consumers or per-bucket storage
+// clients. The first resolution of a name creates a registration for it from
+// the template, with the template's options applied; the registration then
+// behaves like any other, so singletons are cached per name and the
+// registration is reported by [Container.Registrations]. Unnamed requests and
+// names with explicit registrations are not served by the template.
+//
+// Names are checked against the container's naming policy when they are first
+// requested. Registering another template for T replaces the earlier one for
+// names not yet requested.
+//
+// Example:
+//
+//	di.RegisterTemplate[*Consumer](c, func(queue string) (*Consumer, error) {
+//	    return NewConsumer(queue)
+//	}, di.AsSingleton())
+//
+//	orders, _ := di.ResolveNamed[*Consumer](c, "orders")
+//	emails, _ := di.ResolveNamed[*Consumer](c, "emails")
+func RegisterTemplate[T any](c *Container, fn func(name string) (T, error), opts ...RegistrationOption) error {
+	var zero T
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	tmpl := &template{
+		factory: func(name string) any {
+			return func() (T, error) {
+				return fn(name)
+			}
+		},
+		typed: func(name string) func([]any) (any, error) {
+			return func([]any) (any, error) { return fn(name) }
+		},
+		opts: opts,
+	}
+	if err := c.checkRegistration(tmpl.instantiate(targetType, "")); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.templates[targetType] = tmpl
+	return nil
+}
+
+// instantiate creates the registration of name.
+func (t *template) instantiate(targetType reflect.Type, name string) *registration {
+	reg := &registration{
+		targetType:  targetType,
+		factory:     t.factory(name),
+		lifetime:    Transient,
+		userFactory: true,
+	}
+	for _, opt := range t.opts {
+		opt(reg)
+	}
+	if !reflectiveFactories {
+		reg.typed = t.typed(name)
+	}
+	reg.name = name
+	reg.nameSet = true
+	return reg
+}
+
+// templateRegistration creates the registration for key from its type's
+// template, if there is one.
+func (c *Container) templateRegistration(key registrationKey) (*registration, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if reg, ok := c.registrations[key]; ok {
+		return reg, true, nil
+	}
+	tmpl, ok := c.templates[key.typ]
+	if !ok {
+		return nil, false, nil
+	}
+
+	reg := tmpl.instantiate(key.typ, key.name)
+	if err := c.addRegistration(reg); err != nil {
+		return nil, false, err
+	}
+	return reg, true, nil
+}
@@ -0,0 +1,77 @@
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Template Tests
+// =============================================================================
+
+type queueConsumer struct{ queue string }
+
+func TestRegisterTemplate(t *testing.T) {
+	c := di.New()
+	calls := 0
+	err := di.RegisterTemplate[*queueConsumer](c, func(name string) (*queueConsumer, error) {
+		calls++
+		return &queueConsumer{queue: name}, nil
+	}, di.AsSingleton())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	orders := di.MustResolveNamed[*queueConsumer](c, "orders")
+	emails := di.MustResolveNamed[*queueConsumer](c, "emails")
+	if orders.queue != "orders" || emails.queue != "emails" {
+		t.Errorf("expected per-name instances, got %q and %q", orders.queue, emails.queue)
+	}
+	if di.MustResolveNamed[*queueConsumer](c, "orders") != orders || calls != 2 {
+		t.Errorf("expected singletons cached per name, got %d template calls", calls)
+	}
+	if infos := c.Registrations(); len(infos) != 2 || infos[0].Name != "orders" {
+		t.Errorf("expected materialized registrations, got %+v", infos)
+	}
+}
+
+func TestRegisterTemplateExplicitRegistrationWins(t *testing.T) {
+	c := di.New()
+	di.RegisterTemplate[*queueConsumer](c, func(name string) (*queueConsumer, error) {
+		return &queueConsumer{queue: name}, nil
+	})
+	di.RegisterInstance(c, &queueConsumer{queue: "explicit"}, di.WithName("orders"))
+
+	if got := di.MustResolveNamed[*queueConsumer](c, "orders").queue; got != "explicit" {
+		t.Errorf("expected explicit registration, got %q", got)
+	}
+
+	var notRegistered di.ErrNotRegistered
+	if _, err := di.Resolve[*queueConsumer](c); !errors.As(err, &notRegistered) {
+		t.Errorf("expected unnamed request to be unserved, got %v", err)
+	}
+}
+
+func TestRegisterTemplateErrors(t *testing.T) {
+	boom := errors.New("boom")
+	c := di.New(di.WithNamingPolicy(func(name string) error {
+		if name == "bad" {
+			return errors.New("reserved")
+		}
+		return nil
+	}))
+	di.RegisterTemplate[*queueConsumer](c, func(name string) (*queueConsumer, error) {
+		return nil, boom
+	})
+
+	if _, err := di.ResolveNamed[*queueConsumer](c, "orders"); !errors.Is(err, boom) {
+		t.Errorf("expected template error, got %v", err)
+	}
+
+	var invalid di.ErrInvalidName
+	if _, err := di.ResolveNamed[*queueConsumer](c, "bad"); !errors.As(err, &invalid) {
+		t.Errorf("expected ErrInvalidName, got %v", err)
+	}
+}
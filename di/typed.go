@@ -0,0 +1,145 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// errReflectionDisabled is the message of the ErrInvalidFactory returned by
+// reflective entry points when the di_noreflect build tag is set.
+const errReflectionDisabled = "reflective factories are disabled by the di_noreflect build tag; use Register0 to Register3 or RegisterInstance"
+
+// Register0 registers T with a typed factory that takes no parameters.
+//
+// Unlike [Register], typed factories are called directly rather than through
+// reflect.Value.Call, so they keep working in builds with the di_noreflect
+// tag. That tag disables reflective auto-wiring ([Register], [Invoke],
+// [Bind], and [Decorate] return [ErrInvalidFactory]) for TinyGo and
+// WebAssembly targets, where calling functions through reflection is
+// unsupported or bloats binaries. The container still uses reflect.Type to
+// identify types, which those targets support.
+//
+// Typed factories run on the resolving goroutine: a resolution deadline is
+// checked before each factory runs, but does not abandon a running one.
+//
+// Example:
+//
+//	di.Register0[Clock](c, func() (Clock, error) { return systemClock{}, nil }, di.AsSingleton())
+func Register0[T any](c *Container, factory func() (T, error), opts ...RegistrationOption) error {
+	return registerTyped[T](c, factory, func([]any) (any, error) {
+		return factory()
+	}, opts)
+}
+
+// Register1 registers T with a typed factory whose parameter is resolved from
+// the container by type (see [Register0]). As with [Register], a
+// context.Context, *[Scope], or [Metadata] parameter receives the resolution
+// context, the resolving scope, or the registration's metadata.
+//
+// Example:
+//
+//	di.Register1[*UserService](c, func(repo UserRepository) (*UserService, error) {
+//	    return NewUserService(repo), nil
+//	})
+func Register1[T, A any](c *Container, factory func(A) (T, error), opts ...RegistrationOption) error {
+	return registerTyped[T](c, factory, func(args []any) (any, error) {
+		a, _ := args[0].(A)
+		return factory(a)
+	}, opts)
+}
+
+// Register2 registers T with a typed factory whose two parameters are
+// resolved from the container by type (see [Register0]).
+func Register2[T, A, B any](c *Container, factory func(A, B) (T, error), opts ...RegistrationOption) error {
+	return registerTyped[T](c, factory, func(args []any) (any, error) {
+		a, _ := args[0].(A)
+		b, _ := args[1].(B)
+		return factory(a, b)
+	}, opts)
+}
+
+// Register3 registers T with a typed factory whose three parameters are
+// resolved from the container by type (see [Register0]).
+func Register3[T, A, B, C any](c *Container, factory func(A, B, C) (T, error), opts ...RegistrationOption) error {
+	return registerTyped[T](c, factory, func(args []any) (any, error) {
+		a, _ := args[0].(A)
+		b, _ := args[1].(B)
+		cc, _ := args[2].(C)
+		return factory(a, b, cc)
+	}, opts)
+}
+
+// registerTyped registers T with a typed factory and the function that calls
+// it with resolved arguments.
+func registerTyped[T any](c *Container, factory any, call func(args []any) (any, error), opts []RegistrationOption) error {
+	var zero T
+	reg := &registration{
+		targetType:  reflect.TypeOf(&zero).Elem(),
+		factory:     factory,
+		typed:       call,
+		lifetime:    Transient,
+		userFactory: true,
+	}
+	for _, opt := range opts {
+		opt(reg)
+	}
+	if err := c.checkRegistration(reg); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	err := c.addRegistration(reg)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if c.hooks.OnWarning != nil {
+		c.emitWarnings(duplicateFactoryWarnings(c.orderedRegistrations(), reg))
+	}
+	return nil
+}
+
+// invokeTyped calls a typed factory, supplying its parameters the same way
+// as for reflective factories (see planParams).
+func (c *Container) invokeTyped(ctx context.Context, reg *registration, scope *Scope, chain []reflect.Type, budget *scopeBudget) (any, error) {
+	fnType := reflect.TypeOf(reg.factory)
+	values := make([]reflect.Value, fnType.NumIn())
+	if err := c.resolveParams(ctx, fnType, values, scope, chain, reg.metadata()); err != nil {
+		return nil, err
+	}
+	args := make([]any, len(values))
+	for i, value := range values {
+		if value.IsValid() {
+			args[i] = value.Interface()
+		}
+	}
+
+	// Call factory, charging its own running time to the scope's budget
+	var start time.Time
+	if budget != nil {
+		start = time.Now()
+	}
+	instance, err := reg.typed(args)
+	if budget != nil {
+		budget.spend(time.Since(start))
+	}
+	if err != nil {
+		reg.stats.recordConstructionError(err)
+		return nil, err
+	}
+
+	if c.nilChecks {
+		kind, isNil := reflect.Type(nil), instance == nil
+		if !isNil {
+			kind, isNil = nilResult(reflect.ValueOf(instance))
+		}
+		if isNil {
+			err := ErrNilInstance{Type: reg.targetType, Name: reg.name, Factory: fnType, Kind: kind}
+			reg.stats.recordConstructionError(err)
+			return nil, err
+		}
+	}
+	return instance, nil
+}
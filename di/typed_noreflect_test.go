@@ -0,0 +1,39 @@
+//go:build di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Reflection-Free Build Tests
+// =============================================================================
+
+func TestReflectiveEntryPointsDisabled(t *testing.T) {
+	c := di.New()
+	var invalid di.ErrInvalidFactory
+
+	if err := di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }); !errors.As(err, &invalid) {
+		t.Errorf("expected Register to be disabled, got %v", err)
+	}
+	if err := di.Invoke(c, func() {}); !errors.As(err, &invalid) {
+		t.Errorf("expected Invoke to be disabled, got %v", err)
+	}
+	if err := di.Decorate[Greeter](c, func(g Greeter) Greeter { return g }); !errors.As(err, &invalid) {
+		t.Errorf("expected Decorate to be disabled, got %v", err)
+	}
+}
+
+func TestRegisterTypeWithoutReflection(t *testing.T) {
+	c := di.New()
+	if err := di.RegisterType[Greeter, SimpleGreeter](c); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if got := di.MustResolve[Greeter](c).Greet("TinyGo"); got != "Hello, TinyGo" {
+		t.Errorf("expected the registered type, got %q", got)
+	}
+}
@@ -0,0 +1,89 @@
+package di_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Typed Registration Tests
+// =============================================================================
+
+func TestTypedRegistration(t *testing.T) {
+	c := di.New()
+	di.Register0[Logger](c, func() (Logger, error) { return &TestLogger{}, nil }, di.AsSingleton())
+	di.Register0[Greeter](c, func() (Greeter, error) { return &SimpleGreeter{}, nil })
+	di.Register2[Service](c, func(logger Logger, greeter Greeter) (Service, error) {
+		return &DefaultService{logger: logger, greeter: greeter}, nil
+	})
+
+	svc := di.MustResolve[Service](c).(*DefaultService)
+	if svc.logger != di.MustResolve[Logger](c) {
+		t.Error("expected typed parameters to be resolved from the container")
+	}
+	if deps := c.Registrations()[2].Dependencies; len(deps) != 2 {
+		t.Errorf("expected typed factories to report their dependencies, got %v", deps)
+	}
+}
+
+func TestTypedRegistrationErrors(t *testing.T) {
+	c := di.New()
+	boom := errors.New("boom")
+	di.Register3[*closableResource](c, func(Logger, Greeter, *TestLogger) (*closableResource, error) {
+		return nil, boom
+	})
+	di.Register1[*TestLogger](c, func(Greeter) (*TestLogger, error) { return &TestLogger{}, nil })
+	di.Register0[Greeter](c, func() (Greeter, error) { return &SimpleGreeter{}, nil })
+	di.RegisterInstance[Logger](c, &TestLogger{})
+
+	if _, err := di.Resolve[*closableResource](c); !errors.Is(err, boom) {
+		t.Errorf("expected the factory error, got %v", err)
+	}
+
+	strict := di.New(di.WithNilChecks())
+	di.Register0[*TestLogger](strict, func() (*TestLogger, error) { return nil, nil })
+	var nilInstance di.ErrNilInstance
+	if _, err := di.Resolve[*TestLogger](strict); !errors.As(err, &nilInstance) {
+		t.Errorf("expected ErrNilInstance, got %v", err)
+	}
+}
+
+type requestKey struct{}
+
+func TestTypedFactoryReceivesContextScopeAndMetadata(t *testing.T) {
+	c := di.New()
+	di.Register3[string](c, func(ctx context.Context, scope *di.Scope, meta di.Metadata) (string, error) {
+		return ctx.Value(requestKey{}).(string) + "/" + scope.Name() + "/" + meta.Lifetime.String(), nil
+	}, di.AsScoped())
+
+	scope := c.CreateScope("request")
+	ctx := context.WithValue(context.Background(), requestKey{}, "req-1")
+	got, err := di.ResolveInScopeCtx[string](ctx, c, scope)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "req-1/request/Scoped" {
+		t.Errorf("expected context, scope, and metadata to be supplied, got %q", got)
+	}
+}
+
+func TestBuiltinsUseTypedRegistration(t *testing.T) {
+	c := di.New()
+	if err := di.AppendValue(c, "", "a"); err != nil {
+		t.Fatalf("AppendValue: %v", err)
+	}
+	if err := di.RegisterScopedLogger(c, slog.Default()); err != nil {
+		t.Fatalf("RegisterScopedLogger: %v", err)
+	}
+
+	if got := di.MustResolve[[]string](c); len(got) != 1 || got[0] != "a" {
+		t.Errorf("expected the appended value, got %v", got)
+	}
+	if _, err := di.ResolveInScope[*slog.Logger](c, c.CreateScope("job")); err != nil {
+		t.Errorf("expected the scoped logger to resolve, got %v", err)
+	}
+}
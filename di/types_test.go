@@ -0,0 +1,60 @@
+package di_test
+
+import "sync/atomic"
+
+// =============================================================================
+// Test Interfaces and Implementations
+// =============================================================================
+
+type Greeter interface {
+	Greet(name string) string
+}
+
+type SimpleGreeter struct{}
+
+func (g *SimpleGreeter) Greet(name string) string {
+	return "Hello, " + name
+}
+
+type Logger interface {
+	Log(msg string)
+}
+
+type TestLogger struct {
+	Messages []string
+}
+
+func (l *TestLogger) Log(msg string) {
+	l.Messages = append(l.Messages, msg)
+}
+
+type Service interface {
+	DoWork() string
+}
+
+type DefaultService struct {
+	logger  Logger
+	greeter Greeter
+}
+
+func (s *DefaultService) DoWork() string {
+	s.logger.Log("doing work")
+	return s.greeter.Greet("World")
+}
+
+type formalGreeter struct{}
+
+func (g *formalGreeter) Greet(name string) string {
+	return "Good day, " + name
+}
+
+type closableResource struct {
+	closed atomic.Bool
+}
+
+func (r *closableResource) Close() error {
+	r.closed.Store(true)
+	return nil
+}
+
+type tenantKey struct{}
@@ -0,0 +1,149 @@
+package di
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+)
+
+// UnitOfWork is a scoped database transaction.
+//
+// Register it with [RegisterUnitOfWork]. Every scope then gets its own
+// UnitOfWork, and repositories registered as scoped can depend on *sql.Tx to
+// share the scope's transaction. The transaction begins on first use and is
+// bound to the scope: it is rolled back when the scope is disposed unless it
+// was committed first, so a failed or abandoned request never leaves a
+// transaction open.
+type UnitOfWork struct {
+	db   *sql.DB
+	opts *sql.TxOptions
+
+	mu   sync.Mutex
+	tx   *sql.Tx
+	done bool
+}
+
+// errUnitOfWorkDone is returned when a finished unit of work is used again.
+var errUnitOfWorkDone = errors.New("di: unit of work already committed or rolled back")
+
+// RegisterUnitOfWork registers *UnitOfWork and *sql.Tx as scoped dependencies.
+//
+// The container must also have a *sql.DB registration, which is used to begin
+// transactions with the given options (nil for the driver defaults). The
+// transaction begins with the resolution context, so resolving with
+// [ResolveCtx] ties it to a request context.
+//
+// Example:
+//
+//	di.RegisterInstance(c, db) // *sql.DB
+//	di.RegisterUnitOfWork(c, nil)
+//	di.Register[*UserRepository](c, func(tx *sql.Tx) *UserRepository {
+//	    return &UserRepository{tx: tx}
+//	}, di.AsScoped())
+//
+//	err := di.WithUnitOfWork(ctx, c, "signup", func(scope *di.Scope) error {
+//	    users, _ := di.ResolveInScope[*UserRepository](c, scope)
+//	    return users.Create(ctx, newUser)
+//	}) // commits if the function succeeds, rolls back otherwise
+func RegisterUnitOfWork(c *Container, opts *sql.TxOptions) error {
+	if err := Register1[*UnitOfWork](c, func(db *sql.DB) (*UnitOfWork, error) {
+		return &UnitOfWork{db: db, opts: opts}, nil
+	}, AsScoped()); err != nil {
+		return err
+	}
+	return Register2[*sql.Tx](c, func(ctx context.Context, uow *UnitOfWork) (*sql.Tx, error) {
+		return uow.Tx(ctx)
+	}, AsScoped())
+}
+
+// WithUnitOfWork runs fn in a new scope and finishes the scope's unit of work.
+//
+// If fn returns nil, the transaction is committed (if one was begun) and the
+// commit error is returned. Otherwise the transaction is rolled back and fn's
+// error is returned. The scope is disposed in either case. Requires
+// [RegisterUnitOfWork].
+func WithUnitOfWork(ctx context.Context, c *Container, name string, fn func(scope *Scope) error) (err error) {
+	scope := c.CreateScope(name)
+	defer func() {
+		err = errors.Join(err, scope.Dispose())
+	}()
+
+	uow, err := ResolveInScope[*UnitOfWork](c, scope)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(scope); err != nil {
+		return errors.Join(err, uow.Rollback())
+	}
+	return uow.Commit()
+}
+
+// Tx returns the unit of work's transaction, beginning it if needed.
+func (u *UnitOfWork) Tx(ctx context.Context) (*sql.Tx, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.done {
+		return nil, errUnitOfWorkDone
+	}
+	if u.tx == nil {
+		tx, err := u.db.BeginTx(ctx, u.opts)
+		if err != nil {
+			return nil, err
+		}
+		u.tx = tx
+	}
+	return u.tx, nil
+}
+
+// Commit commits the transaction. Committing a unit of work that never began
+// a transaction does nothing.
+func (u *UnitOfWork) Commit() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.done {
+		return errUnitOfWorkDone
+	}
+	return u.finish(true)
+}
+
+// Rollback aborts the transaction. Rolling back a unit of work that never
+// began a transaction does nothing.
+func (u *UnitOfWork) Rollback() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.done {
+		return errUnitOfWorkDone
+	}
+	return u.finish(false)
+}
+
+// Close rolls back the transaction unless it was already committed or rolled
+// back. It is called when the owning scope is disposed.
+func (u *UnitOfWork) Close() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.done {
+		return nil
+	}
+	return u.finish(false)
+}
+
+// finish commits or rolls back the transaction and marks the unit of work done.
+// The caller must hold u.mu.
+func (u *UnitOfWork) finish(commit bool) error {
+	u.done = true
+	switch {
+	case u.tx == nil:
+		return nil
+	case commit:
+		return u.tx.Commit()
+	default:
+		return u.tx.Rollback()
+	}
+}
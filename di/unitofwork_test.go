@@ -0,0 +1,137 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Unit of Work Tests
+// =============================================================================
+
+// txRecorder is a fake database driver that records transaction outcomes.
+type txRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *txRecorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *txRecorder) Connect(context.Context) (driver.Conn, error) { return fakeConn{r}, nil }
+func (r *txRecorder) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ r *txRecorder }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error) {
+	c.r.record("begin")
+	return fakeTx(c), nil
+}
+
+type fakeTx struct{ r *txRecorder }
+
+func (t fakeTx) Commit() error   { t.r.record("commit"); return nil }
+func (t fakeTx) Rollback() error { t.r.record("rollback"); return nil }
+
+type userRepository struct {
+	tx *sql.Tx
+}
+
+func newUnitOfWorkContainer(t *testing.T) (*di.Container, *txRecorder) {
+	t.Helper()
+
+	recorder := &txRecorder{}
+	db := sql.OpenDB(recorder)
+	t.Cleanup(func() { db.Close() })
+
+	c := di.New()
+	di.RegisterInstance(c, db)
+	if err := di.RegisterUnitOfWork(c, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	di.Register[*userRepository](c, func(tx *sql.Tx) *userRepository {
+		return &userRepository{tx: tx}
+	}, di.AsScoped())
+	return c, recorder
+}
+
+func TestWithUnitOfWorkCommits(t *testing.T) {
+	c, recorder := newUnitOfWorkContainer(t)
+
+	err := di.WithUnitOfWork(context.Background(), c, "signup", func(scope *di.Scope) error {
+		users, err := di.ResolveInScope[*userRepository](c, scope)
+		if err != nil {
+			return err
+		}
+		tx, _ := di.ResolveInScope[*sql.Tx](c, scope)
+		if users.tx != tx {
+			t.Error("expected repositories to share the scope's transaction")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(recorder.events) != 2 || recorder.events[1] != "commit" {
+		t.Errorf("expected begin and commit, got %v", recorder.events)
+	}
+}
+
+func TestWithUnitOfWorkRollsBackOnError(t *testing.T) {
+	c, recorder := newUnitOfWorkContainer(t)
+	boom := errors.New("boom")
+
+	err := di.WithUnitOfWork(context.Background(), c, "signup", func(scope *di.Scope) error {
+		di.ResolveInScope[*userRepository](c, scope)
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected fn error, got %v", err)
+	}
+
+	if len(recorder.events) != 2 || recorder.events[1] != "rollback" {
+		t.Errorf("expected begin and rollback, got %v", recorder.events)
+	}
+}
+
+func TestScopeDisposeRollsBackUncommittedWork(t *testing.T) {
+	c, recorder := newUnitOfWorkContainer(t)
+
+	scope := c.CreateScope("abandoned")
+	di.ResolveInScope[*userRepository](c, scope)
+	if err := scope.Dispose(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(recorder.events) != 2 || recorder.events[1] != "rollback" {
+		t.Errorf("expected begin and rollback, got %v", recorder.events)
+	}
+}
+
+func TestUnitOfWorkWithoutTransaction(t *testing.T) {
+	c, recorder := newUnitOfWorkContainer(t)
+
+	err := di.WithUnitOfWork(context.Background(), c, "read-only", func(scope *di.Scope) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recorder.events) != 0 {
+		t.Errorf("expected no transaction to begin, got %v", recorder.events)
+	}
+}
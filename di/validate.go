@@ -0,0 +1,192 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Validate checks the container's wiring without constructing anything, so
+// gaps surface in CI or at startup rather than at the first resolution.
+//
+// Every active registration is checked, and all problems are reported
+// together:
+//   - a factory dependency that nothing can satisfy, as
+//     [ErrUnsatisfiedDependency]; dependencies are satisfied by a
+//     registration whose conditions hold (see [WhenRegistered]), a selector, a
+//     template, a lazy module, the fallback container (see [WithFallback]),
+//     or, with [WithPointerNormalization], a registration of the pointer or
+//     value counterpart
+//   - a cycle of factory dependencies, as [ErrCircularDependency]
+//   - a parameter object (see [In]) with malformed struct tags, as
+//     [ErrInvalidFactory]
+//
+// The dependencies of the decorators that apply to a type (see [Decorate])
+// count as dependencies of its registrations. Only dependencies resolved by
+// type are followed: [Optional], [Lazy], and provider parameters, and
+// parameter object fields with tags, are not required and do not form cycles.
+// Returns the errors found, joined, or nil.
+//
+// Example:
+//
+//	c := di.New()
+//	app.Configure(c)
+//	if err := c.Validate(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Container) Validate() error {
+	var regs []*registration
+	for _, reg := range c.orderedRegistrations() {
+		if reg.enabled(c) {
+			regs = append(regs, reg)
+		}
+	}
+	graph := newDependencyGraph(regs)
+	graph.decorated = c.decoratorDependencies()
+
+	var errs []error
+	for _, reg := range regs {
+		for _, edge := range graph.dependencies(reg) {
+			if edge.target == nil && !c.canSatisfy(edge.typ) {
+				errs = append(errs, ErrUnsatisfiedDependency{
+					Profile:    c.profile,
+					Type:       reg.targetType,
+					Name:       reg.name,
+					Dependency: edge.typ,
+				})
+			}
+		}
+		if err := reg.checkParamObjects(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, cycle := range graph.cycles(regs) {
+		errs = append(errs, ErrCircularDependency{Chain: cycle})
+	}
+	return errors.Join(errs...)
+}
+
+// canSatisfy reports whether an unnamed resolution of typ, which has no
+// unnamed registration, could still succeed.
+func (c *Container) canSatisfy(typ reflect.Type) bool {
+	c.mu.RLock()
+	_, selected := c.selectors[typ]
+	_, templated := c.templates[typ]
+	lazy := len(c.lazy[typ]) > 0
+	fallback := c.fallback
+	normalize := c.normalizePointers
+	c.mu.RUnlock()
+	if selected || templated || lazy || fallback.hasRegistration(typ) {
+		return true
+	}
+	if normalize {
+		counterpart, ok := c.pointerCounterpart(typ, "")
+		return ok && c.registered(counterpart, "", nil)
+	}
+	return false
+}
+
+// decoratorDependencies returns, for each decorated type, the types its
+// applicable decorators resolve from the container besides the instance they
+// wrap.
+func (c *Container) decoratorDependencies() map[reflect.Type][]reflect.Type {
+	c.mu.RLock()
+	decorators := make(map[reflect.Type][]*decorator, len(c.decorators))
+	for typ, list := range c.decorators {
+		decorators[typ] = list
+	}
+	c.mu.RUnlock()
+
+	deps := make(map[reflect.Type][]reflect.Type, len(decorators))
+	for typ, list := range decorators {
+		for _, d := range list {
+			if d.when != nil && !d.when(c) {
+				continue
+			}
+			deps[typ] = append(deps[typ], paramDependencies(planParams(d.fn.Type())[1:])...)
+		}
+	}
+	return deps
+}
+
+// checkParamObjects reports malformed struct tags in the parameter objects of
+// the registration's factory.
+func (r *registration) checkParamObjects() error {
+	if r.factory == nil {
+		return nil
+	}
+	for _, param := range planParams(reflect.TypeOf(r.factory)) {
+		if param.kind != paramObject {
+			continue
+		}
+		for i := 0; i < param.typ.NumField(); i++ {
+			field := param.typ.Field(i)
+			if !field.IsExported() || field.Type == inType {
+				continue
+			}
+			if tag, ok := field.Tag.Lookup("optional"); ok {
+				if _, err := strconv.ParseBool(tag); err != nil {
+					return ErrInvalidFactory{
+						Type:    r.targetType,
+						Message: fmt.Sprintf("%s field %s: invalid optional tag %q", param.typ, field.Name, tag),
+					}
+				}
+			}
+			if _, ok := field.Tag.Lookup("group"); ok && field.Type.Kind() != reflect.Slice {
+				return ErrInvalidFactory{
+					Type:    r.targetType,
+					Message: fmt.Sprintf("%s field %s: group tag requires a slice field, got %s", param.typ, field.Name, field.Type),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// cycles returns the dependency cycles reachable from regs, each as the chain
+// of types from the first type of the cycle back to itself.
+func (g *dependencyGraph) cycles(regs []*registration) [][]reflect.Type {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*registration]int, len(regs))
+	var stack []*registration
+	var cycles [][]reflect.Type
+
+	var visit func(reg *registration)
+	visit = func(reg *registration) {
+		state[reg] = visiting
+		stack = append(stack, reg)
+		for _, edge := range g.dependencies(reg) {
+			switch {
+			case edge.target == nil:
+			case state[edge.target] == visiting:
+				var chain []reflect.Type
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == edge.target {
+						for _, member := range stack[i:] {
+							chain = append(chain, member.targetType)
+						}
+						break
+					}
+				}
+				cycles = append(cycles, append(chain, edge.typ))
+			case state[edge.target] == unvisited:
+				visit(edge.target)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[reg] = visited
+	}
+
+	for _, reg := range regs {
+		if state[reg] == unvisited {
+			visit(reg)
+		}
+	}
+	return cycles
+}
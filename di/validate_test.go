@@ -0,0 +1,113 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Container Validation Tests
+// =============================================================================
+
+type chickenService struct{}
+
+type eggService struct{}
+
+type badParams struct {
+	di.In
+	Logger Logger `optional:"maybe"`
+}
+
+func TestValidate(t *testing.T) {
+	c := di.New()
+	di.Register[Service](c, func(logger Logger, greeter Greeter) Service {
+		return &DefaultService{logger: logger, greeter: greeter}
+	})
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.Register[*chickenService](c, func(*eggService) *chickenService { return nil })
+	di.Register[*eggService](c, func(*chickenService) *eggService { return nil })
+	di.Register[*TestLogger](c, func(badParams) *TestLogger { return nil })
+
+	err := c.Validate()
+
+	var unsatisfied di.ErrUnsatisfiedDependency
+	if !errors.As(err, &unsatisfied) || unsatisfied.Dependency != reflect.TypeOf((*Logger)(nil)).Elem() {
+		t.Errorf("expected the missing Logger to be reported, got %v", err)
+	}
+	var circular di.ErrCircularDependency
+	if !errors.As(err, &circular) || len(circular.Chain) != 3 || circular.Chain[0] != circular.Chain[2] {
+		t.Errorf("expected the cycle to be reported, got %v", err)
+	}
+	var invalid di.ErrInvalidFactory
+	if !errors.As(err, &invalid) {
+		t.Errorf("expected the malformed parameter object to be reported, got %v", err)
+	}
+	if got := c.Stats(); got.Constructions != 0 {
+		t.Errorf("expected nothing to be constructed, got %d constructions", got.Constructions)
+	}
+}
+
+func TestValidateSatisfied(t *testing.T) {
+	platform := di.New()
+	di.Register[Logger](platform, func() Logger { return &TestLogger{} })
+
+	c := di.New(di.WithFallback(platform))
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} }, di.WithName("formal"))
+	di.RegisterSelector[Greeter](c, func(di.SelectionContext) (string, error) { return "formal", nil })
+	di.Register[Service](c, func(logger Logger, greeter Greeter, lazy di.Lazy[*eggService]) Service {
+		return &DefaultService{logger: logger, greeter: greeter}
+	})
+
+	if err := c.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidatePointerNormalization(t *testing.T) {
+	c := di.New(di.WithPointerNormalization())
+	di.RegisterInstance[*chickenService](c, &chickenService{})
+	di.Register[*eggService](c, func(chickenService) *eggService { return &eggService{} })
+
+	if err := c.Validate(); err != nil {
+		t.Errorf("expected the pointer registration to satisfy the value dependency, got %v", err)
+	}
+}
+
+func TestValidateDisabledRegistrations(t *testing.T) {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &TestLogger{} }, di.WhenRegistered[Greeter]())
+	di.Register[*eggService](c, func(*chickenService) *eggService { return nil }, di.WhenRegistered[Greeter]())
+	di.Register[Service](c, func(logger Logger) Service { return &DefaultService{logger: logger} })
+
+	err := c.Validate()
+	var unsatisfied di.ErrUnsatisfiedDependency
+	if !errors.As(err, &unsatisfied) || unsatisfied.Dependency != reflect.TypeOf((*Logger)(nil)).Elem() {
+		t.Errorf("expected the disabled Logger not to satisfy the dependency, got %v", err)
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 1 {
+		t.Errorf("expected the disabled registration's own dependencies to be skipped, got %v", err)
+	}
+}
+
+func TestValidateDecoratorDependencies(t *testing.T) {
+	c := di.New()
+	di.Register[Greeter](c, func() Greeter { return &SimpleGreeter{} })
+	di.Decorate[Greeter](c, func(inner Greeter, logger Logger) Greeter { return inner })
+	di.DecorateWhen[Greeter](c, func(*di.Container) bool { return false },
+		func(inner Greeter, service Service) Greeter { return inner })
+
+	err := c.Validate()
+	var unsatisfied di.ErrUnsatisfiedDependency
+	if !errors.As(err, &unsatisfied) || unsatisfied.Dependency != reflect.TypeOf((*Logger)(nil)).Elem() ||
+		unsatisfied.Type != reflect.TypeOf((*Greeter)(nil)).Elem() {
+		t.Errorf("expected the decorator's Logger dependency to be reported, got %v", err)
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 1 {
+		t.Errorf("expected the inapplicable decorator to be skipped, got %v", err)
+	}
+}
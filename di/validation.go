@@ -0,0 +1,48 @@
+package di
+
+import "fmt"
+
+// WithValidation runs validate on every instance the registration's factory
+// constructs, before the instance is cached or returned.
+//
+// A validation error fails the resolution with [ErrResolutionFailed] wrapping
+// the error, and the rejected instance is disposed if it implements
+// [Disposable] or io.Closer.
+// Use it to check configuration invariants or verify that a connection works.
+// Applying WithValidation more than once runs every validator, in order.
+//
+// T must be the registered type. Instances supplied with [RegisterInstance]
+// are not constructed by the container and are not validated.
+//
+// Example:
+//
+//	di.Register[*Config](c, loadConfig, di.AsSingleton(),
+//	    di.WithValidation(func(cfg *Config) error {
+//	        if cfg.Port == 0 {
+//	            return errors.New("port must be set")
+//	        }
+//	        return nil
+//	    }))
+func WithValidation[T any](validate func(T) error) RegistrationOption {
+	return func(r *registration) {
+		r.validators = append(r.validators, func(instance any) error {
+			typed, ok := instance.(T)
+			if !ok && instance != nil {
+				return fmt.Errorf("validator expects %T, got %T", typed, instance)
+			}
+			return validate(typed)
+		})
+	}
+}
+
+// validate runs the registration's validators on a newly constructed instance,
+// disposing the instance if it is rejected.
+func (r *registration) validate(instance any) error {
+	for _, validator := range r.validators {
+		if err := validator(instance); err != nil {
+			_ = disposeInstance(instance)
+			return fmt.Errorf("validation failed: %w", err)
+		}
+	}
+	return nil
+}
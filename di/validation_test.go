@@ -0,0 +1,66 @@
+//go:build !di_noreflect
+
+package di_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// =============================================================================
+// Instance Validation Tests
+// =============================================================================
+
+type serverConfig struct {
+	Port int
+}
+
+func TestWithValidation(t *testing.T) {
+	c := di.New()
+	port := 0
+
+	di.Register[*serverConfig](c, func() *serverConfig {
+		return &serverConfig{Port: port}
+	}, di.AsSingleton(), di.WithValidation(func(cfg *serverConfig) error {
+		if cfg.Port == 0 {
+			return errors.New("port must be set")
+		}
+		return nil
+	}))
+
+	_, err := di.Resolve[*serverConfig](c)
+	var failed di.ErrResolutionFailed
+	if !errors.As(err, &failed) || !contains(err.Error(), "port must be set") {
+		t.Fatalf("expected validation failure, got %v", err)
+	}
+	if len(c.CachedSingletons()) != 0 {
+		t.Error("expected invalid instance not to be cached")
+	}
+
+	port = 8080
+	cfg, err := di.Resolve[*serverConfig](c)
+	if err != nil || cfg.Port != 8080 {
+		t.Fatalf("expected valid instance, got %v, %v", cfg, err)
+	}
+}
+
+func TestWithValidationDisposesRejectedInstances(t *testing.T) {
+	c := di.New()
+	var rejected *closableResource
+
+	di.Register[*closableResource](c, func() *closableResource {
+		rejected = &closableResource{}
+		return rejected
+	}, di.WithValidation(func(*closableResource) error {
+		return errors.New("ping failed")
+	}))
+
+	if _, err := di.Resolve[*closableResource](c); err == nil {
+		t.Fatal("expected validation failure")
+	}
+	if !rejected.closed.Load() {
+		t.Error("expected rejected instance to be closed")
+	}
+}
@@ -0,0 +1,123 @@
+package dichaos
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// Fault configures the faults injected by [Inject].
+type Fault func(*injection)
+
+// Latency delays every affected construction by d, or until the resolution
+// context is done.
+func Latency(d time.Duration) Fault {
+	return func(i *injection) {
+		i.latency = d
+	}
+}
+
+// Fail makes affected constructions fail with err.
+func Fail(err error) Fault {
+	return func(i *injection) {
+		i.err = err
+	}
+}
+
+// Panic makes affected constructions panic with value.
+func Panic(value any) Fault {
+	return func(i *injection) {
+		i.panicValue = value
+	}
+}
+
+// Rate sets the probability, between 0 and 1, that a construction is
+// affected. The default is 1: every construction is affected.
+func Rate(p float64) Fault {
+	return func(i *injection) {
+		i.rate = p
+	}
+}
+
+// Named limits the faults to the registration of T with the given name. By
+// default every registration of T is affected.
+func Named(name string) Fault {
+	return func(i *injection) {
+		i.name = &name
+	}
+}
+
+// injection is a set of faults injected into the constructions of one type.
+type injection struct {
+	latency    time.Duration
+	err        error
+	panicValue any
+	rate       float64
+	name       *string
+	enabled    atomic.Bool
+}
+
+// Inject installs faults on every construction of T in c for the rest of the
+// test. Faults are applied in order: latency, then panic, then error. They are
+// disabled when the test ends, so a container shared between tests recovers.
+//
+// Injecting faults fails the test if they cannot be installed.
+//
+// Example:
+//
+//	dichaos.Inject[PaymentGateway](t, c,
+//	    dichaos.Fail(errors.New("gateway unavailable")),
+//	    dichaos.Rate(0.2))
+func Inject[T any](t testing.TB, c *di.Container, faults ...Fault) {
+	t.Helper()
+
+	i := &injection{rate: 1}
+	for _, fault := range faults {
+		fault(i)
+	}
+	i.enabled.Store(true)
+	t.Cleanup(func() {
+		i.enabled.Store(false)
+	})
+
+	err := di.DecorateWhen[T](c, func(*di.Container) bool {
+		return i.enabled.Load()
+	}, func(inner T, ctx context.Context, meta di.Metadata) (T, error) {
+		if err := i.apply(ctx, meta); err != nil {
+			var zero T
+			return zero, err
+		}
+		return inner, nil
+	})
+	if err != nil {
+		t.Fatalf("dichaos: %v", err)
+	}
+}
+
+// apply injects the faults into one construction.
+func (i *injection) apply(ctx context.Context, meta di.Metadata) error {
+	if i.name != nil && *i.name != meta.Name {
+		return nil
+	}
+	if i.rate < 1 && rand.Float64() >= i.rate {
+		return nil
+	}
+
+	if i.latency > 0 {
+		timer := time.NewTimer(i.latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if i.panicValue != nil {
+		panic(i.panicValue)
+	}
+	return i.err
+}
@@ -0,0 +1,96 @@
+//go:build !di_noreflect
+
+package dichaos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+	"github.com/pegasusheavy/go-dependency-injector/dichaos"
+)
+
+type Cache interface {
+	Get(key string) string
+}
+
+type memoryCache struct{}
+
+func (memoryCache) Get(key string) string { return key }
+
+func newContainer() *di.Container {
+	c := di.New()
+	di.Register[Cache](c, func() Cache { return memoryCache{} })
+	di.Register[Cache](c, func() Cache { return memoryCache{} }, di.WithName("sessions"))
+	return c
+}
+
+func TestInjectFail(t *testing.T) {
+	c := newContainer()
+	down := errors.New("cache down")
+	dichaos.Inject[Cache](t, c, dichaos.Fail(down))
+
+	if _, err := di.Resolve[Cache](c); !errors.Is(err, down) {
+		t.Errorf("expected injected error, got %v", err)
+	}
+}
+
+func TestInjectNamed(t *testing.T) {
+	c := newContainer()
+	down := errors.New("cache down")
+	dichaos.Inject[Cache](t, c, dichaos.Fail(down), dichaos.Named("sessions"))
+
+	if _, err := di.Resolve[Cache](c); err != nil {
+		t.Errorf("expected unnamed cache to be unaffected, got %v", err)
+	}
+	if _, err := di.ResolveNamed[Cache](c, "sessions"); !errors.Is(err, down) {
+		t.Errorf("expected injected error, got %v", err)
+	}
+}
+
+func TestInjectLatencyHonorsContext(t *testing.T) {
+	c := newContainer()
+	dichaos.Inject[Cache](t, c, dichaos.Latency(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := di.ResolveCtx[Cache](ctx, c); err == nil {
+		t.Error("expected resolution to be cut short by the deadline")
+	}
+}
+
+func TestInjectPanic(t *testing.T) {
+	c := newContainer()
+	dichaos.Inject[Cache](t, c, dichaos.Panic("boom"))
+
+	defer func() {
+		if recover() != "boom" {
+			t.Error("expected injected panic")
+		}
+	}()
+	di.Resolve[Cache](c)
+}
+
+func TestInjectRateZero(t *testing.T) {
+	c := newContainer()
+	dichaos.Inject[Cache](t, c, dichaos.Fail(errors.New("never")), dichaos.Rate(0))
+
+	for range 10 {
+		if _, err := di.Resolve[Cache](c); err != nil {
+			t.Fatalf("expected no faults, got %v", err)
+		}
+	}
+}
+
+func TestInjectDisabledAfterTest(t *testing.T) {
+	c := newContainer()
+	t.Run("chaos", func(t *testing.T) {
+		dichaos.Inject[Cache](t, c, dichaos.Fail(errors.New("cache down")))
+	})
+
+	if _, err := di.Resolve[Cache](c); err != nil {
+		t.Errorf("expected faults to be removed after the subtest, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+// Package dichaos injects faults into the construction of container-managed
+// dependencies for resilience testing.
+//
+// Faults are installed per test as decorators on the test's container, so the
+// application's production wiring is used unchanged:
+//
+//	c := app.NewContainer()
+//	dichaos.Inject[*sql.DB](t, c, dichaos.Latency(2*time.Second))
+//	dichaos.Inject[Cache](t, c, dichaos.Fail(errors.New("cache down")), dichaos.Rate(0.5))
+//
+//	// Exercise the composed application and assert it degrades gracefully
+//
+// Faults fire when an instance is constructed: a delayed, failing, or
+// panicking factory. Cached singletons constructed before the faults were
+// injected are unaffected.
+package dichaos
@@ -0,0 +1,165 @@
+package dicli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// Command is a subcommand of an [App].
+type Command struct {
+	// Name is the word that selects the command on the command line.
+	Name string
+	// Short is a one-line description shown in the app's usage.
+	Short string
+	// Flags defines the command's flags on its flag set. It is called once per
+	// invocation, before the arguments are parsed. It may be nil.
+	Flags func(fs *flag.FlagSet)
+	// Run is the function called for the command. Its parameters are resolved
+	// as described by [di.InvokeInScope]; in addition, the invocation's
+	// [Args] and *flag.FlagSet can be declared as parameters. It may return
+	// nothing or an error.
+	Run any
+}
+
+// Args are the positional arguments left after a command's flags are parsed.
+// Declare a parameter of type Args in a run function to receive them.
+type Args []string
+
+// App dispatches command-line arguments to its commands.
+type App struct {
+	container *di.Container
+	name      string
+	output    io.Writer
+	commands  []Command
+	runs      atomic.Uint64
+}
+
+// Option configures an [App].
+type Option func(*App)
+
+// WithOutput sets where usage and flag errors are written. The default is
+// os.Stderr.
+func WithOutput(w io.Writer) Option {
+	return func(a *App) {
+		a.output = w
+	}
+}
+
+// New creates an app named name whose commands are wired by c. The name is
+// used in usage output.
+func New(c *di.Container, name string, opts ...Option) *App {
+	a := &App{container: c, name: name, output: os.Stderr}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Add adds a command to the app. Commands are listed in usage in the order
+// they were added.
+func (a *App) Add(cmd Command) {
+	a.commands = append(a.commands, cmd)
+}
+
+// Run runs the command named by args[0] with the remaining arguments, which
+// are usually os.Args[1:].
+//
+// The command's flags are parsed, a scope is created for the invocation, and
+// the command's run function is invoked in it with ctx. The scope is disposed
+// when the function returns, and disposal errors are joined with the
+// function's error.
+//
+// With no arguments, or with "help", "-h", or "-help", Run writes the app's
+// usage and returns flag.ErrHelp. An unknown command also writes the usage and
+// returns an error.
+func (a *App) Run(ctx context.Context, args []string) (err error) {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		a.usage()
+		return flag.ErrHelp
+	}
+
+	cmd, ok := a.command(args[0])
+	if !ok {
+		a.usage()
+		return fmt.Errorf("dicli: unknown command %q", args[0])
+	}
+
+	if err := registerInvocationTypes(a.container); err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet(a.name+" "+cmd.Name, flag.ContinueOnError)
+	fs.SetOutput(a.output)
+	if cmd.Flags != nil {
+		cmd.Flags(fs)
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	scope := a.container.CreateScope(fmt.Sprintf("dicli/%s/%d", cmd.Name, a.runs.Add(1)))
+	defer func() {
+		err = errors.Join(err, scope.Dispose())
+	}()
+	scope.SetValue(flagSetKey{}, fs)
+
+	return di.InvokeInScope(ctx, a.container, scope, cmd.Run)
+}
+
+// command returns the command with the given name.
+func (a *App) command(name string) (Command, bool) {
+	for _, cmd := range a.commands {
+		if cmd.Name == name {
+			return cmd, true
+		}
+	}
+	return Command{}, false
+}
+
+// usage writes the app's usage to its output.
+func (a *App) usage() {
+	fmt.Fprintf(a.output, "Usage: %s <command> [flags] [args]\n\nCommands:\n", a.name)
+	tw := tabwriter.NewWriter(a.output, 0, 4, 2, ' ', 0)
+	for _, cmd := range a.commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.Name, cmd.Short)
+	}
+	tw.Flush()
+}
+
+// flagSetKey is the scope value key holding an invocation's flag set.
+type flagSetKey struct{}
+
+// registerMu serializes registerInvocationTypes.
+var registerMu sync.Mutex
+
+// registerInvocationTypes registers the scoped *flag.FlagSet and Args of an
+// invocation, unless they are already registered.
+func registerInvocationTypes(c *di.Container) error {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	if di.Has[Args](c) {
+		return nil
+	}
+	if err := di.Register1[*flag.FlagSet](c, func(scope *di.Scope) (*flag.FlagSet, error) {
+		fs, _ := scope.Value(flagSetKey{}).(*flag.FlagSet)
+		return fs, nil
+	}, di.AsScoped(), di.WithTags("dicli")); err != nil {
+		return err
+	}
+	return di.Register1[Args](c, func(fs *flag.FlagSet) (Args, error) {
+		if fs == nil {
+			return nil, nil
+		}
+		return Args(fs.Args()), nil
+	}, di.AsScoped(), di.WithTags("dicli"))
+}
@@ -0,0 +1,127 @@
+//go:build !di_noreflect
+
+package dicli_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+	"github.com/pegasusheavy/go-dependency-injector/dicli"
+)
+
+type greeter struct{ greeting string }
+
+// session is a scoped dependency that records when it is closed.
+type session struct{ closed bool }
+
+func (s *session) Close() error {
+	s.closed = true
+	return nil
+}
+
+func newApp(t *testing.T, out *bytes.Buffer) (*dicli.App, *di.Container) {
+	t.Helper()
+	c := di.New()
+	if err := di.RegisterInstance(c, &greeter{greeting: "Hello"}); err != nil {
+		t.Fatal(err)
+	}
+	return dicli.New(c, "tool", dicli.WithOutput(out)), c
+}
+
+func TestRunResolvesParameters(t *testing.T) {
+	var out bytes.Buffer
+	app, c := newApp(t, &out)
+
+	var sessions []*session
+	di.Register[*session](c, func() *session {
+		s := &session{}
+		sessions = append(sessions, s)
+		return s
+	}, di.AsScoped())
+
+	var loud bool
+	var got string
+	app.Add(dicli.Command{
+		Name:  "greet",
+		Short: "greet someone",
+		Flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&loud, "loud", false, "shout the greeting")
+		},
+		Run: func(ctx context.Context, g *greeter, s *session, args dicli.Args) error {
+			got = g.greeting + ", " + strings.Join(args, " ")
+			if loud {
+				got = strings.ToUpper(got)
+			}
+			return nil
+		},
+	})
+
+	if err := app.Run(context.Background(), []string{"greet", "-loud", "Ada"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "HELLO, ADA" {
+		t.Errorf("expected %q, got %q", "HELLO, ADA", got)
+	}
+	if len(sessions) != 1 || !sessions[0].closed {
+		t.Errorf("expected one closed scoped session, got %+v", sessions)
+	}
+}
+
+func TestRunReturnsCommandError(t *testing.T) {
+	var out bytes.Buffer
+	app, _ := newApp(t, &out)
+	boom := errors.New("boom")
+	app.Add(dicli.Command{Name: "fail", Run: func() error { return boom }})
+
+	if err := app.Run(context.Background(), []string{"fail"}); !errors.Is(err, boom) {
+		t.Errorf("expected command error, got %v", err)
+	}
+}
+
+func TestRunMissingDependency(t *testing.T) {
+	var out bytes.Buffer
+	app, _ := newApp(t, &out)
+	app.Add(dicli.Command{Name: "broken", Run: func(s *session) {}})
+
+	var notRegistered di.ErrNotRegistered
+	if err := app.Run(context.Background(), []string{"broken"}); !errors.As(err, &notRegistered) {
+		t.Errorf("expected ErrNotRegistered, got %v", err)
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	var out bytes.Buffer
+	app, _ := newApp(t, &out)
+	app.Add(dicli.Command{Name: "greet", Short: "greet someone", Run: func() {}})
+
+	if err := app.Run(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp, got %v", err)
+	}
+	if !strings.Contains(out.String(), "greet") || !strings.Contains(out.String(), "greet someone") {
+		t.Errorf("expected usage to list commands, got %q", out.String())
+	}
+
+	out.Reset()
+	err := app.Run(context.Background(), []string{"unknown"})
+	if err == nil || !strings.Contains(err.Error(), `unknown command "unknown"`) {
+		t.Errorf("expected unknown command error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "Usage: tool") {
+		t.Errorf("expected usage output, got %q", out.String())
+	}
+}
+
+func TestRunFlagError(t *testing.T) {
+	var out bytes.Buffer
+	app, _ := newApp(t, &out)
+	app.Add(dicli.Command{Name: "greet", Run: func() {}})
+
+	if err := app.Run(context.Background(), []string{"greet", "-nope"}); err == nil {
+		t.Error("expected flag parse error")
+	}
+}
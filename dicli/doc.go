@@ -0,0 +1,27 @@
+// Package dicli builds command-line tools whose subcommands are wired by a di
+// container.
+//
+// Each subcommand has a flag.FlagSet and a run function. The run function's
+// parameters are resolved from the container, like the parameters of a
+// factory, in a scope created for the invocation and disposed when it returns:
+//
+//	app := dicli.New(c, "tool")
+//	app.Add(dicli.Command{
+//	    Name:  "migrate",
+//	    Short: "apply database migrations",
+//	    Flags: func(fs *flag.FlagSet) {
+//	        fs.BoolVar(&dryRun, "dry-run", false, "print the migrations only")
+//	    },
+//	    Run: func(ctx context.Context, db *sql.DB, args dicli.Args) error {
+//	        return migrate(ctx, db, dryRun, args)
+//	    },
+//	})
+//
+//	if err := app.Run(ctx, os.Args[1:]); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// This gives CLI tools the same ergonomics as HTTP handlers wrapped by
+// dihttp.Middleware: scoped dependencies such as transactions are created per
+// invocation and closed when it finishes.
+package dicli
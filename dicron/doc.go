@@ -0,0 +1,19 @@
+// Package dicron runs background jobs resolved from a di container.
+//
+// Jobs are registered in the container together with a schedule. Every
+// execution resolves the job in a fresh scope, so scoped dependencies such as
+// database transactions or unit-of-work objects are created per run and
+// disposed when the run finishes:
+//
+//	di.Register[*sql.Tx](c, beginTx, di.AsScoped())
+//	dicron.Register[*CleanupJob](c, NewCleanupJob, dicron.Every(time.Hour))
+//
+//	if err := c.Start(ctx); err != nil { // starts the scheduler
+//	    log.Fatal(err)
+//	}
+//	defer c.Stop(context.Background())
+//
+// The scheduler is a [di.HostedService] registered in the container, so it is
+// started and stopped with the rest of the application by [di.Container.Start]
+// and [di.Container.Stop].
+package dicron
@@ -0,0 +1,269 @@
+package dicron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// Job is a unit of background work.
+//
+// A job is resolved from the container in a fresh scope for every execution,
+// so it can depend on scoped services as well as singletons.
+type Job interface {
+	// Run performs one execution of the job. The context is cancelled when the
+	// scheduler stops or the execution's timeout (see [WithTimeout]) expires.
+	Run(ctx context.Context) error
+}
+
+// Schedule decides when a job runs.
+type Schedule interface {
+	// Next returns the next execution time after the given time. Returning the
+	// zero time stops scheduling the job.
+	Next(after time.Time) time.Time
+}
+
+// Every returns a schedule that runs a job at a fixed interval, starting one
+// interval after the scheduler starts.
+//
+// Executions of the same job never overlap; the interval is measured from the
+// end of one execution to the start of the next.
+func Every(interval time.Duration) Schedule {
+	return everySchedule(interval)
+}
+
+type everySchedule time.Duration
+
+func (s everySchedule) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(s))
+}
+
+// Option configures a job registered with [Register].
+type Option func(*job)
+
+// WithTimeout limits each execution of the job to d. The context passed to
+// [Job.Run] is cancelled when the timeout expires.
+func WithTimeout(d time.Duration) Option {
+	return func(j *job) {
+		j.timeout = d
+	}
+}
+
+// OnError sets a function that is called when an execution of the job fails,
+// panics, or fails to dispose its scope. Without it, failures are dropped.
+//
+// Example:
+//
+//	dicron.Register[*CleanupJob](c, NewCleanupJob, dicron.Every(time.Hour),
+//	    dicron.OnError(func(err error) {
+//	        log.Printf("cleanup failed: %v", err)
+//	    }))
+func OnError(fn func(err error)) Option {
+	return func(j *job) {
+		j.onError = fn
+	}
+}
+
+// Register registers a job in the container and schedules it.
+//
+// The factory is registered for T as a scoped dependency, with the same
+// signature rules as [di.Register]. A func() (T, error) factory is registered
+// with [di.Register0] instead, so it also works in builds with the
+// di_noreflect tag. Each execution creates a new scope, resolves T in it,
+// calls [Job.Run], and then disposes the scope, closing any scoped instances
+// that implement io.Closer.
+//
+// Jobs are run by the container's [Scheduler], which is registered in the
+// container on first use and started by [di.Container.Start]. Jobs registered
+// after the scheduler has started are not run until it is restarted. Each job
+// type can be registered once.
+//
+// Example:
+//
+//	dicron.Register[*ReportJob](c, func(db *sql.DB, mailer Mailer) *ReportJob {
+//	    return &ReportJob{db: db, mailer: mailer}
+//	}, dicron.Every(24*time.Hour), dicron.WithTimeout(10*time.Minute))
+func Register[T Job](c *di.Container, factory any, schedule Schedule, opts ...Option) error {
+	var zero T
+	jobType := reflect.TypeOf(&zero).Elem()
+
+	if schedule == nil {
+		return fmt.Errorf("dicron: job %s has no schedule", jobType)
+	}
+
+	scheduler, err := schedulerFor(c)
+	if err != nil {
+		return err
+	}
+
+	if typed, ok := factory.(func() (T, error)); ok {
+		err = di.Register0[T](c, typed, di.AsScoped(), di.WithTags("dicron"))
+	} else {
+		err = di.Register[T](c, factory, di.AsScoped(), di.WithTags("dicron"))
+	}
+	if err != nil {
+		return err
+	}
+
+	j := &job{
+		typ:      jobType,
+		schedule: schedule,
+		resolve: func(scope *di.Scope) (Job, error) {
+			return di.ResolveInScope[T](c, scope)
+		},
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	scheduler.mu.Lock()
+	scheduler.jobs = append(scheduler.jobs, j)
+	scheduler.mu.Unlock()
+	return nil
+}
+
+// registerMu serializes the creation of schedulers by Register.
+var registerMu sync.Mutex
+
+// schedulerFor returns the container's scheduler, registering one if needed.
+func schedulerFor(c *di.Container) (*Scheduler, error) {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	if di.Has[*Scheduler](c) {
+		return di.Resolve[*Scheduler](c)
+	}
+
+	scheduler := &Scheduler{container: c}
+	if err := di.RegisterInstance(c, scheduler); err != nil {
+		return nil, err
+	}
+	return scheduler, nil
+}
+
+// Scheduler runs the jobs registered in a container.
+//
+// A container's scheduler is created and registered by [Register]; it is a
+// [di.HostedService], so [di.Container.Start] and [di.Container.Stop] start
+// and stop it along with the application's other hosted services.
+type Scheduler struct {
+	container *di.Container
+
+	mu      sync.Mutex
+	jobs    []*job
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// job is a registered job and its schedule.
+type job struct {
+	typ      reflect.Type
+	schedule Schedule
+	timeout  time.Duration
+	onError  func(error)
+	resolve  func(scope *di.Scope) (Job, error)
+	runs     atomic.Uint64
+}
+
+// Start begins running the registered jobs on their schedules. The context
+// only bounds startup; jobs keep running until [Scheduler.Stop] is called.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, j := range s.jobs {
+		s.running.Add(1)
+		go s.loop(runCtx, j)
+	}
+	return nil
+}
+
+// Stop cancels running executions and stops scheduling new ones. It waits for
+// in-flight executions to finish until ctx is done.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loop runs a job on its schedule until ctx is cancelled.
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	defer s.running.Done()
+
+	next := j.schedule.Next(time.Now())
+	for !next.IsZero() {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := s.execute(ctx, j); err != nil && j.onError != nil {
+			j.onError(err)
+		}
+		next = j.schedule.Next(time.Now())
+	}
+}
+
+// execute runs one execution of a job in a fresh scope.
+func (s *Scheduler) execute(ctx context.Context, j *job) (err error) {
+	if j.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.timeout)
+		defer cancel()
+	}
+
+	scope := s.container.CreateScope(fmt.Sprintf("dicron/%s/%d", j.typ, j.runs.Add(1)))
+	defer func() {
+		if disposeErr := scope.Dispose(); disposeErr != nil {
+			err = errors.Join(err, fmt.Errorf("dicron: disposing scope of %s: %w", j.typ, disposeErr))
+		}
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("dicron: job %s panicked: %v", j.typ, r)
+		}
+	}()
+
+	instance, err := j.resolve(scope)
+	if err != nil {
+		return fmt.Errorf("dicron: resolving job %s: %w", j.typ, err)
+	}
+	if err := instance.Run(ctx); err != nil {
+		return fmt.Errorf("dicron: job %s: %w", j.typ, err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+//go:build !di_noreflect
+
+package dicron_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+	"github.com/pegasusheavy/go-dependency-injector/dicron"
+)
+
+// unitOfWork is a scoped dependency that records when it is closed.
+type unitOfWork struct {
+	id     int64
+	closed atomic.Bool
+}
+
+func (u *unitOfWork) Close() error {
+	u.closed.Store(true)
+	return nil
+}
+
+type recordingJob struct {
+	uow *unitOfWork
+	log *runLog
+}
+
+func (j *recordingJob) Run(ctx context.Context) error {
+	j.log.add(j.uow)
+	return nil
+}
+
+type runLog struct {
+	mu   sync.Mutex
+	uows []*unitOfWork
+}
+
+func (l *runLog) add(u *unitOfWork) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.uows = append(l.uows, u)
+}
+
+func (l *runLog) snapshot() []*unitOfWork {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]*unitOfWork(nil), l.uows...)
+}
+
+type failingJob struct{}
+
+func (failingJob) Run(ctx context.Context) error { return errors.New("boom") }
+
+// waitFor polls cond until it returns true or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestScheduledJobsRunInFreshScopes(t *testing.T) {
+	c := di.New()
+	log := &runLog{}
+	var ids atomic.Int64
+
+	di.RegisterInstance(c, log)
+	di.Register[*unitOfWork](c, func() *unitOfWork {
+		return &unitOfWork{id: ids.Add(1)}
+	}, di.AsScoped())
+	err := dicron.Register[*recordingJob](c, func(u *unitOfWork, l *runLog) *recordingJob {
+		return &recordingJob{uow: u, log: l}
+	}, dicron.Every(time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	waitFor(t, func() bool { return len(log.snapshot()) >= 3 })
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("unexpected stop error: %v", err)
+	}
+
+	runs := log.snapshot()
+	seen := make(map[int64]bool)
+	for _, u := range runs {
+		if seen[u.id] {
+			t.Errorf("unit of work %d reused across executions", u.id)
+		}
+		seen[u.id] = true
+		if !u.closed.Load() {
+			t.Errorf("unit of work %d was not disposed", u.id)
+		}
+	}
+
+	// No more executions after Stop
+	time.Sleep(5 * time.Millisecond)
+	if after := len(log.snapshot()); after != len(runs) {
+		t.Errorf("expected no runs after stop, got %d more", after-len(runs))
+	}
+}
+
+func TestJobErrorsAreReported(t *testing.T) {
+	c := di.New()
+	errs := make(chan error, 10)
+
+	dicron.Register[failingJob](c, func() failingJob { return failingJob{} }, dicron.Every(time.Millisecond),
+		dicron.OnError(func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}))
+
+	c.Start(context.Background())
+	defer c.Stop(context.Background())
+
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "boom") {
+			t.Errorf("expected job error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected job error to be reported")
+	}
+}
+
+func TestRegisterRequiresSchedule(t *testing.T) {
+	c := di.New()
+	if err := dicron.Register[failingJob](c, func() failingJob { return failingJob{} }, nil); err == nil {
+		t.Error("expected error for missing schedule")
+	}
+}
@@ -0,0 +1,189 @@
+package dihttp
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// DebugHandler returns an http.Handler serving a dashboard for the container.
+//
+// The dashboard lists every registration with its lifetime, tags, implementation
+// type, dependencies, and resolution statistics. Browsers receive an HTML page;
+// requests with ?format=json or an Accept header of application/json receive the
+// same data as JSON for use by scripts and other tooling.
+//
+// Example:
+//
+//	if cfg.Environment == "staging" {
+//	    mux.Handle("/debug/container", dihttp.DebugHandler(container))
+//	}
+func DebugHandler(c *di.Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := buildDebugReport(c)
+
+		if wantsJSON(r) {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_ = json.NewEncoder(w).Encode(report)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := debugTemplate.Execute(w, report); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// debugReport is the data rendered by DebugHandler.
+type debugReport struct {
+	Totals        debugTotals         `json:"totals"`
+	Registrations []debugRegistration `json:"registrations"`
+}
+
+// debugTotals summarizes container-wide statistics.
+type debugTotals struct {
+	Registrations int    `json:"registrations"`
+	Resolutions   uint64 `json:"resolutions"`
+	CacheHits     uint64 `json:"cache_hits"`
+	Constructions uint64 `json:"constructions"`
+	Errors        uint64 `json:"errors"`
+}
+
+// debugRegistration describes a single registration on the dashboard.
+type debugRegistration struct {
+	Type           string   `json:"type"`
+	Name           string   `json:"name"`
+	Lifetime       string   `json:"lifetime"`
+	Tags           []string `json:"tags"`
+	Implementation string   `json:"implementation,omitempty"`
+	Instance       bool     `json:"instance"`
+	Dependencies   []string `json:"dependencies"`
+	Resolutions    uint64   `json:"resolutions"`
+	CacheHits      uint64   `json:"cache_hits"`
+	Constructions  uint64   `json:"constructions"`
+	Errors         uint64   `json:"errors"`
+	AvgFactoryTime string   `json:"avg_factory_time"`
+	MaxFactoryTime string   `json:"max_factory_time"`
+}
+
+// buildDebugReport joins the container's registrations with its statistics.
+func buildDebugReport(c *di.Container) debugReport {
+	type statsKey struct {
+		typ  reflect.Type
+		name string
+	}
+
+	stats := c.Stats()
+	byKey := make(map[statsKey]di.RegistrationStats, len(stats.Registrations))
+	for _, s := range stats.Registrations {
+		byKey[statsKey{s.Type, s.Name}] = s
+	}
+
+	infos := c.Registrations()
+	report := debugReport{
+		Totals: debugTotals{
+			Registrations: len(infos),
+			Resolutions:   stats.Resolutions,
+			CacheHits:     stats.CacheHits,
+			Constructions: stats.Constructions,
+			Errors:        stats.Errors,
+		},
+		Registrations: make([]debugRegistration, len(infos)),
+	}
+
+	for i, info := range infos {
+		s := byKey[statsKey{info.Type, info.Name}]
+
+		reg := debugRegistration{
+			Type:           info.Type.String(),
+			Name:           info.Name,
+			Lifetime:       info.Lifetime.String(),
+			Tags:           append([]string{}, info.Tags...),
+			Instance:       info.Instance,
+			Dependencies:   make([]string, len(info.Dependencies)),
+			Resolutions:    s.Resolutions,
+			CacheHits:      s.CacheHits,
+			Constructions:  s.Constructions,
+			Errors:         s.Errors,
+			MaxFactoryTime: s.MaxFactoryDuration.String(),
+		}
+		if info.ImplType != nil {
+			reg.Implementation = info.ImplType.String()
+		}
+		for j, dep := range info.Dependencies {
+			reg.Dependencies[j] = dep.String()
+		}
+		if s.Constructions > 0 {
+			reg.AvgFactoryTime = (s.TotalFactoryDuration / time.Duration(s.Constructions)).String()
+		} else {
+			reg.AvgFactoryTime = "0s"
+		}
+
+		report.Registrations[i] = reg
+	}
+
+	return report
+}
+
+// wantsJSON reports whether the request asks for JSON rather than HTML.
+func wantsJSON(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format == "json"
+	}
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+var debugTemplate = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Container Dashboard</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+code { font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Container Dashboard</h1>
+<p>
+{{.Totals.Registrations}} registrations &middot;
+{{.Totals.Resolutions}} resolutions &middot;
+{{.Totals.CacheHits}} cache hits &middot;
+{{.Totals.Constructions}} constructions &middot;
+{{.Totals.Errors}} errors
+&middot; <a href="?format=json">JSON</a>
+</p>
+<table>
+<tr>
+<th>Type</th><th>Name</th><th>Lifetime</th><th>Tags</th><th>Dependencies</th>
+<th>Resolutions</th><th>Cache hits</th><th>Constructions</th><th>Errors</th>
+<th>Avg time</th><th>Max time</th>
+</tr>
+{{range .Registrations}}
+<tr>
+<td><code>{{.Type}}</code>{{if .Implementation}} &rarr; <code>{{.Implementation}}</code>{{end}}{{if .Instance}} (instance){{end}}</td>
+<td>{{.Name}}</td>
+<td>{{.Lifetime}}</td>
+<td>{{range $i, $t := .Tags}}{{if $i}}, {{end}}{{$t}}{{end}}</td>
+<td>{{range .Dependencies}}<code>{{.}}</code><br>{{end}}</td>
+<td>{{.Resolutions}}</td>
+<td>{{.CacheHits}}</td>
+<td>{{.Constructions}}</td>
+<td>{{.Errors}}</td>
+<td>{{.AvgFactoryTime}}</td>
+<td>{{.MaxFactoryTime}}</td>
+</tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,89 @@
+//go:build !di_noreflect
+
+package dihttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+	"github.com/pegasusheavy/go-dependency-injector/dihttp"
+)
+
+type Logger interface {
+	Log(msg string)
+}
+
+type testLogger struct{}
+
+func (l *testLogger) Log(string) {}
+
+type Service struct {
+	Logger Logger
+}
+
+func newTestContainer() *di.Container {
+	c := di.New()
+	di.Register[Logger](c, func() Logger { return &testLogger{} }, di.AsSingleton(), di.WithTags("infra"))
+	di.Register[*Service](c, func(log Logger) *Service { return &Service{Logger: log} })
+	di.MustResolve[*Service](c)
+	return c
+}
+
+func TestDebugHandlerHTML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	dihttp.DebugHandler(newTestContainer()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected HTML content type, got %s", ct)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{"Container Dashboard", "dihttp_test.Logger", "*dihttp_test.Service", "infra", "Singleton"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected page to contain %q", want)
+		}
+	}
+}
+
+func TestDebugHandlerJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	dihttp.DebugHandler(newTestContainer()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug?format=json", nil))
+
+	var report struct {
+		Totals struct {
+			Registrations int    `json:"registrations"`
+			Resolutions   uint64 `json:"resolutions"`
+		} `json:"totals"`
+		Registrations []struct {
+			Type         string   `json:"type"`
+			Lifetime     string   `json:"lifetime"`
+			Tags         []string `json:"tags"`
+			Dependencies []string `json:"dependencies"`
+			Resolutions  uint64   `json:"resolutions"`
+		} `json:"registrations"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if report.Totals.Registrations != 2 || report.Totals.Resolutions != 2 {
+		t.Errorf("unexpected totals: %+v", report.Totals)
+	}
+
+	service := report.Registrations[1]
+	if service.Type != "*dihttp_test.Service" {
+		t.Fatalf("unexpected ordering: %+v", report.Registrations)
+	}
+	if len(service.Dependencies) != 1 || service.Dependencies[0] != "dihttp_test.Logger" {
+		t.Errorf("expected Service to depend on Logger, got %v", service.Dependencies)
+	}
+
+	logger := report.Registrations[0]
+	if logger.Lifetime != "Singleton" || len(logger.Tags) != 1 || logger.Tags[0] != "infra" {
+		t.Errorf("unexpected logger entry: %+v", logger)
+	}
+}
@@ -0,0 +1,25 @@
+// Package dihttp provides net/http integration for the di container.
+//
+// [Middleware] gives every request its own scope, so scoped dependencies live
+// exactly as long as the request, and can register a request logger that tags
+// every log line with the request ID:
+//
+//	handler := dihttp.Middleware(container, dihttp.WithRequestLogger(slog.Default()))(mux)
+//
+// [DebugHandler] serves a dashboard that lists a container's registrations,
+// their lifetimes and tags, the dependency graph formed by their factories,
+// and live resolution statistics:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/debug/container", dihttp.DebugHandler(container))
+//
+// The dashboard exposes the application's internal wiring, so it should only
+// be mounted in staging environments or behind authentication.
+//
+// [HealthHandler] and [ReadyHandler] serve liveness and readiness probes from
+// the container's health checks and lifecycle state. They report status only;
+// [WithErrorDetails] adds the error messages:
+//
+//	mux.Handle("/healthz", dihttp.HealthHandler(container))
+//	mux.Handle("/readyz", dihttp.ReadyHandler(container))
+package dihttp
@@ -0,0 +1,133 @@
+package dihttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// HealthOption configures [HealthHandler] and [ReadyHandler].
+type HealthOption func(*healthConfig)
+
+type healthConfig struct {
+	details bool
+}
+
+// WithErrorDetails includes the error messages of failing checks in health
+// responses, and the reason in readiness responses. They are left out by
+// default because errors from health checks and hosted services can embed
+// internal addresses or credentials, and probe endpoints are often reachable
+// from outside; enable details only on endpoints served to operators.
+func WithErrorDetails() HealthOption {
+	return func(cfg *healthConfig) {
+		cfg.details = true
+	}
+}
+
+// newHealthConfig applies opts to the default configuration.
+func newHealthConfig(opts []HealthOption) healthConfig {
+	var cfg healthConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// HealthHandler returns an http.Handler serving the aggregated health checks
+// of the container's components (see [di.Container.CheckHealth]), suitable
+// for a liveness probe.
+//
+// It responds 200 OK when every check passes and 503 Service Unavailable
+// otherwise, with a JSON body listing each check's status and duration; error
+// messages are included only with [WithErrorDetails]. Checks run with the
+// request's context, so a probe timeout bounds them.
+//
+// Example:
+//
+//	mux.Handle("/healthz", dihttp.HealthHandler(container))
+//	admin.Handle("/healthz", dihttp.HealthHandler(container, dihttp.WithErrorDetails()))
+func HealthHandler(c *di.Container, opts ...HealthOption) http.Handler {
+	cfg := newHealthConfig(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.CheckHealth(r.Context())
+
+		body := healthBody{Status: "ok", Checks: make([]healthCheck, len(report.Results))}
+		for i, result := range report.Results {
+			check := healthCheck{
+				Type:            result.Type.String(),
+				Name:            result.Name,
+				Status:          "ok",
+				DurationSeconds: result.Duration.Seconds(),
+			}
+			if result.Err != nil {
+				check.Status = "failing"
+				if cfg.details {
+					check.Error = result.Err.Error()
+				}
+			}
+			body.Checks[i] = check
+		}
+
+		status := http.StatusOK
+		if !report.Healthy() {
+			status = http.StatusServiceUnavailable
+			body.Status = "unavailable"
+		}
+		writeJSON(w, status, body)
+	})
+}
+
+// ReadyHandler returns an http.Handler serving the container's readiness
+// (see [di.Container.CheckReady]), suitable for a readiness probe.
+//
+// It responds 200 OK once [di.Container.Start] has completed and every hosted
+// service is running, and 503 Service Unavailable otherwise. The reason is
+// included only with [WithErrorDetails].
+//
+// Example:
+//
+//	mux.Handle("/readyz", dihttp.ReadyHandler(container))
+func ReadyHandler(c *di.Container, opts ...HealthOption) http.Handler {
+	cfg := newHealthConfig(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := c.CheckReady(); err != nil {
+			body := readyBody{Status: "not ready"}
+			if cfg.details {
+				body.Reason = err.Error()
+			}
+			writeJSON(w, http.StatusServiceUnavailable, body)
+			return
+		}
+		writeJSON(w, http.StatusOK, readyBody{Status: "ready"})
+	})
+}
+
+// healthBody is the JSON document served by HealthHandler.
+type healthBody struct {
+	Status string        `json:"status"`
+	Checks []healthCheck `json:"checks"`
+}
+
+// healthCheck describes the outcome of one health check.
+type healthCheck struct {
+	Type            string  `json:"type"`
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	Error           string  `json:"error,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// readyBody is the JSON document served by ReadyHandler.
+type readyBody struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,86 @@
+package dihttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+	"github.com/pegasusheavy/go-dependency-injector/dihttp"
+)
+
+type checkedClient struct{ err error }
+
+func (c *checkedClient) CheckHealth(context.Context) error { return c.err }
+
+func TestHealthHandler(t *testing.T) {
+	c := di.New()
+	client := &checkedClient{}
+	di.RegisterInstance[*checkedClient](c, client)
+
+	rec := httptest.NewRecorder()
+	dihttp.HealthHandler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rec.Code)
+	}
+
+	client.err = errors.New("timeout")
+	rec = httptest.NewRecorder()
+	dihttp.HealthHandler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", rec.Code)
+	}
+
+	var body struct {
+		Status string `json:"status"`
+		Checks []struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"checks"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body.Status != "unavailable" || len(body.Checks) != 1 || body.Checks[0].Status != "failing" || body.Checks[0].Error != "" {
+		t.Errorf("unexpected body %+v", body)
+	}
+
+	rec = httptest.NewRecorder()
+	dihttp.HealthHandler(c, dihttp.WithErrorDetails()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(body.Checks) != 1 || body.Checks[0].Error != "timeout" {
+		t.Errorf("expected the error with details enabled, got %+v", body)
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	c := di.New()
+
+	rec := httptest.NewRecorder()
+	dihttp.ReadyHandler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 before Start, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "reason") {
+		t.Errorf("expected no reason without details, got %s", rec.Body)
+	}
+	rec = httptest.NewRecorder()
+	dihttp.ReadyHandler(c, dihttp.WithErrorDetails()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if !strings.Contains(rec.Body.String(), "reason") {
+		t.Errorf("expected the reason with details enabled, got %s", rec.Body)
+	}
+
+	c.Start(context.Background())
+	defer c.Stop(context.Background())
+	rec = httptest.NewRecorder()
+	dihttp.ReadyHandler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 after Start, got %d: %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,154 @@
+package dihttp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// RequestIDHeader is the header Middleware returns the request ID in, and
+// reads the client's own request ID from.
+const RequestIDHeader = "X-Request-ID"
+
+// maxClientRequestIDLength is the length client request IDs are cut to.
+const maxClientRequestIDLength = 64
+
+// RequestIDKey is the scope value key under which Middleware stores the
+// request ID it generated:
+//
+//	id, _ := scope.Value(dihttp.RequestIDKey{}).(string)
+type RequestIDKey struct{}
+
+// ClientRequestIDKey is the scope value key under which Middleware stores the
+// request ID the client sent in the X-Request-ID header, if any. It is cut to
+// 64 characters and stripped of everything but ASCII letters, digits, and
+// "-", "_", ".", and ":", so it can be logged safely, but it is not unique
+// and must not be used to identify the request.
+type ClientRequestIDKey struct{}
+
+// Option configures [Middleware].
+type Option func(*middlewareConfig)
+
+type middlewareConfig struct {
+	logger    *slog.Logger
+	logFields []di.LogField
+}
+
+// WithRequestLogger registers a scoped *slog.Logger derived from base (see
+// [di.RegisterScopedLogger]) that carries the request ID as the "request_id"
+// attribute, and the client's request ID, if it sent one, as the
+// "client_request_id" attribute, so every scoped service that depends on
+// *slog.Logger logs with the request's correlation IDs.
+func WithRequestLogger(base *slog.Logger) Option {
+	return func(cfg *middlewareConfig) {
+		if base == nil {
+			base = slog.Default()
+		}
+		cfg.logger = base
+	}
+}
+
+// WithLogField adds a scope value to the request logger's attributes. It only
+// has an effect together with [WithRequestLogger]. Handlers or earlier
+// middleware set the value on the request scope before resolving services.
+func WithLogField(key any, attr string) Option {
+	return func(cfg *middlewareConfig) {
+		cfg.logFields = append(cfg.logFields, di.LogField{Key: key, Attr: attr})
+	}
+}
+
+// Middleware returns HTTP middleware that gives every request its own scope.
+//
+// The scope is named after a request ID generated for the request, which is
+// stored in the scope under [RequestIDKey] and returned in the X-Request-ID
+// response header. A request ID sent by the client is never used to name the
+// scope, since clients can send the same one on concurrent requests; it is
+// kept, sanitized, under [ClientRequestIDKey]. Handlers find the scope with
+// [ScopeFromContext]. The request's context becomes the scope's context (see
+// [di.Scope.SetContext]), so factories of services resolved in the scope that
+// take a context.Context receive it. The scope is disposed when the handler
+// returns.
+//
+// Middleware panics if an option's registration is rejected by the container,
+// for example in strict mode when *slog.Logger is already registered.
+//
+// Example:
+//
+//	handler := dihttp.Middleware(container, dihttp.WithRequestLogger(slog.Default()))(mux)
+//
+//	mux.HandleFunc("/orders", func(w http.ResponseWriter, r *http.Request) {
+//	    scope := dihttp.ScopeFromContext(r.Context())
+//	    orders, _ := di.ResolveInScope[*OrderService](container, scope)
+//	    // orders logs with request_id=...
+//	})
+func Middleware(c *di.Container, opts ...Option) func(http.Handler) http.Handler {
+	var cfg middlewareConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.logger != nil {
+		fields := append([]di.LogField{
+			{Key: RequestIDKey{}, Attr: "request_id"},
+			{Key: ClientRequestIDKey{}, Attr: "client_request_id"},
+		}, cfg.logFields...)
+		if err := di.RegisterScopedLogger(c, cfg.logger, fields...); err != nil {
+			panic(fmt.Sprintf("dihttp: registering request logger: %v", err))
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := newRequestID()
+			w.Header().Set(RequestIDHeader, requestID)
+
+			scope := c.CreateScope("request-" + requestID)
+			scope.SetValue(RequestIDKey{}, requestID)
+			if clientID := sanitizeRequestID(r.Header.Get(RequestIDHeader)); clientID != "" {
+				scope.SetValue(ClientRequestIDKey{}, clientID)
+			}
+			defer scope.Dispose()
+
+			ctx := context.WithValue(r.Context(), scopeKey{}, scope)
+			scope.SetContext(ctx)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// scopeKey is the context key for the request scope.
+type scopeKey struct{}
+
+// ScopeFromContext returns the request scope created by [Middleware], or nil
+// if the context does not carry one.
+func ScopeFromContext(ctx context.Context) *di.Scope {
+	scope, _ := ctx.Value(scopeKey{}).(*di.Scope)
+	return scope
+}
+
+// newRequestID returns a random request ID.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// sanitizeRequestID returns the characters of a client request ID that are
+// safe to log, up to maxClientRequestIDLength of them.
+func sanitizeRequestID(id string) string {
+	var b strings.Builder
+	for i := 0; i < len(id) && b.Len() < maxClientRequestIDLength; i++ {
+		switch ch := id[i]; {
+		case 'a' <= ch && ch <= 'z', 'A' <= ch && ch <= 'Z', '0' <= ch && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == ':':
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
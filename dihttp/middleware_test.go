@@ -0,0 +1,154 @@
+//go:build !di_noreflect
+
+package dihttp_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+	"github.com/pegasusheavy/go-dependency-injector/dihttp"
+)
+
+type requestHandler struct {
+	log *slog.Logger
+}
+
+func TestMiddlewareScopePerRequest(t *testing.T) {
+	var buf bytes.Buffer
+	c := di.New()
+	di.Register[*requestHandler](c, func(log *slog.Logger) *requestHandler {
+		return &requestHandler{log: log}
+	}, di.AsScoped())
+
+	var scopes []*di.Scope
+	handler := dihttp.Middleware(c, dihttp.WithRequestLogger(slog.New(slog.NewTextHandler(&buf, nil))))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := dihttp.ScopeFromContext(r.Context())
+			scopes = append(scopes, scope)
+
+			h, err := di.ResolveInScope[*requestHandler](c, scope)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			h.log.Info("handled")
+		}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(dihttp.RequestIDHeader, "abc123")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	first := rec.Header().Get(dihttp.RequestIDHeader)
+	if first == "" || first == "abc123" {
+		t.Errorf("expected a generated request ID, got %q", first)
+	}
+	if !strings.Contains(buf.String(), "request_id="+first) || !strings.Contains(buf.String(), "client_request_id=abc123") {
+		t.Errorf("expected both request IDs in log output, got %q", buf.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	generated := rec.Header().Get(dihttp.RequestIDHeader)
+	if generated == "" || generated == first {
+		t.Errorf("expected a fresh generated request ID, got %q", generated)
+	}
+
+	if len(scopes) != 2 || scopes[0] == scopes[1] {
+		t.Fatal("expected a distinct scope per request")
+	}
+	if id := scopes[1].Value(dihttp.RequestIDKey{}); id != generated {
+		t.Errorf("expected scope to carry request ID %q, got %v", generated, id)
+	}
+}
+
+func TestMiddlewareDuplicateClientRequestIDs(t *testing.T) {
+	c := di.New()
+	entered := make(chan *di.Scope, 2)
+	release := make(chan struct{})
+	handler := dihttp.Middleware(c)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- dihttp.ScopeFromContext(r.Context())
+		<-release
+	}))
+
+	done := make(chan struct{}, 2)
+	for range 2 {
+		go func() {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(dihttp.RequestIDHeader, "same")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			done <- struct{}{}
+		}()
+	}
+	first, second := <-entered, <-entered
+	close(release)
+	<-done
+	<-done
+
+	if first.Name() == second.Name() {
+		t.Errorf("expected concurrent requests to get distinct scopes, both named %q", first.Name())
+	}
+	if first.Value(dihttp.ClientRequestIDKey{}) != "same" || second.Value(dihttp.ClientRequestIDKey{}) != "same" {
+		t.Error("expected both scopes to keep the client request ID")
+	}
+}
+
+func TestMiddlewareSanitizesClientRequestID(t *testing.T) {
+	c := di.New()
+	var clientID any
+	handler := dihttp.Middleware(c)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID = dihttp.ScopeFromContext(r.Context()).Value(dihttp.ClientRequestIDKey{})
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(dihttp.RequestIDHeader, "id=\"x\" level=ERROR "+strings.Repeat("a", 100))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if clientID != "idxlevelERROR"+strings.Repeat("a", 51) {
+		t.Errorf("expected a sanitized client request ID of 64 characters, got %q", clientID)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(dihttp.RequestIDHeader, "\"= ")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if clientID != nil {
+		t.Errorf("expected no client request ID when nothing is left after sanitizing, got %q", clientID)
+	}
+}
+
+func TestScopeFromContextWithoutMiddleware(t *testing.T) {
+	if scope := dihttp.ScopeFromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()); scope != nil {
+		t.Error("expected no scope without middleware")
+	}
+}
+
+type requestContextKey struct{}
+
+type requestUser struct {
+	name any
+}
+
+func TestMiddlewareInjectsRequestContext(t *testing.T) {
+	c := di.New()
+	di.Register[*requestUser](c, func(ctx context.Context) *requestUser {
+		return &requestUser{name: ctx.Value(requestContextKey{})}
+	}, di.AsScoped())
+
+	var user *requestUser
+	handler := dihttp.Middleware(c)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _ = di.ResolveInScope[*requestUser](c, dihttp.ScopeFromContext(r.Context()))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), requestContextKey{}, "alice"))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if user == nil || user.name != "alice" {
+		t.Errorf("expected the factory to receive the request context, got %+v", user)
+	}
+}
@@ -0,0 +1,88 @@
+package ditest
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// Auto makes c stub out unregistered interface and function types during the
+// test.
+//
+// Whenever a resolution asks for an unregistered type, Auto supplies a stub
+// instead of failing with [di.ErrNotRegistered]:
+//
+//   - Function types get a function that returns zero values.
+//   - Interface types get a value that satisfies the interface. Go reflection
+//     cannot create method bodies, so calling a method of such a stub panics;
+//     the stub only lets the service under test be constructed. Register a
+//     fake for interfaces whose methods the test exercises.
+//
+// Other types, and interfaces with unexported methods, still fail to resolve.
+// Stubs are not cached: every resolution of a missing type gets a new stub.
+// Each stubbed type is logged once through t.
+//
+// Example:
+//
+//	c := di.New()
+//	ditest.Auto(t, c)
+//	di.Register[*Checkout](c, NewCheckout) // depends on an unregistered Mailer
+//
+//	checkout := di.MustResolve[*Checkout](c)
+func Auto(t testing.TB, c *di.Container) {
+	t.Helper()
+	if err := c.AddExtension(&autoStubs{t: t, logged: make(map[reflect.Type]bool)}); err != nil {
+		t.Fatalf("ditest: %v", err)
+	}
+}
+
+// autoStubs is the extension installed by Auto.
+type autoStubs struct {
+	t      testing.TB
+	mu     sync.Mutex
+	logged map[reflect.Type]bool
+}
+
+func (a *autoStubs) Name() string { return "ditest.Auto" }
+
+func (a *autoStubs) OnMissing(typ reflect.Type, name string) (any, bool) {
+	instance, ok := stub(typ)
+	if !ok {
+		return nil, false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.logged[typ] {
+		a.logged[typ] = true
+		a.t.Logf("ditest: stubbed unregistered %v", typ)
+	}
+	return instance, true
+}
+
+// stub creates a stub value of typ, if possible.
+func stub(typ reflect.Type) (instance any, ok bool) {
+	switch typ.Kind() {
+	case reflect.Func:
+		fn := reflect.MakeFunc(typ, func([]reflect.Value) []reflect.Value {
+			results := make([]reflect.Value, typ.NumOut())
+			for i := range results {
+				results[i] = reflect.Zero(typ.Out(i))
+			}
+			return results
+		})
+		return fn.Interface(), true
+	case reflect.Interface:
+		// StructOf panics for interfaces with unexported methods
+		defer func() {
+			if recover() != nil {
+				instance, ok = nil, false
+			}
+		}()
+		stubType := reflect.StructOf([]reflect.StructField{{Name: "Stub", Type: typ, Anonymous: true}})
+		return reflect.New(stubType).Elem().Interface(), true
+	}
+	return nil, false
+}
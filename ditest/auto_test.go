@@ -0,0 +1,99 @@
+//go:build !di_noreflect
+
+package ditest_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+	"github.com/pegasusheavy/go-dependency-injector/ditest"
+)
+
+type Mailer interface {
+	Send(to, body string) error
+}
+
+type Clock func() int64
+
+type Checkout struct {
+	mailer Mailer
+	clock  Clock
+}
+
+func NewCheckout(mailer Mailer, clock Clock) *Checkout {
+	return &Checkout{mailer: mailer, clock: clock}
+}
+
+func TestAutoStubsMissingDependencies(t *testing.T) {
+	c := di.New()
+	ditest.Auto(t, c)
+	di.Register[*Checkout](c, NewCheckout)
+
+	checkout, err := di.Resolve[*Checkout](c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checkout.mailer == nil {
+		t.Error("expected a stub mailer")
+	}
+	if checkout.clock == nil || checkout.clock() != 0 {
+		t.Error("expected a stub clock returning zero")
+	}
+}
+
+func TestAutoPrefersRegistrations(t *testing.T) {
+	c := di.New()
+	ditest.Auto(t, c)
+	di.RegisterInstance[Clock](c, func() int64 { return 42 })
+	di.Register[*Checkout](c, NewCheckout)
+
+	checkout := di.MustResolve[*Checkout](c)
+	if checkout.clock() != 42 {
+		t.Errorf("expected registered clock, got %d", checkout.clock())
+	}
+}
+
+func TestAutoLeavesConcreteTypesUnregistered(t *testing.T) {
+	c := di.New()
+	ditest.Auto(t, c)
+
+	var notRegistered di.ErrNotRegistered
+	if _, err := di.Resolve[*Checkout](c); !errors.As(err, &notRegistered) {
+		t.Errorf("expected ErrNotRegistered, got %v", err)
+	}
+}
+
+type fakeMailer struct{ sent []string }
+
+func (m *fakeMailer) Send(to, body string) error {
+	m.sent = append(m.sent, to)
+	return nil
+}
+
+func TestWithFallbackFactory(t *testing.T) {
+	mailer := &fakeMailer{}
+	fakes := map[reflect.Type]any{
+		reflect.TypeOf((*Mailer)(nil)).Elem(): mailer,
+		reflect.TypeOf(Clock(nil)):            Clock(func() int64 { return 7 }),
+	}
+	c := di.New(ditest.WithFallbackFactory(func(t reflect.Type) (any, bool) {
+		fake, ok := fakes[t]
+		return fake, ok
+	}))
+	di.Register[*Checkout](c, NewCheckout)
+
+	checkout, err := di.Resolve[*Checkout](c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checkout.mailer != mailer || checkout.clock() != 7 {
+		t.Error("expected the fakes to be injected")
+	}
+
+	var notRegistered di.ErrNotRegistered
+	if _, err := di.Resolve[*int](c); !errors.As(err, &notRegistered) {
+		t.Errorf("expected types without a fake to stay unregistered, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package ditest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// AssertAllResolved fails the test, listing every registration of c that has
+// not been resolved so far, directly or as a dependency.
+//
+// It reads the container's resolution statistics (see [di.Container.Stats]),
+// so run it after the test has exercised the container, typically deferred.
+// Registrations left unresolved by a test that wires a whole module point to
+// dead wiring or to code the test does not cover.
+//
+// Example:
+//
+//	func TestBillingModule(t *testing.T) {
+//	    c := di.New()
+//	    c.Apply(billing.Module)
+//	    defer ditest.AssertAllResolved(t, c)
+//
+//	    runScenario(t, c)
+//	}
+func AssertAllResolved(t testing.TB, c *di.Container) {
+	t.Helper()
+
+	var unresolved []string
+	for _, s := range c.Stats().Registrations {
+		if s.Resolutions > 0 {
+			continue
+		}
+		if s.Name == "" {
+			unresolved = append(unresolved, s.Type.String())
+		} else {
+			unresolved = append(unresolved, fmt.Sprintf("%s (name %q)", s.Type, s.Name))
+		}
+	}
+	if len(unresolved) > 0 {
+		t.Errorf("ditest: %d registrations were never resolved:\n\t%s",
+			len(unresolved), strings.Join(unresolved, "\n\t"))
+	}
+}
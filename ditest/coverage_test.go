@@ -0,0 +1,48 @@
+//go:build !di_noreflect
+
+package ditest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+	"github.com/pegasusheavy/go-dependency-injector/ditest"
+)
+
+// recordingT captures the failures reported to it.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertAllResolved(t *testing.T) {
+	c := di.New()
+	di.Register[Clock](c, func() Clock { return func() int64 { return 0 } })
+	di.Register[Mailer](c, func() Mailer { return &fakeMailer{} }, di.WithName("smtp"))
+	di.Register[*Checkout](c, func(clock Clock) *Checkout { return &Checkout{clock: clock} })
+	di.MustResolve[*Checkout](c)
+
+	rec := &recordingT{TB: t}
+	ditest.AssertAllResolved(rec, c)
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], `ditest_test.Mailer (name "smtp")`) {
+		t.Fatalf("expected the unresolved registration to be reported, got %q", rec.errors)
+	}
+	if strings.Contains(rec.errors[0], "Clock") {
+		t.Errorf("expected dependencies resolved through factories to count, got %q", rec.errors[0])
+	}
+
+	di.MustResolveNamed[Mailer](c, "smtp")
+	rec = &recordingT{TB: t}
+	ditest.AssertAllResolved(rec, c)
+	if len(rec.errors) != 0 {
+		t.Errorf("expected no failures once everything is resolved, got %q", rec.errors)
+	}
+}
@@ -0,0 +1,18 @@
+// Package ditest provides helpers for testing code wired by a di container.
+//
+// Unit tests often need to resolve one service without registering every
+// dependency it transitively needs. [Auto] fills those gaps with stubs so the
+// test can focus on the type under test:
+//
+//	c := di.New()
+//	ditest.Auto(t, c)
+//	di.Register[*Checkout](c, NewCheckout) // needs a Mailer, never registered
+//
+//	checkout := di.MustResolve[*Checkout](c)
+//
+// [WithFallbackFactory] fabricates missing dependencies from a test's own
+// fakes instead, such as a registry of fakes shared across a test suite.
+//
+// [AssertAllResolved] fails a test that left registrations unresolved, which
+// finds dead wiring and modules the test does not exercise.
+package ditest
@@ -0,0 +1,42 @@
+package ditest
+
+import (
+	"reflect"
+
+	"github.com/pegasusheavy/go-dependency-injector/di"
+)
+
+// WithFallbackFactory returns a container option that fabricates unregistered
+// dependencies with factory, keeping unit tests focused on the type under
+// test.
+//
+// Whenever a resolution asks for a type that has no registration, named or
+// not, factory is called with the type. If it returns true, its value is used
+// instead of failing with [di.ErrNotRegistered]; the value must be assignable
+// to the type. Results are not cached: factory is called on every miss, so it
+// should return the same fake each time if the test inspects it.
+//
+// Example:
+//
+//	fakes := map[reflect.Type]any{
+//	    reflect.TypeOf((*Mailer)(nil)).Elem(): &FakeMailer{},
+//	}
+//	c := di.New(ditest.WithFallbackFactory(func(t reflect.Type) (any, bool) {
+//	    fake, ok := fakes[t]
+//	    return fake, ok
+//	}))
+func WithFallbackFactory(factory func(t reflect.Type) (any, bool)) di.ContainerOption {
+	return func(c *di.Container) {
+		// AddExtension only fails for nil extensions and failing Init hooks
+		_ = c.AddExtension(fallbackFactory(factory))
+	}
+}
+
+// fallbackFactory is the extension installed by WithFallbackFactory.
+type fallbackFactory func(t reflect.Type) (any, bool)
+
+func (f fallbackFactory) Name() string { return "ditest.WithFallbackFactory" }
+
+func (f fallbackFactory) OnMissing(typ reflect.Type, name string) (any, bool) {
+	return f(typ)
+}